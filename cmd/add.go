@@ -1,10 +1,14 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"slices"
 	"strings"
 
 	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/metadata"
 	"github.com/airRnot1106/bkm/internal/storage"
 	"github.com/airRnot1106/bkm/internal/usecase"
 	"github.com/manifoldco/promptui"
@@ -21,7 +25,25 @@ You can use flags to specify bookmark details:
   bkm add --url https://example.com --title "Example" --description "An example site" --tags go,cli
 
 Or run without flags for interactive mode:
-  bkm add`,
+  bkm add
+
+The title and description are fetched from the page when they are not given,
+and offered as defaults in interactive mode. The page is requested only after
+tracking parameters are removed and the secrets policy is applied, so a URL
+that is refused is never sent. Disable fetching with --no-fetch:
+  bkm add --url https://example.com --no-fetch --title "Example"
+
+Tags are suggested from the tag rules in the config, the tags of bookmarks on
+the same site and existing tags found in the title and description. They are
+offered as defaults in interactive mode; add them in flag mode with --auto-tags:
+  bkm add --url https://go.dev/blog --auto-tags
+
+Tracking parameters such as utm_source or fbclid are removed from the URL.
+See "bkm clean-urls --help" for the rules.
+
+URLs with secrets, such as a token parameter, a presigned signature or a
+password, are refused. Redact the secrets or keep them with --secrets:
+  bkm add --url "https://example.com/feed?token=..." --secrets redact`,
 	RunE: runAdd,
 }
 
@@ -32,79 +54,206 @@ func init() {
 	addCmd.Flags().StringP("title", "t", "", "Title of the bookmark")
 	addCmd.Flags().StringP("description", "d", "", "Description of the bookmark")
 	addCmd.Flags().StringSliceP("tags", "T", []string{}, "Tags (comma-separated)")
+	addCmd.Flags().StringP("keyword", "k", "", "Unique keyword to open the bookmark with \"bkm open <keyword>\"")
+	addCmd.Flags().StringToString("variants", map[string]string{}, "Named alternate URLs (e.g. staging=https://...,prod=https://...)")
+	addCmd.Flags().Bool("no-fetch", false, "Do not fetch the title and description from the page")
+	addCmd.Flags().Bool("auto-tags", false, "Add suggested tags to the given ones")
+	addCmd.Flags().String("secrets", "", "What to do with secrets in the URL: refuse, redact or warn (default from config, else refuse)")
 }
 
 func runAdd(cmd *cobra.Command, args []string) error {
-	flagsProvided := cmd.Flags().Changed("url") ||
-		cmd.Flags().Changed("title") ||
-		cmd.Flags().Changed("description") ||
-		cmd.Flags().Changed("tags")
+	noFetch, err := cmd.Flags().GetBool("no-fetch")
+	if err != nil {
+		return fmt.Errorf("failed to get no-fetch flag: %w", err)
+	}
+	autoTags, err := cmd.Flags().GetBool("auto-tags")
+	if err != nil {
+		return fmt.Errorf("failed to get auto-tags flag: %w", err)
+	}
 
-	var input usecase.AddBookmarkInput
+	repo, err := storage.NewDefaultJSONStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	policy, err := newURLPolicy(cmd)
+	if err != nil {
+		return err
+	}
 
-	if flagsProvided {
-		url, err := cmd.Flags().GetString("url")
-		if err != nil {
-			return fmt.Errorf("failed to get url flag: %w", err)
-		}
-		title, err := cmd.Flags().GetString("title")
+	var input usecase.AddBookmarkInput
+	if addFlagsProvided(cmd) {
+		input, err = getAddInputFromFlags(cmd)
 		if err != nil {
-			return fmt.Errorf("failed to get title flag: %w", err)
+			return err
 		}
-		description, err := cmd.Flags().GetString("description")
-		if err != nil {
-			return fmt.Errorf("failed to get description flag: %w", err)
+		// The page is only fetched once the URL is cleaned and its secrets
+		// are handled, so that a token is never sent anywhere.
+		if input.URL, err = applyURLPolicy(policy, input.URL); err != nil {
+			return fmt.Errorf("failed to add bookmark: %w", err)
 		}
-		tags, err := cmd.Flags().GetStringSlice("tags")
-		if err != nil {
-			return fmt.Errorf("failed to get tags flag: %w", err)
+		if !noFetch {
+			fillFromMetadata(&input)
 		}
-
-		input = usecase.AddBookmarkInput{
-			URL:         url,
-			Title:       title,
-			Description: description,
-			Tags:        tags,
+		if autoTags {
+			input.Tags = mergeTags(input.Tags, suggestTags(repo, input))
 		}
 	} else {
-		var err error
-		input, err = promptForBookmark()
+		input, err = promptForBookmark(repo, policy, !noFetch)
 		if err != nil {
 			return fmt.Errorf("failed to get bookmark details: %w", err)
 		}
 	}
 
-	repo, err := storage.NewDefaultJSONStorage()
-	if err != nil {
-		return fmt.Errorf("failed to initialize storage: %w", err)
-	}
-
-	uc := usecase.NewAddBookmark(repo)
-	bm, err := uc.Execute(input)
+	bm, err := usecase.NewAddBookmark(repo, policy, commandNames()...).Execute(input)
 	if err != nil {
 		return fmt.Errorf("failed to add bookmark: %w", err)
 	}
 
 	fmt.Println("✓ Bookmark added successfully!")
 	fmt.Println()
+	printBookmarkDetails(bm)
+	return nil
+}
+
+func applyURLPolicy(policy *usecase.URLPolicy, rawURL string) (string, error) {
+	url, err := policy.Apply(rawURL)
+	if err != nil {
+		return "", err
+	}
+	return url.Value(), nil
+}
+
+func addFlagsProvided(cmd *cobra.Command) bool {
+	return cmd.Flags().Changed("url") ||
+		cmd.Flags().Changed("title") ||
+		cmd.Flags().Changed("description") ||
+		cmd.Flags().Changed("tags") ||
+		cmd.Flags().Changed("keyword") ||
+		cmd.Flags().Changed("variants")
+}
+
+func getAddInputFromFlags(cmd *cobra.Command) (usecase.AddBookmarkInput, error) {
+	url, err := cmd.Flags().GetString("url")
+	if err != nil {
+		return usecase.AddBookmarkInput{}, fmt.Errorf("failed to get url flag: %w", err)
+	}
+	title, err := cmd.Flags().GetString("title")
+	if err != nil {
+		return usecase.AddBookmarkInput{}, fmt.Errorf("failed to get title flag: %w", err)
+	}
+	description, err := cmd.Flags().GetString("description")
+	if err != nil {
+		return usecase.AddBookmarkInput{}, fmt.Errorf("failed to get description flag: %w", err)
+	}
+	tags, err := cmd.Flags().GetStringSlice("tags")
+	if err != nil {
+		return usecase.AddBookmarkInput{}, fmt.Errorf("failed to get tags flag: %w", err)
+	}
+	keyword, err := cmd.Flags().GetString("keyword")
+	if err != nil {
+		return usecase.AddBookmarkInput{}, fmt.Errorf("failed to get keyword flag: %w", err)
+	}
+	variants, err := cmd.Flags().GetStringToString("variants")
+	if err != nil {
+		return usecase.AddBookmarkInput{}, fmt.Errorf("failed to get variants flag: %w", err)
+	}
+
+	return usecase.AddBookmarkInput{
+		URL:         url,
+		Title:       title,
+		Description: description,
+		Tags:        tags,
+		Keyword:     keyword,
+		Variants:    variants,
+	}, nil
+}
+
+// fillFromMetadata fills a missing title and description from the page.
+func fillFromMetadata(input *usecase.AddBookmarkInput) {
+	if input.URL == "" || (input.Title != "" && input.Description != "") {
+		return
+	}
+
+	meta := fetchMetadata(input.URL)
+	if input.Title == "" {
+		input.Title = meta.Title
+	}
+	if input.Description == "" {
+		input.Description = meta.Description
+	}
+}
+
+// suggestTags suggests tags for the bookmark being added. Failures are
+// reported as warnings because tags can always be entered by hand.
+func suggestTags(repo bookmark.Repository, input usecase.AddBookmarkInput) []string {
+	suggester, err := newTagSuggester()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return nil
+	}
+
+	uc := usecase.NewSuggestTags(repo, suggester)
+	tags, err := uc.Execute(usecase.SuggestTagsInput{
+		URL:         input.URL,
+		Title:       input.Title,
+		Description: input.Description,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return nil
+	}
+	return tags
+}
+
+// mergeTags appends the suggested tags that are not given yet.
+func mergeTags(given, suggested []string) []string {
+	merged := slices.Clone(given)
+	for _, tag := range suggested {
+		if !slices.Contains(merged, tag) {
+			merged = append(merged, tag)
+		}
+	}
+	return merged
+}
+
+func printBookmarkDetails(bm bookmark.Bookmark) {
 	fmt.Printf("  URL:         %s\n", bm.URL.Value())
 	fmt.Printf("  Title:       %s\n", bm.Title.Value())
 	if desc := bm.Description.Value(); desc != "" {
 		fmt.Printf("  Description: %s\n", desc)
 	}
 	if len(bm.Tags) > 0 {
-		tagStrs := make([]string, len(bm.Tags))
-		for i, tag := range bm.Tags {
-			tagStrs[i] = tag.Value()
-		}
-		fmt.Printf("  Tags:        %s\n", strings.Join(tagStrs, ", "))
+		fmt.Printf("  Tags:        %s\n", strings.Join(tagValues(bm.Tags), ", "))
+	}
+	if keyword := bm.Keyword.Value(); keyword != "" {
+		fmt.Printf("  Keyword:     %s\n", keyword)
+	}
+	for _, name := range bm.VariantNames() {
+		fmt.Printf("  Variant:     %s = %s\n", name.Value(), bm.Variants[name].Value())
 	}
-	return nil
 }
 
-func promptForBookmarkURL() (string, error) {
+func variantValues(variants map[bookmark.BookmarkVariantName]bookmark.BookmarkURL) map[string]string {
+	values := make(map[string]string, len(variants))
+	for name, url := range variants {
+		values[name.Value()] = url.Value()
+	}
+	return values
+}
+
+func tagValues(tags []bookmark.BookmarkTag) []string {
+	values := make([]string, len(tags))
+	for i, tag := range tags {
+		values[i] = tag.Value()
+	}
+	return values
+}
+
+func promptForBookmarkURL(defaultValue string) (string, error) {
 	prompt := promptui.Prompt{
-		Label: "URL",
+		Label:     "URL",
+		Default:   defaultValue,
+		AllowEdit: true,
 		Validate: func(input string) error {
 			if _, err := bookmark.NewBookmarkURL(input); err != nil {
 				return err
@@ -115,9 +264,11 @@ func promptForBookmarkURL() (string, error) {
 	return prompt.Run()
 }
 
-func promptForBookmarkTitle() (string, error) {
+func promptForBookmarkTitle(defaultValue string) (string, error) {
 	prompt := promptui.Prompt{
-		Label: "Title",
+		Label:     "Title",
+		Default:   defaultValue,
+		AllowEdit: true,
 		Validate: func(input string) error {
 			if _, err := bookmark.NewBookmarkTitle(input); err != nil {
 				return err
@@ -128,16 +279,65 @@ func promptForBookmarkTitle() (string, error) {
 	return prompt.Run()
 }
 
-func promptForBookmarkDescription() (string, error) {
+func promptForBookmarkDescription(defaultValue string) (string, error) {
 	prompt := promptui.Prompt{
-		Label: "Description",
+		Label:     "Description",
+		Default:   defaultValue,
+		AllowEdit: true,
 	}
 	return prompt.Run()
 }
 
-func promptForBookmarkTags() ([]string, error) {
+// pickTags lets the user choose from the tags in use, with defaults marked up
+// front. Tags that no bookmark has yet are only kept once confirmed, so a
+// typo does not quietly become a tag of its own.
+func pickTags(repo bookmark.Repository, defaults []string) ([]string, error) {
+	counts, err := usecase.NewListTags(repo).Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	var preselected []bookmark.BookmarkTag
+	for _, name := range defaults {
+		if tag, err := bookmark.NewBookmarkTag(name); err == nil {
+			preselected = append(preselected, tag)
+		}
+	}
+
+	sel, err := newSelector()
+	if err != nil {
+		return nil, err
+	}
+	selection, err := sel.SelectTags(counts, preselected)
+	if err != nil {
+		return nil, err
+	}
+	tags := tagValues(selection.Tags)
+	if selection.CreateNew {
+		created, err := promptForNewTags()
+		if err != nil {
+			return nil, err
+		}
+		tags = mergeTags(tags, created)
+	}
+
+	existing := make(map[string]bool, len(counts))
+	for _, c := range counts {
+		existing[c.Tag.Value()] = true
+	}
+	picked := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if !existing[tag] && !confirmNewTag(tag) {
+			continue
+		}
+		picked = append(picked, tag)
+	}
+	return picked, nil
+}
+
+func promptForNewTags() ([]string, error) {
 	prompt := promptui.Prompt{
-		Label: "Tags (comma-separated)",
+		Label: "New tags (comma-separated)",
 		Validate: func(input string) error {
 			if strings.TrimSpace(input) == "" {
 				return nil
@@ -156,28 +356,91 @@ func promptForBookmarkTags() ([]string, error) {
 		return nil, err
 	}
 
-	if strings.TrimSpace(result) == "" {
-		return []string{}, nil
+	var tags []string
+	for _, tag := range strings.Split(result, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
 	}
-
-	tags := strings.Split(result, ",")
 	return tags, nil
 }
 
-func promptForBookmark() (usecase.AddBookmarkInput, error) {
-	url, err := promptForBookmarkURL()
+func confirmNewTag(tag string) bool {
+	prompt := promptui.Prompt{
+		Label:     fmt.Sprintf("Create new tag %q", tag),
+		IsConfirm: true,
+	}
+	_, err := prompt.Run()
+	return err == nil
+}
+
+func promptForBookmarkKeyword(defaultValue string) (string, error) {
+	prompt := promptui.Prompt{
+		Label:     "Keyword (optional)",
+		Default:   defaultValue,
+		AllowEdit: true,
+		Validate: func(input string) error {
+			if strings.TrimSpace(input) == "" {
+				return nil
+			}
+			keyword, err := bookmark.NewBookmarkKeyword(input)
+			if err != nil {
+				return err
+			}
+			if slices.Contains(commandNames(), keyword.Value()) {
+				return fmt.Errorf("%s is the name of a command", keyword.Value())
+			}
+			return nil
+		},
+	}
+	result, err := prompt.Run()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(result), nil
+}
+
+// fetchMetadata fetches the title and description of the page. Failures are
+// reported as warnings because the user can still enter them by hand.
+func fetchMetadata(url string) metadata.Metadata {
+	uc := usecase.NewFetchBookmarkMetadata(newFetcher())
+	meta, err := uc.Execute(usecase.FetchBookmarkMetadataInput{URL: url})
+	if err != nil {
+		if !errors.Is(err, metadata.ErrUnsupportedURL) {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		return metadata.Metadata{}
+	}
+	return meta
+}
+
+func promptForBookmark(repo bookmark.Repository, policy *usecase.URLPolicy, fetch bool) (usecase.AddBookmarkInput, error) {
+	rawURL, err := promptForBookmarkURL("")
+	if err != nil {
+		return usecase.AddBookmarkInput{}, err
+	}
+	url, err := applyURLPolicy(policy, rawURL)
+	if err != nil {
+		return usecase.AddBookmarkInput{}, err
+	}
+	var meta metadata.Metadata
+	if fetch {
+		meta = fetchMetadata(url)
+	}
+	title, err := promptForBookmarkTitle(meta.Title)
 	if err != nil {
 		return usecase.AddBookmarkInput{}, err
 	}
-	title, err := promptForBookmarkTitle()
+	description, err := promptForBookmarkDescription(meta.Description)
 	if err != nil {
 		return usecase.AddBookmarkInput{}, err
 	}
-	description, err := promptForBookmarkDescription()
+	suggested := suggestTags(repo, usecase.AddBookmarkInput{URL: url, Title: title, Description: description})
+	tags, err := pickTags(repo, suggested)
 	if err != nil {
 		return usecase.AddBookmarkInput{}, err
 	}
-	tags, err := promptForBookmarkTags()
+	keyword, err := promptForBookmarkKeyword("")
 	if err != nil {
 		return usecase.AddBookmarkInput{}, err
 	}
@@ -187,5 +450,6 @@ func promptForBookmark() (usecase.AddBookmarkInput, error) {
 		Title:       title,
 		Description: description,
 		Tags:        tags,
+		Keyword:     keyword,
 	}, nil
 }
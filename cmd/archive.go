@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/airRnot1106/bkm/internal/storage"
+	"github.com/airRnot1106/bkm/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+// archiveCmd represents the archive command
+var archiveCmd = &cobra.Command{
+	Use:   "archive",
+	Short: "Save offline copies of bookmarked pages",
+	Long: `Download bookmarked pages, with their stylesheets and images embedded, and
+keep them as offline copies. Archiving a bookmark again replaces its copy.
+
+Mark the bookmarks to archive with Tab:
+  bkm archive
+
+Or archive every bookmark, or the ones with the given tags:
+  bkm archive --all
+  bkm archive --tags docs
+
+Open the offline copy instead of the live page:
+  bkm open gh --archived`,
+	RunE: runArchive,
+}
+
+func init() {
+	rootCmd.AddCommand(archiveCmd)
+
+	archiveCmd.Flags().Bool("all", false, "Archive every bookmark")
+	archiveCmd.Flags().StringSliceP("tags", "T", []string{}, "Archive every bookmark with these tags (comma-separated)")
+}
+
+func runArchive(cmd *cobra.Command, args []string) error {
+	all, err := cmd.Flags().GetBool("all")
+	if err != nil {
+		return fmt.Errorf("failed to get all flag: %w", err)
+	}
+	tags, err := cmd.Flags().GetStringSlice("tags")
+	if err != nil {
+		return fmt.Errorf("failed to get tags flag: %w", err)
+	}
+
+	repo, err := storage.NewDefaultJSONStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	store, err := storage.NewDefaultSnapshotFileStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize snapshot storage: %w", err)
+	}
+
+	bookmarks, err := listOrSelectBookmarks(repo, all, tags)
+	if err != nil || len(bookmarks) == 0 {
+		return err
+	}
+
+	archiveUc := usecase.NewArchiveBookmark(repo, newCapturer(), store)
+	failed := 0
+	for _, bm := range bookmarks {
+		if _, err := archiveUc.Execute(usecase.ArchiveBookmarkInput{Bookmark: bm}); err != nil {
+			fmt.Fprintf(os.Stderr, "✗ %s: %v\n", bm.Title.Value(), err)
+			failed++
+			continue
+		}
+		fmt.Printf("✓ %s\n", bm.Title.Value())
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to archive %d of %d bookmarks", failed, len(bookmarks))
+	}
+	return nil
+}
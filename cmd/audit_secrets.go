@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/secretscan"
+	"github.com/airRnot1106/bkm/internal/storage"
+	"github.com/airRnot1106/bkm/internal/usecase"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+// auditSecretsCmd represents the audit-secrets command
+var auditSecretsCmd = &cobra.Command{
+	Use:   "audit-secrets",
+	Short: "Find bookmarks with secrets in their URLs",
+	Long: `Scan the URLs and URL variants of all bookmarks for secrets: parameters such
+as token or access_key, presigned S3 and GCS signatures, well-known token
+formats and passwords in the userinfo. Secrets are never printed.
+
+  bkm audit-secrets
+  bkm audit-secrets --tags work
+
+Replace the secrets with REDACTED after confirmation:
+  bkm audit-secrets --redact`,
+	RunE: runAuditSecrets,
+}
+
+func init() {
+	rootCmd.AddCommand(auditSecretsCmd)
+
+	auditSecretsCmd.Flags().StringSliceP("tags", "T", []string{}, "Audit only bookmarks with these tags (comma-separated)")
+	auditSecretsCmd.Flags().Bool("redact", false, "Replace the secrets found with REDACTED")
+	auditSecretsCmd.Flags().BoolP("yes", "y", false, "Redact without asking for confirmation")
+}
+
+func runAuditSecrets(cmd *cobra.Command, args []string) error {
+	tags, err := cmd.Flags().GetStringSlice("tags")
+	if err != nil {
+		return fmt.Errorf("failed to get tags flag: %w", err)
+	}
+	redact, err := cmd.Flags().GetBool("redact")
+	if err != nil {
+		return fmt.Errorf("failed to get redact flag: %w", err)
+	}
+	yes, err := cmd.Flags().GetBool("yes")
+	if err != nil {
+		return fmt.Errorf("failed to get yes flag: %w", err)
+	}
+
+	scanner, err := newSecretScanner()
+	if err != nil {
+		return err
+	}
+	repo, err := storage.NewDefaultJSONStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	listUc := usecase.NewListBookmarks(repo)
+	bookmarks, err := listUc.Execute(usecase.ListBookmarksInput{Tags: tags})
+	if err != nil {
+		return fmt.Errorf("failed to list bookmarks: %w", err)
+	}
+
+	findUc := usecase.NewFindSecrets(scanner)
+	audits := findUc.Execute(usecase.FindSecretsInput{Bookmarks: bookmarks})
+	if len(audits) == 0 {
+		fmt.Printf("No secrets found in %d bookmarks.\n", len(bookmarks))
+		return nil
+	}
+
+	printSecretAudits(scanner, audits)
+	if !redact {
+		fmt.Println("Run with --redact to replace the secrets.")
+		return nil
+	}
+	if !yes && !confirmRedact(len(audits)) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	redactUc := usecase.NewRedactBookmarkSecrets(repo, scanner)
+	updated, err := redactUc.Execute(usecase.RedactBookmarkSecretsInput{Bookmarks: auditedBookmarks(audits)})
+	if err != nil {
+		return fmt.Errorf("failed to redact secrets: %w", err)
+	}
+
+	fmt.Printf("✓ Redacted %d bookmarks.\n", len(updated))
+	return nil
+}
+
+func printSecretAudits(scanner *secretscan.Scanner, audits []usecase.SecretAudit) {
+	for _, audit := range audits {
+		title := audit.Bookmark.Title.Value()
+		if audit.Variant != (bookmark.BookmarkVariantName{}) {
+			title += fmt.Sprintf(" (%s)", audit.Variant.Value())
+		}
+		url, err := audit.Bookmark.URLFor(audit.Variant)
+		if err != nil {
+			continue
+		}
+		fmt.Printf("  %s\n    %s\n    %s\n", title, scanner.Redact(url.Value()), secretscan.FormatFindings(audit.Findings))
+	}
+	fmt.Println()
+}
+
+// auditedBookmarks returns each bookmark of audits once.
+func auditedBookmarks(audits []usecase.SecretAudit) []bookmark.Bookmark {
+	var bookmarks []bookmark.Bookmark
+	seen := make(map[bookmark.BookmarkID]bool)
+	for _, audit := range audits {
+		if !seen[audit.Bookmark.ID] {
+			seen[audit.Bookmark.ID] = true
+			bookmarks = append(bookmarks, audit.Bookmark)
+		}
+	}
+	return bookmarks
+}
+
+func confirmRedact(count int) bool {
+	prompt := promptui.Prompt{
+		Label:     fmt.Sprintf("Redact secrets in %d URLs", count),
+		IsConfirm: true,
+	}
+	_, err := prompt.Run()
+	return err == nil
+}
@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/airRnot1106/bkm/internal/linkcheck"
+	"github.com/airRnot1106/bkm/internal/storage"
+	"github.com/airRnot1106/bkm/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+// defaultSlowThreshold is the response time above which a link is reported as
+// slow when neither the flag nor the config sets one.
+const defaultSlowThreshold = 3 * time.Second
+
+// checkCmd represents the check command
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Find dead, redirected and slow links",
+	Long: `Probe the URL of every bookmark and report dead, redirected and slow links.
+
+Each URL is requested with HEAD, falling back to GET when the server rejects
+HEAD. Several URLs are checked at the same time, but requests to the same host
+are spaced out. The status and time of the check are saved on the bookmark.
+
+Check only some bookmarks:
+  bkm check --tags docs
+
+Tag dead links with "dead" (and untag links that respond again):
+  bkm check --tag-dead
+
+Only 404 and 410 responses count as dead. Links that could not be reached at
+all, because of a DNS failure, a timeout or being offline, are reported as
+unreachable and keep their tags.
+
+URL templates, URLs with variables and non-HTTP URLs are skipped.`,
+	RunE: runCheck,
+}
+
+func init() {
+	rootCmd.AddCommand(checkCmd)
+
+	checkCmd.Flags().StringSliceP("tags", "T", []string{}, "Check only bookmarks with these tags (comma-separated)")
+	checkCmd.Flags().Bool("tag-dead", false, "Tag dead links with \"dead\" and untag links that respond again")
+	checkCmd.Flags().Int("concurrency", linkcheck.DefaultConcurrency, "Number of URLs checked at the same time (defaults to check.concurrency in the config)")
+	checkCmd.Flags().Duration("timeout", linkcheck.DefaultTimeout, "Time limit for checking one URL (defaults to check.timeout in the config)")
+	checkCmd.Flags().Duration("slow", defaultSlowThreshold, "Report links that take longer than this (defaults to check.slow in the config)")
+}
+
+// checkOptions control how bookmark URLs are probed.
+type checkOptions struct {
+	concurrency int
+	timeout     time.Duration
+	slow        time.Duration
+}
+
+func runCheck(cmd *cobra.Command, args []string) error {
+	tags, err := cmd.Flags().GetStringSlice("tags")
+	if err != nil {
+		return fmt.Errorf("failed to get tags flag: %w", err)
+	}
+	tagDead, err := cmd.Flags().GetBool("tag-dead")
+	if err != nil {
+		return fmt.Errorf("failed to get tag-dead flag: %w", err)
+	}
+	opts, err := getCheckOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	repo, err := storage.NewDefaultJSONStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	checkUc := usecase.NewCheckBookmarks(repo, newChecker(opts))
+	output, err := checkUc.Execute(usecase.CheckBookmarksInput{Tags: tags, TagDead: tagDead})
+	if err != nil {
+		return fmt.Errorf("failed to check bookmarks: %w", err)
+	}
+
+	printCheckReport(output, opts.slow)
+	return nil
+}
+
+// getCheckOptions reads the check flags, using the config for the ones that
+// were not set on the command line.
+func getCheckOptions(cmd *cobra.Command) (checkOptions, error) {
+	opts := configCheckOptions()
+
+	var err error
+	if cmd.Flags().Changed("concurrency") {
+		if opts.concurrency, err = cmd.Flags().GetInt("concurrency"); err != nil {
+			return checkOptions{}, fmt.Errorf("failed to get concurrency flag: %w", err)
+		}
+	}
+	if cmd.Flags().Changed("timeout") {
+		if opts.timeout, err = cmd.Flags().GetDuration("timeout"); err != nil {
+			return checkOptions{}, fmt.Errorf("failed to get timeout flag: %w", err)
+		}
+	}
+	if cmd.Flags().Changed("slow") {
+		if opts.slow, err = cmd.Flags().GetDuration("slow"); err != nil {
+			return checkOptions{}, fmt.Errorf("failed to get slow flag: %w", err)
+		}
+	}
+	return opts, nil
+}
+
+func configCheckOptions() checkOptions {
+	opts := checkOptions{
+		concurrency: appConfig.Check.Concurrency,
+		timeout:     time.Duration(appConfig.Check.Timeout),
+		slow:        time.Duration(appConfig.Check.Slow),
+	}
+	if opts.slow <= 0 {
+		opts.slow = defaultSlowThreshold
+	}
+	return opts
+}
+
+func newChecker(opts checkOptions) *linkcheck.HTTPChecker {
+	var checkerOpts []linkcheck.Option
+	if opts.concurrency > 0 {
+		checkerOpts = append(checkerOpts, linkcheck.WithConcurrency(opts.concurrency))
+	}
+	if opts.timeout > 0 {
+		checkerOpts = append(checkerOpts, linkcheck.WithTimeout(opts.timeout))
+	}
+	if interval := time.Duration(appConfig.Check.HostInterval); interval > 0 {
+		checkerOpts = append(checkerOpts, linkcheck.WithHostInterval(interval))
+	}
+	return linkcheck.NewHTTPChecker(checkerOpts...)
+}
+
+func printCheckReport(output usecase.CheckBookmarksOutput, slow time.Duration) {
+	var dead, unreachable, failing, redirected, slowChecks []usecase.BookmarkCheck
+	for _, check := range output.Checks {
+		switch {
+		case check.Result.IsDead():
+			dead = append(dead, check)
+		case check.Result.IsUnreachable():
+			unreachable = append(unreachable, check)
+		case check.Result.IsFailing():
+			failing = append(failing, check)
+		case check.Result.IsRedirected():
+			redirected = append(redirected, check)
+		}
+		if !check.Result.IsDead() && !check.Result.IsUnreachable() && check.Result.Duration > slow {
+			slowChecks = append(slowChecks, check)
+		}
+	}
+
+	printCheckSection("Dead", dead, func(c usecase.BookmarkCheck) string {
+		return describeCheckFailure(c.Result)
+	})
+	printCheckSection("Unreachable", unreachable, func(c usecase.BookmarkCheck) string {
+		return describeCheckFailure(c.Result)
+	})
+	printCheckSection("Failing", failing, func(c usecase.BookmarkCheck) string {
+		return describeCheckFailure(c.Result)
+	})
+	printCheckSection("Redirected", redirected, func(c usecase.BookmarkCheck) string {
+		return fmt.Sprintf("→ %s", c.Result.FinalURL)
+	})
+	printCheckSection("Slow", slowChecks, func(c usecase.BookmarkCheck) string {
+		return c.Result.Duration.Round(time.Millisecond).String()
+	})
+
+	fmt.Printf("Checked %d bookmarks: %d dead, %d unreachable, %d failing, %d redirected, %d slow",
+		len(output.Checks), len(dead), len(unreachable), len(failing), len(redirected), len(slowChecks))
+	if output.Skipped > 0 {
+		fmt.Printf(" (%d skipped)", output.Skipped)
+	}
+	fmt.Println()
+}
+
+func printCheckSection(heading string, checks []usecase.BookmarkCheck, detail func(usecase.BookmarkCheck) string) {
+	if len(checks) == 0 {
+		return
+	}
+	fmt.Printf("%s (%d):\n", heading, len(checks))
+	for _, check := range checks {
+		fmt.Printf("  %s\n    %s\n    %s\n", check.Bookmark.Title.Value(), check.Bookmark.URL.Value(), detail(check))
+	}
+	fmt.Println()
+}
+
+func describeCheckFailure(result linkcheck.Result) string {
+	if result.Err != nil {
+		return result.Err.Error()
+	}
+	return fmt.Sprintf("HTTP %d", result.StatusCode)
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/airRnot1106/bkm/internal/storage"
+	"github.com/airRnot1106/bkm/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+// cleanURLsCmd represents the clean-urls command
+var cleanURLsCmd = &cobra.Command{
+	Use:   "clean-urls",
+	Short: "Remove tracking parameters from bookmark URLs",
+	Long: `Remove tracking parameters such as utm_source, fbclid or gclid from the URLs
+and variants of existing bookmarks. New bookmarks are cleaned when they are added. The old
+and new URLs are shown for confirmation first.
+
+Mark the bookmarks to clean with Tab:
+  bkm clean-urls
+
+Or clean every bookmark, or the ones with the given tags:
+  bkm clean-urls --all
+  bkm clean-urls --tags reading
+
+Besides the built-in rules, parameters can be removed or kept per domain in
+the config:
+  "clean_urls": {
+    "rules": [
+      { "domain": "*.example.com", "remove": ["ref", "src_*"] },
+      { "domain": "shop.example.com", "keep": ["utm_source"] }
+    ]
+  }`,
+	RunE: runCleanURLs,
+}
+
+func init() {
+	rootCmd.AddCommand(cleanURLsCmd)
+
+	cleanURLsCmd.Flags().Bool("all", false, "Clean every bookmark")
+	cleanURLsCmd.Flags().StringSliceP("tags", "T", []string{}, "Clean every bookmark with these tags (comma-separated)")
+	cleanURLsCmd.Flags().BoolP("yes", "y", false, "Replace the URLs without asking for confirmation")
+}
+
+func runCleanURLs(cmd *cobra.Command, args []string) error {
+	all, err := cmd.Flags().GetBool("all")
+	if err != nil {
+		return fmt.Errorf("failed to get all flag: %w", err)
+	}
+	tags, err := cmd.Flags().GetStringSlice("tags")
+	if err != nil {
+		return fmt.Errorf("failed to get tags flag: %w", err)
+	}
+	yes, err := cmd.Flags().GetBool("yes")
+	if err != nil {
+		return fmt.Errorf("failed to get yes flag: %w", err)
+	}
+
+	cleaner, err := newURLCleaner()
+	if err != nil {
+		return err
+	}
+	repo, err := storage.NewDefaultJSONStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	bookmarks, err := listOrSelectBookmarks(repo, all, tags)
+	if err != nil || len(bookmarks) == 0 {
+		return err
+	}
+
+	findUc := usecase.NewFindCleanableURLs(cleaner)
+	upgrades := findUc.Execute(usecase.FindCleanableURLsInput{Bookmarks: bookmarks})
+	if len(upgrades) == 0 {
+		fmt.Println("No tracking parameters found.")
+		return nil
+	}
+
+	printURLUpgrades(upgrades)
+	if !yes && !confirmURLUpgrades(len(upgrades)) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	upgradeUc := usecase.NewUpgradeBookmarkURLs(repo)
+	if _, err := upgradeUc.Execute(usecase.UpgradeBookmarkURLsInput{Upgrades: upgrades}); err != nil {
+		return fmt.Errorf("failed to replace URLs: %w", err)
+	}
+
+	fmt.Printf("✓ Cleaned %d URLs.\n", len(upgrades))
+	return nil
+}
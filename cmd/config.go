@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/config"
+	"github.com/airRnot1106/bkm/internal/history"
+	"github.com/airRnot1106/bkm/internal/metadata"
+	"github.com/airRnot1106/bkm/internal/opener"
+	"github.com/airRnot1106/bkm/internal/secretscan"
+	"github.com/airRnot1106/bkm/internal/selector"
+	"github.com/airRnot1106/bkm/internal/snapshot"
+	"github.com/airRnot1106/bkm/internal/storage"
+	"github.com/airRnot1106/bkm/internal/tagsuggest"
+	"github.com/airRnot1106/bkm/internal/urlclean"
+	"github.com/airRnot1106/bkm/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// appConfig is loaded before any command runs.
+	appConfig config.Config
+	// profile is set with the --profile flag.
+	profile string
+)
+
+func loadConfig(cmd *cobra.Command, args []string) error {
+	cfg, err := config.LoadDefault()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	appConfig = cfg
+	return nil
+}
+
+// variableLookup resolves URL variables for the profile selected with
+// --profile or $BKM_PROFILE.
+func variableLookup() (func(name string) (string, bool), error) {
+	selected := profile
+	if selected == "" {
+		selected = os.Getenv("BKM_PROFILE")
+	}
+	return appConfig.VariableLookup(selected)
+}
+
+func newOpener() (opener.Opener, error) {
+	lookup, err := variableLookup()
+	if err != nil {
+		return nil, err
+	}
+	browser, err := newBrowserOpener()
+	if err != nil {
+		return nil, err
+	}
+
+	registry := opener.NewRegistry(browser)
+	for scheme, template := range appConfig.Openers {
+		op, err := opener.NewCommandOpener(template)
+		if err != nil {
+			return nil, fmt.Errorf("invalid opener for scheme %q: %w", scheme, err)
+		}
+		registry.Register(strings.ToLower(scheme), op)
+	}
+	return opener.NewExpandingOpener(registry, lookup), nil
+}
+
+// newBrowserOpener routes URLs to the browser commands configured in
+// browsers, falling back to $BROWSER or the platform default.
+func newBrowserOpener() (opener.Opener, error) {
+	fallback, err := opener.NewDefaultBrowserOpener()
+	if err != nil {
+		return nil, fmt.Errorf("invalid $BROWSER: %w", err)
+	}
+
+	routes := make([]opener.Route, 0, len(appConfig.Browsers))
+	for i, rule := range appConfig.Browsers {
+		op, err := opener.NewCommandOpener(rule.Command)
+		if err != nil {
+			return nil, fmt.Errorf("invalid command in browser rule %d: %w", i+1, err)
+		}
+		route := opener.Route{Domain: rule.Domain, Tag: rule.Tag, Opener: op}
+		if rule.URL != "" {
+			pattern, err := regexp.Compile(rule.URL)
+			if err != nil {
+				return nil, fmt.Errorf("invalid url pattern in browser rule %d: %w", i+1, err)
+			}
+			route.URL = pattern
+		}
+		routes = append(routes, route)
+	}
+
+	return opener.NewRoutingOpener(routes, fallback), nil
+}
+
+func newTagSuggester() (*tagsuggest.Suggester, error) {
+	rules := make([]tagsuggest.Rule, 0, len(appConfig.TagRules))
+	for i, rule := range appConfig.TagRules {
+		r := tagsuggest.Rule{Domain: rule.Domain}
+		for _, raw := range rule.Tags {
+			tag, err := bookmark.NewBookmarkTag(raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid tag in tag rule %d: %w", i+1, err)
+			}
+			r.Tags = append(r.Tags, tag.Value())
+		}
+		if rule.URL != "" {
+			pattern, err := regexp.Compile(rule.URL)
+			if err != nil {
+				return nil, fmt.Errorf("invalid url pattern in tag rule %d: %w", i+1, err)
+			}
+			r.URL = pattern
+		}
+		rules = append(rules, r)
+	}
+	return tagsuggest.NewSuggester(rules), nil
+}
+
+// newURLCleaner combines the built-in tracking parameter rules with the ones
+// in the config.
+func newURLCleaner() (*urlclean.Cleaner, error) {
+	var rules []urlclean.Rule
+	if !appConfig.CleanURLs.NoBuiltinRules {
+		rules = urlclean.BuiltinRules()
+	}
+	for i, rule := range appConfig.CleanURLs.Rules {
+		patterns := append([]string{rule.Domain}, rule.Remove...)
+		for _, pattern := range append(patterns, rule.Keep...) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("invalid pattern %q in clean_urls rule %d: %w", pattern, i+1, err)
+			}
+		}
+		rules = append(rules, urlclean.Rule{Domain: rule.Domain, Remove: rule.Remove, Keep: rule.Keep})
+	}
+	return urlclean.NewCleaner(rules), nil
+}
+
+func newSecretScanner() (*secretscan.Scanner, error) {
+	for _, pattern := range appConfig.Secrets.Params {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q in secrets params: %w", pattern, err)
+		}
+	}
+	return secretscan.NewScanner(secretscan.WithParams(appConfig.Secrets.Params...)), nil
+}
+
+// newSecretGuard applies the secrets policy of the config, or policy when it
+// is not empty. URLs stored despite their secrets are reported on stderr.
+func newSecretGuard(policy string) (*secretscan.Guard, error) {
+	if policy == "" {
+		policy = appConfig.Secrets.Policy
+	}
+	parsed, err := secretscan.ParsePolicy(policy)
+	if err != nil {
+		return nil, err
+	}
+	scanner, err := newSecretScanner()
+	if err != nil {
+		return nil, err
+	}
+	// A URL is checked more than once when bkm add prepares it for fetching
+	// the page, but reported only once.
+	reported := make(map[string]bool)
+	return secretscan.NewGuard(scanner, parsed, secretscan.WithReporter(func(redactedURL string, findings []secretscan.Finding) {
+		if reported[redactedURL] {
+			return
+		}
+		reported[redactedURL] = true
+		fmt.Fprintf(os.Stderr, "Warning: %s contains secrets: %s\n", redactedURL, secretscan.FormatFindings(findings))
+	})), nil
+}
+
+// newURLPolicy cleans URLs, handles their secrets and checks their scheme for
+// the commands that store them. The secrets policy is taken from the
+// --secrets flag of cmd.
+func newURLPolicy(cmd *cobra.Command) (*usecase.URLPolicy, error) {
+	secretsPolicy, err := cmd.Flags().GetString("secrets")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secrets flag: %w", err)
+	}
+	guard, err := newSecretGuard(secretsPolicy)
+	if err != nil {
+		return nil, err
+	}
+	cleaner, err := newURLCleaner()
+	if err != nil {
+		return nil, err
+	}
+	return usecase.NewURLPolicy(cleaner, guard, bookmark.NewSchemeAllowlist(appConfig.Schemes...)), nil
+}
+
+// newHistory opens the history of opened bookmarks. Entries older than the
+// retention are removed whenever an open is recorded.
+func newHistory() (history.Repository, error) {
+	hist, err := storage.NewDefaultHistoryJSONLStorage()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize history storage: %w", err)
+	}
+	return history.NewRetainingRepository(hist, historyRetention()), nil
+}
+
+func newFetcher() *metadata.HTTPFetcher {
+	var opts []metadata.Option
+	if timeout := time.Duration(appConfig.Fetch.Timeout); timeout > 0 {
+		opts = append(opts, metadata.WithTimeout(timeout))
+	}
+	if appConfig.Fetch.MaxBytes > 0 {
+		opts = append(opts, metadata.WithMaxBytes(appConfig.Fetch.MaxBytes))
+	}
+	return metadata.NewHTTPFetcher(opts...)
+}
+
+// newCapturer applies the archive config followed by extra.
+func newCapturer(extra ...snapshot.Option) *snapshot.HTTPCapturer {
+	var opts []snapshot.Option
+	if timeout := time.Duration(appConfig.Archive.Timeout); timeout > 0 {
+		opts = append(opts, snapshot.WithTimeout(timeout))
+	}
+	if appConfig.Archive.MaxBytes > 0 {
+		opts = append(opts, snapshot.WithMaxBytes(appConfig.Archive.MaxBytes))
+	}
+	if appConfig.Archive.MaxInlineBytes > 0 {
+		opts = append(opts, snapshot.WithMaxInlineBytes(appConfig.Archive.MaxInlineBytes))
+	}
+	return snapshot.NewHTTPCapturer(append(opts, extra...)...)
+}
+
+func newSelector() (*selector.FuzzyFinderSelector, error) {
+	lookup, err := variableLookup()
+	if err != nil {
+		return nil, err
+	}
+	return selector.NewFuzzyFinderSelector(selector.WithVariables(lookup)), nil
+}
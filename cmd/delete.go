@@ -45,7 +45,10 @@ func runDelete(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to initialize storage: %w", err)
 	}
 
-	sel := selector.NewFuzzyFinderSelector()
+	sel, err := newSelector()
+	if err != nil {
+		return fmt.Errorf("failed to initialize selector: %w", err)
+	}
 
 	// Use SearchBookmark to let user select which bookmark to delete
 	searchInput := usecase.SearchBookmarkInput{
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/selector"
+	"github.com/airRnot1106/bkm/internal/storage"
+	"github.com/airRnot1106/bkm/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+// editCmd represents the edit command
+var editCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Edit a bookmark",
+	Long: `Select a bookmark and edit its details.
+
+You can use flags to change specific fields of the selected bookmark:
+  bkm edit --keyword gh
+  bkm edit --title "New title" --tags go,cli
+
+Or run without flags for interactive mode, with the current values prefilled:
+  bkm edit
+
+Like with bkm add, tracking parameters are removed from changed URLs and URLs
+with secrets are handled by the secrets policy.`,
+	RunE: runEdit,
+}
+
+func init() {
+	rootCmd.AddCommand(editCmd)
+
+	editCmd.Flags().StringSlice("filter-tags", []string{}, "Filter bookmarks to choose from by tags (comma-separated)")
+	editCmd.Flags().StringP("url", "u", "", "New URL of the bookmark")
+	editCmd.Flags().StringP("title", "t", "", "New title of the bookmark")
+	editCmd.Flags().StringP("description", "d", "", "New description of the bookmark")
+	editCmd.Flags().StringSliceP("tags", "T", []string{}, "New tags (comma-separated)")
+	editCmd.Flags().StringP("keyword", "k", "", "New keyword (empty to remove)")
+	editCmd.Flags().StringToString("variants", map[string]string{}, "New set of named alternate URLs (e.g. staging=https://...,prod=https://...)")
+	editCmd.Flags().String("secrets", "", "What to do with secrets in changed URLs: refuse, redact or warn (default from config, else refuse)")
+}
+
+func runEdit(cmd *cobra.Command, args []string) error {
+	filterTags, err := cmd.Flags().GetStringSlice("filter-tags")
+	if err != nil {
+		return fmt.Errorf("failed to get filter-tags flag: %w", err)
+	}
+
+	policy, err := newURLPolicy(cmd)
+	if err != nil {
+		return err
+	}
+	repo, err := storage.NewDefaultJSONStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	sel, err := newSelector()
+	if err != nil {
+		return fmt.Errorf("failed to initialize selector: %w", err)
+	}
+
+	searchUc := usecase.NewSearchBookmark(repo, sel)
+	bookmark, err := searchUc.Execute(usecase.SearchBookmarkInput{Tags: filterTags})
+	if err != nil {
+		if errors.Is(err, selector.ErrCancelled) {
+			return nil
+		}
+		return fmt.Errorf("failed to search bookmark: %w", err)
+	}
+
+	input := usecase.EditBookmarkInput{
+		Bookmark:    bookmark,
+		URL:         bookmark.URL.Value(),
+		Title:       bookmark.Title.Value(),
+		Description: bookmark.Description.Value(),
+		Tags:        tagValues(bookmark.Tags),
+		Keyword:     bookmark.Keyword.Value(),
+		Variants:    variantValues(bookmark.Variants),
+	}
+
+	flagsProvided := cmd.Flags().Changed("url") ||
+		cmd.Flags().Changed("title") ||
+		cmd.Flags().Changed("description") ||
+		cmd.Flags().Changed("tags") ||
+		cmd.Flags().Changed("keyword") ||
+		cmd.Flags().Changed("variants")
+
+	if flagsProvided {
+		if cmd.Flags().Changed("url") {
+			if input.URL, err = cmd.Flags().GetString("url"); err != nil {
+				return fmt.Errorf("failed to get url flag: %w", err)
+			}
+		}
+		if cmd.Flags().Changed("title") {
+			if input.Title, err = cmd.Flags().GetString("title"); err != nil {
+				return fmt.Errorf("failed to get title flag: %w", err)
+			}
+		}
+		if cmd.Flags().Changed("description") {
+			if input.Description, err = cmd.Flags().GetString("description"); err != nil {
+				return fmt.Errorf("failed to get description flag: %w", err)
+			}
+		}
+		if cmd.Flags().Changed("tags") {
+			if input.Tags, err = cmd.Flags().GetStringSlice("tags"); err != nil {
+				return fmt.Errorf("failed to get tags flag: %w", err)
+			}
+		}
+		if cmd.Flags().Changed("keyword") {
+			if input.Keyword, err = cmd.Flags().GetString("keyword"); err != nil {
+				return fmt.Errorf("failed to get keyword flag: %w", err)
+			}
+		}
+		if cmd.Flags().Changed("variants") {
+			if input.Variants, err = cmd.Flags().GetStringToString("variants"); err != nil {
+				return fmt.Errorf("failed to get variants flag: %w", err)
+			}
+		}
+	} else {
+		input, err = promptForBookmarkEdit(repo, input)
+		if err != nil {
+			return fmt.Errorf("failed to get bookmark details: %w", err)
+		}
+	}
+
+	uc := usecase.NewEditBookmark(repo, policy, commandNames()...)
+	bm, err := uc.Execute(input)
+	if err != nil {
+		return fmt.Errorf("failed to edit bookmark: %w", err)
+	}
+
+	fmt.Println("✓ Bookmark updated successfully!")
+	fmt.Println()
+	printBookmarkDetails(bm)
+	return nil
+}
+
+func promptForBookmarkEdit(repo bookmark.Repository, input usecase.EditBookmarkInput) (usecase.EditBookmarkInput, error) {
+	var err error
+	if input.URL, err = promptForBookmarkURL(input.URL); err != nil {
+		return usecase.EditBookmarkInput{}, err
+	}
+	if input.Title, err = promptForBookmarkTitle(input.Title); err != nil {
+		return usecase.EditBookmarkInput{}, err
+	}
+	if input.Description, err = promptForBookmarkDescription(input.Description); err != nil {
+		return usecase.EditBookmarkInput{}, err
+	}
+	if input.Tags, err = pickTags(repo, input.Tags); err != nil {
+		return usecase.EditBookmarkInput{}, err
+	}
+	if input.Keyword, err = promptForBookmarkKeyword(input.Keyword); err != nil {
+		return usecase.EditBookmarkInput{}, err
+	}
+	return input, nil
+}
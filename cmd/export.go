@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/exporter"
+	"github.com/airRnot1106/bkm/internal/storage"
+	"github.com/airRnot1106/bkm/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export bookmarks for a browser",
+	Long: `Export bookmarks in a format that browsers can import.
+
+The netscape format is the bookmarks.html file read by "Import bookmarks" in
+every major browser:
+  bkm export --format netscape --output bookmarks.html
+
+Bookmarks are put into folders by their first tag. With --folders
+hierarchical, the most nested tag is used instead. A tag like "dev/go"
+becomes the nested folders dev > go, which bkm import turns back into the
+same tag. --folders flat writes no folders. All tags are kept in the TAGS
+attribute either way.
+
+URLs are written as stored: ${VAR} variables are not expanded, so that
+secrets kept in the environment do not end up in the file.`,
+	Args: cobra.NoArgs,
+	RunE: runExport,
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().StringP("format", "f", "netscape", "Format of the file: netscape")
+	exportCmd.Flags().String("folders", string(exporter.FolderFirstTag), "How to put bookmarks into folders: first-tag, hierarchical or flat")
+	exportCmd.Flags().StringP("output", "o", "", "File to write to (default stdout)")
+	exportCmd.Flags().StringSliceP("tags", "T", []string{}, "Export only bookmarks with these tags (comma-separated)")
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return fmt.Errorf("failed to get format flag: %w", err)
+	}
+	if format != "netscape" {
+		return fmt.Errorf("unknown format %q", format)
+	}
+	folders, err := cmd.Flags().GetString("folders")
+	if err != nil {
+		return fmt.Errorf("failed to get folders flag: %w", err)
+	}
+	strategy, err := exporter.ParseFolderStrategy(folders)
+	if err != nil {
+		return err
+	}
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return fmt.Errorf("failed to get output flag: %w", err)
+	}
+	tags, err := cmd.Flags().GetStringSlice("tags")
+	if err != nil {
+		return fmt.Errorf("failed to get tags flag: %w", err)
+	}
+
+	repo, err := storage.NewDefaultJSONStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	uc := usecase.NewListBookmarks(repo)
+	bookmarks, err := uc.Execute(usecase.ListBookmarksInput{Tags: tags})
+	if err != nil {
+		return fmt.Errorf("failed to list bookmarks: %w", err)
+	}
+
+	if output == "" {
+		return exporter.WriteNetscape(os.Stdout, bookmarks, strategy)
+	}
+	if err := writeExportFile(output, bookmarks, strategy); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Exported %d bookmarks to %s.\n", len(bookmarks), output)
+	return nil
+}
+
+func writeExportFile(path string, bookmarks []bookmark.Bookmark, strategy exporter.FolderStrategy) (retErr error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer func() {
+		if err := f.Close(); err != nil && retErr == nil {
+			retErr = fmt.Errorf("failed to close file: %w", err)
+		}
+	}()
+
+	return exporter.WriteNetscape(f, bookmarks, strategy)
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+// goCmd represents the go command
+var goCmd = &cobra.Command{
+	Use:   "go <keyword> <args...>",
+	Short: "Fill a URL template bookmark and open it",
+	Long: `Open a bookmark whose URL is a template, filling its %s placeholders with args.
+
+Add a search-engine style bookmark with a keyword:
+  bkm add -u "https://pkg.go.dev/search?q=%s" -t "Go packages" -k pkg
+
+Then search with it:
+  bkm go pkg http client
+
+Arguments are URL-escaped. When there are more arguments than placeholders,
+the remaining ones are joined with spaces into the last placeholder.`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runGo,
+}
+
+func init() {
+	rootCmd.AddCommand(goCmd)
+
+	addOpenFlags(goCmd)
+}
+
+func runGo(cmd *cobra.Command, args []string) error {
+	opts, err := getOpenOptions(cmd)
+	if err != nil {
+		return err
+	}
+	opts.templateArgs = args[1:]
+
+	return openByKeyword(args[0], opts)
+}
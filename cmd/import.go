@@ -0,0 +1,252 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/adrg/xdg"
+	"github.com/airRnot1106/bkm/internal/importer"
+	"github.com/airRnot1106/bkm/internal/storage"
+	"github.com/airRnot1106/bkm/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Import bookmarks from a browser",
+	Long: `Import bookmarks exported from a browser.
+
+The netscape format is the bookmarks.html file written by "Export bookmarks"
+in every major browser:
+  bkm import --format netscape bookmarks.html
+
+The chromium format is the Bookmarks file in the profile directory of Chrome,
+Chromium, Brave, Edge and Vivaldi. Without a file, the profiles in
+~/.config, snap and flatpak are searched, and the file is used when exactly
+one profile is found:
+  bkm import --format chromium
+  bkm import --format chromium ~/.config/google-chrome/Default/Bookmarks
+
+The firefox format is a bookmark backup, either one of the daily backups
+Firefox writes to bookmarkbackups/ in the profile directory (.jsonlz4) or one
+saved with "Backup..." in the Library window (.json). Firefox tags are kept:
+  bkm import --format firefox ~/.mozilla/firefox/*.default-release/bookmarkbackups/bookmarks-2024-01-01_1200_AbCd.jsonlz4
+
+Folders become tags: a bookmark in Dev > Go is tagged "Dev/Go". The root
+folders the browser creates, such as the bookmarks bar, are left out. The
+dates the bookmarks were added and last modified are kept. Like with bkm add,
+tracking parameters are removed and URLs with secrets are handled by the
+secrets policy.
+
+Entries are compared with your bookmarks and with each other by canonical URL,
+ignoring case in the host, default ports, trailing slashes and the order of
+query parameters. An entry is a duplicate when it adds nothing, and a conflict
+when its title, description or tags differ. Duplicates are never imported;
+--merge decides what happens to conflicts:
+  skip        keep the existing bookmark as it is (default)
+  merge-tags  add the tags of the entry to the existing bookmark
+  overwrite   replace the title, description and tags of the existing bookmark
+  keep-both   add the entry as another bookmark
+
+--dry-run lists the new entries, duplicates and conflicts without changing
+anything:
+  bkm import --format chromium --dry-run --merge merge-tags`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runImport,
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+
+	importCmd.Flags().StringP("format", "f", "netscape", "Format of the file: netscape, chromium or firefox")
+	importCmd.Flags().String("secrets", "", "What to do with secrets in URLs: refuse, redact or warn (default from config, else refuse)")
+	importCmd.Flags().String("merge", string(usecase.MergeSkip), "What to do with entries that conflict with a bookmark: skip, merge-tags, overwrite or keep-both")
+	importCmd.Flags().Bool("dry-run", false, "Report new, duplicate and conflicting entries without importing them")
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	format, err := cmd.Flags().GetString("format")
+	if err != nil {
+		return fmt.Errorf("failed to get format flag: %w", err)
+	}
+
+	merge, err := cmd.Flags().GetString("merge")
+	if err != nil {
+		return fmt.Errorf("failed to get merge flag: %w", err)
+	}
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return fmt.Errorf("failed to get dry-run flag: %w", err)
+	}
+	strategy, err := usecase.ParseMergeStrategy(merge)
+	if err != nil {
+		return err
+	}
+
+	parse, err := importParser(format)
+	if err != nil {
+		return err
+	}
+	path, err := importPath(format, args)
+	if err != nil {
+		return err
+	}
+	entries, err := readImportFile(parse, path)
+	if err != nil {
+		return err
+	}
+
+	policy, err := newURLPolicy(cmd)
+	if err != nil {
+		return err
+	}
+	repo, err := storage.NewDefaultJSONStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	uc := usecase.NewImportBookmarks(repo, policy)
+	output, err := uc.Execute(usecase.ImportBookmarksInput{Entries: entries, Strategy: strategy, DryRun: dryRun})
+	if err != nil {
+		return fmt.Errorf("failed to import bookmarks: %w", err)
+	}
+
+	for _, skipped := range output.Skipped {
+		fmt.Fprintf(os.Stderr, "Skipped %q: %v\n", skipped.Entry.Title, skipped.Err)
+	}
+	if dryRun {
+		printImportReport(output)
+		fmt.Printf("Dry run with --merge %s: would add %d bookmarks and update %d. Nothing was changed.\n", strategy, len(output.Added), len(output.Updated))
+		return nil
+	}
+
+	fmt.Printf("✓ Imported %d bookmarks", len(output.Added))
+	if len(output.Updated) > 0 {
+		fmt.Printf(", updated %d", len(output.Updated))
+	}
+	if len(output.Duplicates) > 0 || len(output.Conflicts) > 0 {
+		fmt.Printf(" (%d duplicates, %d conflicts)", len(output.Duplicates), len(output.Conflicts))
+	}
+	if len(output.Skipped) > 0 {
+		fmt.Printf(", skipped %d", len(output.Skipped))
+	}
+	fmt.Println(".")
+	return nil
+}
+
+func printImportReport(output usecase.ImportBookmarksOutput) {
+	fmt.Printf("New (%d):\n", len(output.New))
+	for _, bm := range output.New {
+		fmt.Printf("  %s\n    %s\n", bm.Title.Value(), bm.URL.Value())
+	}
+	fmt.Printf("\nDuplicates (%d):\n", len(output.Duplicates))
+	for _, match := range output.Duplicates {
+		fmt.Printf("  %s\n    %s\n", match.Existing.Title.Value(), match.Imported.URL.Value())
+	}
+	fmt.Printf("\nConflicts (%d):\n", len(output.Conflicts))
+	for _, match := range output.Conflicts {
+		fmt.Printf("  %s\n    %s\n", match.Existing.Title.Value(), match.Imported.URL.Value())
+		for _, diff := range importDiff(match) {
+			fmt.Printf("    %s\n", diff)
+		}
+	}
+	fmt.Println()
+}
+
+// importDiff describes how the imported bookmark of a conflict differs from
+// the existing one.
+func importDiff(match usecase.ImportMatch) []string {
+	var diffs []string
+	if match.Imported.Title != match.Existing.Title {
+		diffs = append(diffs, fmt.Sprintf("title: %q → %q", match.Existing.Title.Value(), match.Imported.Title.Value()))
+	}
+	if desc := match.Imported.Description.Value(); desc != "" && desc != match.Existing.Description.Value() {
+		diffs = append(diffs, fmt.Sprintf("description: %q → %q", match.Existing.Description.Value(), desc))
+	}
+	var tags []string
+	for _, tag := range match.Imported.Tags {
+		if !slices.Contains(match.Existing.Tags, tag) {
+			tags = append(tags, "+"+tag.Value())
+		}
+	}
+	if len(tags) > 0 {
+		diffs = append(diffs, "tags: "+strings.Join(tags, " "))
+	}
+	return diffs
+}
+
+// importPath returns the file given on the command line, or the only
+// Chromium profile found.
+func importPath(format string, args []string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+	if format != "chromium" {
+		return "", fmt.Errorf("a file to import is required for the %s format", format)
+	}
+
+	files, err := importer.FindChromiumBookmarks(chromiumConfigDirs()...)
+	if err != nil {
+		return "", err
+	}
+	switch len(files) {
+	case 0:
+		return "", fmt.Errorf("no Chromium profile found, pass the path of the Bookmarks file")
+	case 1:
+		fmt.Fprintf(os.Stderr, "Importing %s\n", files[0])
+		return files[0], nil
+	default:
+		return "", fmt.Errorf("found several Chromium profiles, pass the path of one of these Bookmarks files:\n  %s", strings.Join(files, "\n  "))
+	}
+}
+
+// chromiumConfigDirs are where Chromium-based browsers keep their profiles on
+// Linux, including the snap and flatpak packages.
+func chromiumConfigDirs() []string {
+	dirs := []string{xdg.ConfigHome}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return dirs
+	}
+	dirs = append(dirs, filepath.Join(home, "snap", "chromium", "common"))
+	if flatpaks, err := filepath.Glob(filepath.Join(home, ".var", "app", "*", "config")); err == nil {
+		dirs = append(dirs, flatpaks...)
+	}
+	return dirs
+}
+
+func importParser(format string) (func(io.Reader) ([]importer.Entry, error), error) {
+	switch format {
+	case "netscape":
+		return importer.ParseNetscape, nil
+	case "chromium":
+		return importer.ParseChromium, nil
+	case "firefox":
+		return importer.ParseFirefox, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q", format)
+	}
+}
+
+func readImportFile(parse func(io.Reader) ([]importer.Entry, error), path string) (entries []importer.Entry, retErr error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer func() {
+		if err := f.Close(); err != nil && retErr == nil {
+			retErr = fmt.Errorf("failed to close file: %w", err)
+		}
+	}()
+
+	entries, err = parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bookmarks: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/fulltext"
+	"github.com/airRnot1106/bkm/internal/snapshot"
+	"github.com/airRnot1106/bkm/internal/storage"
+	"github.com/airRnot1106/bkm/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+// indexCmd represents the index command
+var indexCmd = &cobra.Command{
+	Use:   "index",
+	Short: "Index page contents for bkm search --content",
+	Long: `Index the text of archived pages for "bkm search --content".
+
+Archived pages are indexed automatically when searching. Use --fetch to also
+download and index the live pages of bookmarks that were not archived:
+  bkm index --fetch`,
+	RunE: runIndex,
+}
+
+func init() {
+	rootCmd.AddCommand(indexCmd)
+
+	indexCmd.Flags().Bool("fetch", false, "Download and index the live pages of bookmarks that were not archived")
+}
+
+func runIndex(cmd *cobra.Command, args []string) error {
+	fetch, err := cmd.Flags().GetBool("fetch")
+	if err != nil {
+		return fmt.Errorf("failed to get fetch flag: %w", err)
+	}
+
+	repo, err := storage.NewDefaultJSONStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	index, err := storage.NewDefaultIndexJSONStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize index storage: %w", err)
+	}
+
+	output, err := updateContentIndex(repo, index, fetch)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Indexed %d pages, removed %d.\n", output.Indexed, output.Removed)
+	return nil
+}
+
+// updateContentIndex indexes new and changed pages, printing the pages that
+// failed as warnings.
+func updateContentIndex(repo bookmark.Repository, index fulltext.Repository, fetch bool) (usecase.UpdateContentIndexOutput, error) {
+	store, err := storage.NewDefaultSnapshotFileStorage()
+	if err != nil {
+		return usecase.UpdateContentIndexOutput{}, fmt.Errorf("failed to initialize snapshot storage: %w", err)
+	}
+
+	// Only the text is indexed, so stylesheets and images are not downloaded.
+	capturer := newCapturer(snapshot.WithMaxInlineBytes(0))
+	indexUc := usecase.NewUpdateContentIndex(repo, index, store, capturer)
+	output, err := indexUc.Execute(usecase.UpdateContentIndexInput{Fetch: fetch})
+	if err != nil {
+		return usecase.UpdateContentIndexOutput{}, fmt.Errorf("failed to update index: %w", err)
+	}
+
+	for _, err := range output.Errors {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	return output, nil
+}
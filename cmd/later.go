@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/airRnot1106/bkm/internal/storage"
+	"github.com/airRnot1106/bkm/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+// laterCmd represents the later command
+var laterCmd = &cobra.Command{
+	Use:   "later <url>",
+	Short: "Add a URL to the read-later queue",
+	Long: `Add a URL to the read-later queue as an unread bookmark.
+
+If the URL is already bookmarked, it is put back into the queue:
+  bkm later https://example.com/article
+
+You can optionally set a title and tags. For a bookmarked URL the title
+replaces its title and the tags are added to its tags:
+  bkm later https://example.com/article --title "Article" --tags go
+
+Like with bkm add, tracking parameters are removed and URLs with secrets are
+handled by the secrets policy.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runLater,
+}
+
+func init() {
+	rootCmd.AddCommand(laterCmd)
+
+	laterCmd.Flags().StringP("title", "t", "", "Title of the bookmark (defaults to the URL)")
+	laterCmd.Flags().StringSliceP("tags", "T", []string{}, "Tags (comma-separated)")
+	laterCmd.Flags().String("secrets", "", "What to do with secrets in the URL: refuse, redact or warn (default from config, else refuse)")
+}
+
+func runLater(cmd *cobra.Command, args []string) error {
+	title, err := cmd.Flags().GetString("title")
+	if err != nil {
+		return fmt.Errorf("failed to get title flag: %w", err)
+	}
+	tags, err := cmd.Flags().GetStringSlice("tags")
+	if err != nil {
+		return fmt.Errorf("failed to get tags flag: %w", err)
+	}
+
+	repo, err := storage.NewDefaultJSONStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	policy, err := newURLPolicy(cmd)
+	if err != nil {
+		return err
+	}
+
+	uc := usecase.NewReadLater(repo, policy)
+	bm, err := uc.Execute(usecase.ReadLaterInput{
+		URL:   args[0],
+		Title: title,
+		Tags:  tags,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to queue bookmark: %w", err)
+	}
+
+	fmt.Printf("✓ Queued for later: %s\n", bm.Title.Value())
+	return nil
+}
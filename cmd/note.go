@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/airRnot1106/bkm/internal/editor"
+	"github.com/airRnot1106/bkm/internal/selector"
+	"github.com/airRnot1106/bkm/internal/storage"
+	"github.com/airRnot1106/bkm/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+// noteCmd represents the note command
+var noteCmd = &cobra.Command{
+	Use:   "note",
+	Short: "Edit the notes of a bookmark",
+	Long: `Edit free-form Markdown notes of a bookmark in your editor.
+
+The editor is taken from $VISUAL or $EDITOR. Notes are shown in the
+preview pane of the fuzzy finder, next to the list of bookmarks.
+
+You can filter by tags:
+  bkm note --tags go,cli
+
+Or run without flags to choose from all bookmarks:
+  bkm note`,
+	RunE: runNote,
+}
+
+func init() {
+	rootCmd.AddCommand(noteCmd)
+
+	noteCmd.Flags().StringSliceP("tags", "T", []string{}, "Filter by tags (comma-separated)")
+}
+
+func runNote(cmd *cobra.Command, args []string) error {
+	tags, err := cmd.Flags().GetStringSlice("tags")
+	if err != nil {
+		return fmt.Errorf("failed to get tags flag: %w", err)
+	}
+
+	repo, err := storage.NewDefaultJSONStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	sel, err := newSelector()
+	if err != nil {
+		return fmt.Errorf("failed to initialize selector: %w", err)
+	}
+
+	searchUc := usecase.NewSearchBookmark(repo, sel)
+	bookmark, err := searchUc.Execute(usecase.SearchBookmarkInput{Tags: tags})
+	if err != nil {
+		if errors.Is(err, selector.ErrCancelled) {
+			return nil
+		}
+		return fmt.Errorf("failed to search bookmark: %w", err)
+	}
+
+	ed := editor.NewExternalEditor()
+
+	noteUc := usecase.NewEditBookmarkNotes(repo, ed)
+	if _, err := noteUc.Execute(usecase.EditBookmarkNotesInput{Bookmark: bookmark}); err != nil {
+		return fmt.Errorf("failed to edit notes: %w", err)
+	}
+
+	fmt.Printf("✓ Notes saved for %s\n", bookmark.Title.Value())
+	return nil
+}
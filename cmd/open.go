@@ -0,0 +1,390 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/selector"
+	"github.com/airRnot1106/bkm/internal/storage"
+	"github.com/airRnot1106/bkm/internal/usecase"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+// openCmd represents the open command
+var openCmd = &cobra.Command{
+	Use:   "open [keyword]",
+	Short: "Open bookmarks by keyword, tag or multi-selection",
+	Long: `Open the bookmark with the given keyword without launching the fuzzy finder.
+
+Assign a keyword when adding or editing a bookmark:
+  bkm add -u https://github.com -t GitHub -k gh
+
+Then open it directly:
+  bkm open gh
+
+As a shortcut, the keyword can also be passed to bkm itself:
+  bkm gh
+
+Open every bookmark with the given tags:
+  bkm open --tag morning
+
+Or run without arguments to mark several bookmarks with Tab and open them all:
+  bkm open`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runOpen,
+}
+
+// defaultConfirmThreshold is the number of bookmarks that can be opened by tag
+// without confirmation when the config does not set one.
+const defaultConfirmThreshold = 5
+
+func init() {
+	rootCmd.AddCommand(openCmd)
+
+	openCmd.Flags().StringSliceP("tag", "T", []string{}, "Open every bookmark with these tags (comma-separated)")
+	openCmd.Flags().BoolP("yes", "y", false, "Do not ask for confirmation when opening many bookmarks")
+	addBulkOpenFlags(openCmd)
+	addOpenFlags(openCmd)
+}
+
+func runOpen(cmd *cobra.Command, args []string) error {
+	opts, err := getOpenOptions(cmd)
+	if err != nil {
+		return err
+	}
+	tags, err := cmd.Flags().GetStringSlice("tag")
+	if err != nil {
+		return fmt.Errorf("failed to get tag flag: %w", err)
+	}
+
+	if len(args) == 1 {
+		if len(tags) > 0 {
+			return fmt.Errorf("cannot use --tag together with a keyword")
+		}
+		return openByKeyword(args[0], opts)
+	}
+
+	bulk, err := getBulkOpenOptions(cmd)
+	if err != nil {
+		return err
+	}
+	if bulk.yes, err = cmd.Flags().GetBool("yes"); err != nil {
+		return fmt.Errorf("failed to get yes flag: %w", err)
+	}
+
+	repo, err := storage.NewDefaultJSONStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	bookmarks, err := bookmarksToOpen(repo, tags, bulk)
+	if err != nil || len(bookmarks) == 0 {
+		return err
+	}
+	if len(bookmarks) == 1 && len(tags) == 0 {
+		return openBookmark(repo, bookmarks[0], opts)
+	}
+
+	return openBookmarks(repo, bookmarks, opts, bulk)
+}
+
+// bookmarksToOpen returns every bookmark with the given tags after
+// confirmation, or lets the user mark bookmarks when no tags are given. It
+// returns no bookmarks when the user cancels.
+func bookmarksToOpen(repo bookmark.Repository, tags []string, bulk bulkOpenOptions) ([]bookmark.Bookmark, error) {
+	listUc := usecase.NewListBookmarks(repo)
+	bookmarks, err := listUc.Execute(usecase.ListBookmarksInput{Tags: tags})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bookmarks: %w", err)
+	}
+
+	if len(tags) > 0 {
+		if len(bookmarks) == 0 {
+			fmt.Println("No bookmarks found.")
+			return nil, nil
+		}
+		if len(bookmarks) > bulk.confirmThreshold && !bulk.yes && !confirmOpenMany(len(bookmarks)) {
+			fmt.Println("Cancelled.")
+			return nil, nil
+		}
+		return bookmarks, nil
+	}
+
+	return selectBookmarks(bookmarks)
+}
+
+// selectBookmarks lets the user mark bookmarks in the fuzzy finder. It
+// returns no bookmarks when the user cancels.
+func selectBookmarks(candidates []bookmark.Bookmark) ([]bookmark.Bookmark, error) {
+	sel, err := newSelector()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize selector: %w", err)
+	}
+	selected, err := sel.SelectMulti(candidates)
+	if err != nil {
+		if errors.Is(err, selector.ErrCancelled) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to select bookmarks: %w", err)
+	}
+	return selected, nil
+}
+
+// bulkOpenOptions control how several bookmarks are opened at once.
+type bulkOpenOptions struct {
+	delay            time.Duration
+	concurrency      int
+	confirmThreshold int
+	yes              bool
+}
+
+func addBulkOpenFlags(cmd *cobra.Command) {
+	cmd.Flags().Duration("delay", 0, "Pause between opening two bookmarks (defaults to open.delay in the config)")
+	cmd.Flags().Int("concurrency", 1, "Number of bookmarks opened at the same time (defaults to open.concurrency in the config)")
+}
+
+// getBulkOpenOptions reads the bulk open flags, using the config for the ones
+// that were not set on the command line.
+func getBulkOpenOptions(cmd *cobra.Command) (bulkOpenOptions, error) {
+	opts := bulkOpenOptions{
+		delay:            time.Duration(appConfig.Open.Delay),
+		concurrency:      appConfig.Open.Concurrency,
+		confirmThreshold: appConfig.Open.ConfirmThreshold,
+	}
+	if opts.confirmThreshold <= 0 {
+		opts.confirmThreshold = defaultConfirmThreshold
+	}
+
+	var err error
+	if cmd.Flags().Changed("delay") {
+		if opts.delay, err = cmd.Flags().GetDuration("delay"); err != nil {
+			return bulkOpenOptions{}, fmt.Errorf("failed to get delay flag: %w", err)
+		}
+	}
+	if cmd.Flags().Changed("concurrency") {
+		if opts.concurrency, err = cmd.Flags().GetInt("concurrency"); err != nil {
+			return bulkOpenOptions{}, fmt.Errorf("failed to get concurrency flag: %w", err)
+		}
+	}
+	return opts, nil
+}
+
+func confirmOpenMany(count int) bool {
+	prompt := promptui.Prompt{
+		Label:     fmt.Sprintf("Open %d bookmarks", count),
+		IsConfirm: true,
+	}
+	_, err := prompt.Run()
+	return err == nil
+}
+
+func openBookmarks(repo bookmark.Repository, bookmarks []bookmark.Bookmark, opts openOptions, bulk bulkOpenOptions) error {
+	if opts.variant != "" {
+		return fmt.Errorf("--variant can only be used when opening a single bookmark")
+	}
+	if opts.archived {
+		return fmt.Errorf("--archived can only be used when opening a single bookmark")
+	}
+
+	op, err := newOpener()
+	if err != nil {
+		return fmt.Errorf("failed to initialize opener: %w", err)
+	}
+
+	hist, err := newHistory()
+	if err != nil {
+		return err
+	}
+
+	openUc := usecase.NewOpenBookmarks(repo, op, hist)
+	output, err := openUc.Execute(usecase.OpenBookmarksInput{
+		Bookmarks:   bookmarks,
+		Delay:       bulk.delay,
+		Concurrency: bulk.concurrency,
+		KeepStatus:  opts.keepQueued,
+	})
+	warnHistory(output.HistoryErrs...)
+	if err != nil {
+		return fmt.Errorf("failed to open bookmarks: %w", err)
+	}
+
+	return nil
+}
+
+// openOptions control how a selected bookmark is opened.
+type openOptions struct {
+	// variant names the URL variant to open. When empty and the bookmark has
+	// variants, the user is asked to choose one.
+	variant string
+	// templateArgs fill the URL template. When empty and the URL is a
+	// template, the user is asked for them.
+	templateArgs []string
+	keepQueued   bool
+	// archived opens the offline copy saved by bkm archive.
+	archived bool
+}
+
+func addOpenFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("keep-queued", false, "Keep a queued bookmark in the queue, marked as reading, instead of marking it done")
+	cmd.Flags().String("variant", "", "Name of the URL variant to open")
+	cmd.Flags().Bool("archived", false, "Open the offline copy saved by \"bkm archive\"")
+}
+
+func getOpenOptions(cmd *cobra.Command) (openOptions, error) {
+	keepQueued, err := cmd.Flags().GetBool("keep-queued")
+	if err != nil {
+		return openOptions{}, fmt.Errorf("failed to get keep-queued flag: %w", err)
+	}
+	variant, err := cmd.Flags().GetString("variant")
+	if err != nil {
+		return openOptions{}, fmt.Errorf("failed to get variant flag: %w", err)
+	}
+	archived, err := cmd.Flags().GetBool("archived")
+	if err != nil {
+		return openOptions{}, fmt.Errorf("failed to get archived flag: %w", err)
+	}
+	if archived && variant != "" {
+		return openOptions{}, fmt.Errorf("cannot use --variant together with --archived")
+	}
+	return openOptions{variant: variant, keepQueued: keepQueued, archived: archived}, nil
+}
+
+func openByKeyword(keyword string, opts openOptions) error {
+	repo, err := storage.NewDefaultJSONStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	findUc := usecase.NewFindBookmarkByKeyword(repo)
+	bookmark, err := findUc.Execute(usecase.FindBookmarkByKeywordInput{Keyword: keyword})
+	if err != nil {
+		return fmt.Errorf("failed to find bookmark: %w", err)
+	}
+
+	return openBookmark(repo, bookmark, opts)
+}
+
+// openBookmark opens bm, asking for the URL variant and template arguments
+// when they are needed but were not given.
+func openBookmark(repo bookmark.Repository, bm bookmark.Bookmark, opts openOptions) error {
+	if opts.archived {
+		return openSnapshot(repo, bm, opts)
+	}
+
+	if opts.variant == "" && len(bm.Variants) > 0 {
+		sel, err := newSelector()
+		if err != nil {
+			return fmt.Errorf("failed to initialize selector: %w", err)
+		}
+		name, err := sel.SelectVariant(bm)
+		if err != nil {
+			if errors.Is(err, selector.ErrCancelled) {
+				return nil
+			}
+			return fmt.Errorf("failed to select variant: %w", err)
+		}
+		opts.variant = name.Value()
+	}
+
+	if len(opts.templateArgs) == 0 && variantURL(bm, opts.variant).IsTemplate() {
+		args, err := promptForTemplateArgs(bm)
+		if err != nil {
+			return fmt.Errorf("failed to get template arguments: %w", err)
+		}
+		opts.templateArgs = args
+	}
+
+	return executeOpen(repo, usecase.OpenBookmarkInput{
+		Bookmark:   bm,
+		Variant:    opts.variant,
+		Args:       opts.templateArgs,
+		KeepStatus: opts.keepQueued,
+	})
+}
+
+// openSnapshot opens the offline copy of bm saved by bkm archive.
+func openSnapshot(repo bookmark.Repository, bm bookmark.Bookmark, opts openOptions) error {
+	store, err := storage.NewDefaultSnapshotFileStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize snapshot storage: %w", err)
+	}
+
+	snapshotUc := usecase.NewGetSnapshotURL(store)
+	url, err := snapshotUc.Execute(usecase.GetSnapshotURLInput{Bookmark: bm})
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot: %w", err)
+	}
+
+	return executeOpen(repo, usecase.OpenBookmarkInput{
+		Bookmark:   bm,
+		URL:        url,
+		KeepStatus: opts.keepQueued,
+	})
+}
+
+func executeOpen(repo bookmark.Repository, input usecase.OpenBookmarkInput) error {
+	op, err := newOpener()
+	if err != nil {
+		return fmt.Errorf("failed to initialize opener: %w", err)
+	}
+
+	hist, err := newHistory()
+	if err != nil {
+		return err
+	}
+
+	openUc := usecase.NewOpenBookmark(repo, op, hist)
+	output, err := openUc.Execute(input)
+	warnHistory(output.HistoryErr)
+	if err != nil {
+		return fmt.Errorf("failed to open bookmark: %w", err)
+	}
+
+	return nil
+}
+
+// warnHistory reports opens that could not be recorded in the history. The
+// bookmarks were opened all the same, so the command does not fail.
+func warnHistory(errs ...error) {
+	for _, err := range errs {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+}
+
+// variantURL returns the URL of the named variant, falling back to the primary
+// URL for an unknown name so that the open use case reports the error.
+func variantURL(bm bookmark.Bookmark, variant string) bookmark.BookmarkURL {
+	name, err := bookmark.NewBookmarkVariantName(variant)
+	if err != nil {
+		return bm.URL
+	}
+	url, err := bm.URLFor(name)
+	if err != nil {
+		return bm.URL
+	}
+	return url
+}
+
+func promptForTemplateArgs(bm bookmark.Bookmark) ([]string, error) {
+	prompt := promptui.Prompt{
+		Label: bm.Title.Value(),
+		Validate: func(input string) error {
+			if strings.TrimSpace(input) == "" {
+				return fmt.Errorf("input cannot be empty")
+			}
+			return nil
+		},
+	}
+	result, err := prompt.Run()
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(result), nil
+}
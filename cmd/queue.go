@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/airRnot1106/bkm/internal/selector"
+	"github.com/airRnot1106/bkm/internal/storage"
+	"github.com/airRnot1106/bkm/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+// queueCmd represents the queue command
+var queueCmd = &cobra.Command{
+	Use:   "queue",
+	Short: "Pick the next bookmark from the read-later queue",
+	Long: `Pick an unread bookmark from the read-later queue and open it.
+
+Bookmarks you are already reading are listed first, followed by the oldest
+unread ones. Opening a bookmark marks it as done:
+  bkm queue
+
+Keep it in the queue (marked as reading) instead:
+  bkm queue --keep-queued`,
+	RunE: runQueue,
+}
+
+func init() {
+	rootCmd.AddCommand(queueCmd)
+
+	queueCmd.Flags().StringSliceP("tags", "T", []string{}, "Filter by tags (comma-separated)")
+	addOpenFlags(queueCmd)
+}
+
+func runQueue(cmd *cobra.Command, args []string) error {
+	tags, err := cmd.Flags().GetStringSlice("tags")
+	if err != nil {
+		return fmt.Errorf("failed to get tags flag: %w", err)
+	}
+	opts, err := getOpenOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	repo, err := storage.NewDefaultJSONStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	sel, err := newSelector()
+	if err != nil {
+		return fmt.Errorf("failed to initialize selector: %w", err)
+	}
+
+	pickUc := usecase.NewPickFromQueue(repo, sel)
+	bookmark, err := pickUc.Execute(usecase.PickFromQueueInput{Tags: tags})
+	if err != nil {
+		if errors.Is(err, selector.ErrCancelled) {
+			return nil
+		}
+		return fmt.Errorf("failed to pick bookmark: %w", err)
+	}
+
+	return openBookmark(repo, bookmark, opts)
+}
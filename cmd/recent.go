@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/selector"
+	"github.com/airRnot1106/bkm/internal/storage"
+	"github.com/airRnot1106/bkm/internal/usecase"
+	"github.com/spf13/cobra"
+)
+
+// defaultHistoryRetention is how long opened bookmarks are kept in the history
+// when the config does not set history.retention.
+const defaultHistoryRetention = 90 * 24 * time.Hour
+
+// recentCmd represents the recent command
+var recentCmd = &cobra.Command{
+	Use:   "recent",
+	Short: "Reopen a recently opened bookmark",
+	Long: `Browse the history of opened bookmarks, most recent first, and reopen one.
+
+The exact URL that was opened is reopened, including the chosen variant and
+the filled URL template:
+  bkm recent
+
+Entries older than history.retention in the config (90 days by default) are
+not shown, and are removed from the history whenever a bookmark is opened.`,
+	RunE: runRecent,
+}
+
+func init() {
+	rootCmd.AddCommand(recentCmd)
+
+	recentCmd.Flags().Bool("keep-queued", false, "Keep a queued bookmark in the queue, marked as reading, instead of marking it done")
+}
+
+func runRecent(cmd *cobra.Command, args []string) error {
+	keepQueued, err := cmd.Flags().GetBool("keep-queued")
+	if err != nil {
+		return fmt.Errorf("failed to get keep-queued flag: %w", err)
+	}
+
+	repo, err := storage.NewDefaultJSONStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	hist, err := newHistory()
+	if err != nil {
+		return err
+	}
+
+	listUc := usecase.NewListRecentBookmarks(repo, hist)
+	recent, err := listUc.Execute()
+	if err != nil {
+		return fmt.Errorf("failed to list history: %w", err)
+	}
+	if len(recent) == 0 {
+		fmt.Println("No bookmarks opened yet.")
+		return nil
+	}
+
+	// Show the URLs as they were opened.
+	items := make([]bookmark.Bookmark, len(recent))
+	for i, r := range recent {
+		items[i] = r.Bookmark
+		items[i].URL = r.URL
+		items[i].Variants = nil
+	}
+
+	sel, err := newSelector()
+	if err != nil {
+		return fmt.Errorf("failed to initialize selector: %w", err)
+	}
+	selected, err := sel.Select(items)
+	if err != nil {
+		if errors.Is(err, selector.ErrCancelled) {
+			return nil
+		}
+		return fmt.Errorf("failed to select bookmark: %w", err)
+	}
+	idx := slices.IndexFunc(items, func(bm bookmark.Bookmark) bool {
+		return bm.ID == selected.ID && bm.URL == selected.URL
+	})
+
+	op, err := newOpener()
+	if err != nil {
+		return fmt.Errorf("failed to initialize opener: %w", err)
+	}
+
+	openUc := usecase.NewOpenBookmark(repo, op, hist)
+	output, err := openUc.Execute(usecase.OpenBookmarkInput{
+		Bookmark:   recent[idx].Bookmark,
+		URL:        recent[idx].URL,
+		KeepStatus: keepQueued,
+	})
+	warnHistory(output.HistoryErr)
+	if err != nil {
+		return fmt.Errorf("failed to open bookmark: %w", err)
+	}
+
+	return nil
+}
+
+// historyRetention is how long opened bookmarks are kept in the history.
+func historyRetention() time.Duration {
+	if retention := time.Duration(appConfig.History.Retention); retention > 0 {
+		return retention
+	}
+	return defaultHistoryRetention
+}
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/linkcheck"
+	"github.com/airRnot1106/bkm/internal/storage"
+	"github.com/airRnot1106/bkm/internal/usecase"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+// resolveURLsCmd represents the resolve-urls command
+var resolveURLsCmd = &cobra.Command{
+	Use:   "resolve-urls",
+	Short: "Replace URLs that permanently redirect",
+	Long: `Follow the redirects of bookmark URLs and replace the stored URL with the
+new location when the redirect is permanent (301 or 308) or only upgrades
+http to https. The old and new URLs are shown for confirmation first. New
+URLs are cleaned and checked for secrets like the URLs given to bkm add.
+
+Mark the bookmarks to resolve with Tab:
+  bkm resolve-urls
+
+Or resolve every bookmark, or the ones with the given tags:
+  bkm resolve-urls --all
+  bkm resolve-urls --tags docs`,
+	RunE: runResolveURLs,
+}
+
+func init() {
+	rootCmd.AddCommand(resolveURLsCmd)
+
+	resolveURLsCmd.Flags().Bool("all", false, "Resolve every bookmark")
+	resolveURLsCmd.Flags().StringSliceP("tags", "T", []string{}, "Resolve every bookmark with these tags (comma-separated)")
+	resolveURLsCmd.Flags().BoolP("yes", "y", false, "Replace the URLs without asking for confirmation")
+	resolveURLsCmd.Flags().String("secrets", "", "What to do with secrets in new URLs: refuse, redact or warn (default from config, else refuse)")
+}
+
+func runResolveURLs(cmd *cobra.Command, args []string) error {
+	all, err := cmd.Flags().GetBool("all")
+	if err != nil {
+		return fmt.Errorf("failed to get all flag: %w", err)
+	}
+	tags, err := cmd.Flags().GetStringSlice("tags")
+	if err != nil {
+		return fmt.Errorf("failed to get tags flag: %w", err)
+	}
+	yes, err := cmd.Flags().GetBool("yes")
+	if err != nil {
+		return fmt.Errorf("failed to get yes flag: %w", err)
+	}
+
+	policy, err := newURLPolicy(cmd)
+	if err != nil {
+		return err
+	}
+	repo, err := storage.NewDefaultJSONStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	bookmarks, err := listOrSelectBookmarks(repo, all, tags)
+	if err != nil || len(bookmarks) == 0 {
+		return err
+	}
+
+	findUc := usecase.NewFindURLUpgrades(newChecker(configCheckOptions()), policy)
+	output, err := findUc.Execute(usecase.FindURLUpgradesInput{Bookmarks: bookmarks})
+	if err != nil {
+		return fmt.Errorf("failed to resolve URLs: %w", err)
+	}
+	for _, refused := range output.Refused {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", refused)
+	}
+	upgrades := output.Upgrades
+	if len(upgrades) == 0 {
+		fmt.Println("All URLs are up to date.")
+		return nil
+	}
+
+	printURLUpgrades(upgrades)
+	if !yes && !confirmURLUpgrades(len(upgrades)) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	upgradeUc := usecase.NewUpgradeBookmarkURLs(repo)
+	updated, err := upgradeUc.Execute(usecase.UpgradeBookmarkURLsInput{Upgrades: upgrades})
+	if err != nil {
+		return fmt.Errorf("failed to replace URLs: %w", err)
+	}
+
+	fmt.Printf("✓ Replaced %d URLs.\n", len(updated))
+	return nil
+}
+
+// listOrSelectBookmarks returns every bookmark with all, the ones with the
+// given tags, or lets the user mark them. It returns no bookmarks when the
+// user cancels.
+func listOrSelectBookmarks(repo bookmark.Repository, all bool, tags []string) ([]bookmark.Bookmark, error) {
+	listUc := usecase.NewListBookmarks(repo)
+	bookmarks, err := listUc.Execute(usecase.ListBookmarksInput{Tags: tags})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bookmarks: %w", err)
+	}
+	if len(bookmarks) == 0 {
+		fmt.Println("No bookmarks found.")
+		return nil, nil
+	}
+
+	if all || len(tags) > 0 {
+		return bookmarks, nil
+	}
+	return selectBookmarks(bookmarks)
+}
+
+func printURLUpgrades(upgrades []usecase.URLUpgrade) {
+	for _, upgrade := range upgrades {
+		title := upgrade.Bookmark.Title.Value()
+		if upgrade.Variant != (bookmark.BookmarkVariantName{}) {
+			title += fmt.Sprintf(" [%s]", upgrade.Variant.Value())
+		}
+		if len(upgrade.Redirects) > 0 {
+			title += fmt.Sprintf(" (%s)", formatRedirectCodes(upgrade.Redirects))
+		}
+		oldURL, err := upgrade.Bookmark.URLFor(upgrade.Variant)
+		if err != nil {
+			continue
+		}
+		fmt.Printf("  %s\n    %s\n  → %s\n", title, oldURL.Value(), upgrade.URL.Value())
+	}
+	fmt.Println()
+}
+
+func formatRedirectCodes(redirects []linkcheck.Redirect) string {
+	codes := make([]string, len(redirects))
+	for i, redirect := range redirects {
+		codes[i] = strconv.Itoa(redirect.StatusCode)
+	}
+	return strings.Join(codes, " → ")
+}
+
+func confirmURLUpgrades(count int) bool {
+	prompt := promptui.Prompt{
+		Label:     fmt.Sprintf("Replace %d URLs", count),
+		IsConfirm: true,
+	}
+	_, err := prompt.Run()
+	return err == nil
+}
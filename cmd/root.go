@@ -1,8 +1,12 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
 	"os"
+	"strings"
 
+	"github.com/airRnot1106/bkm/internal/bookmark"
 	"github.com/spf13/cobra"
 )
 
@@ -17,7 +21,14 @@ var rootCmd = &cobra.Command{
 	Long: `bkm is a command-line bookmark manager that allows you to:
   - Add bookmarks with URLs, titles, descriptions, and tags
   - Search bookmarks with fuzzy finder
-  - Open bookmarks in your default browser`,
+  - Open bookmarks in your default browser
+
+Run "bkm <keyword> [args...]" to open the bookmark with that keyword directly.`,
+	// Arguments that do not name a subcommand are treated as a bookmark keyword
+	// followed by URL template arguments.
+	Args:              cobra.ArbitraryArgs,
+	PersistentPreRunE: loadConfig,
+	RunE:              runRoot,
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -31,4 +42,47 @@ func Execute() {
 
 func init() {
 	// Add subcommands here
+
+	addOpenFlags(rootCmd)
+	rootCmd.PersistentFlags().StringVarP(&profile, "profile", "p", "", "Profile of URL variables to use (defaults to $BKM_PROFILE)")
+}
+
+func runRoot(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		return cmd.Help()
+	}
+	opts, err := getOpenOptions(cmd)
+	if err != nil {
+		return err
+	}
+	opts.templateArgs = args[1:]
+
+	err = openByKeyword(args[0], opts)
+	if errors.Is(err, bookmark.ErrBookmarkNotFound) {
+		return unknownCommandError(cmd, args[0], err)
+	}
+	return err
+}
+
+// commandNames returns the names and aliases of the subcommands. A keyword
+// with one of them could never be opened with "bkm <keyword>", since the
+// subcommand is run instead.
+func commandNames() []string {
+	var names []string
+	for _, c := range rootCmd.Commands() {
+		names = append(names, c.Name())
+		names = append(names, c.Aliases...)
+	}
+	return names
+}
+
+// unknownCommandError reports an argument that is neither a keyword nor a
+// subcommand like cobra does for a mistyped subcommand, when there are
+// subcommands it resembles. Otherwise notFound is returned.
+func unknownCommandError(cmd *cobra.Command, arg string, notFound error) error {
+	suggestions := cmd.SuggestionsFor(arg)
+	if len(suggestions) == 0 {
+		return notFound
+	}
+	return fmt.Errorf("unknown command %q for %q\n\nDid you mean this?\n\t%s", arg, cmd.CommandPath(), strings.Join(suggestions, "\n\t"))
 }
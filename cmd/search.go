@@ -4,7 +4,7 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/airRnot1106/bkm/internal/opener"
+	"github.com/airRnot1106/bkm/internal/fulltext"
 	"github.com/airRnot1106/bkm/internal/selector"
 	"github.com/airRnot1106/bkm/internal/storage"
 	"github.com/airRnot1106/bkm/internal/usecase"
@@ -21,7 +21,14 @@ You can filter by tags:
   bkm search --tags go,cli
 
 Or run without flags to search all bookmarks:
-  bkm search`,
+  bkm search
+
+Search the text of archived pages instead of titles and URLs. Matches are
+ranked by relevance before the fuzzy finder opens:
+  bkm search --content "borrow checker lifetimes"
+
+Pages are indexed when they are archived with "bkm archive". Run "bkm index
+--fetch" to also index the live pages of bookmarks that were not archived.`,
 	RunE: runSearch,
 }
 
@@ -29,6 +36,8 @@ func init() {
 	rootCmd.AddCommand(searchCmd)
 
 	searchCmd.Flags().StringSliceP("tags", "T", []string{}, "Filter by tags (comma-separated)")
+	searchCmd.Flags().StringP("content", "c", "", "Rank bookmarks by how well their page content matches this query")
+	addOpenFlags(searchCmd)
 }
 
 func runSearch(cmd *cobra.Command, args []string) error {
@@ -36,6 +45,17 @@ func runSearch(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to get tags flag: %w", err)
 	}
+	opts, err := getOpenOptions(cmd)
+	if err != nil {
+		return err
+	}
+	if cmd.Flags().Changed("content") {
+		query, err := cmd.Flags().GetString("content")
+		if err != nil {
+			return fmt.Errorf("failed to get content flag: %w", err)
+		}
+		return searchContent(query, tags, opts)
+	}
 
 	input := usecase.SearchBookmarkInput{
 		Tags: tags,
@@ -46,7 +66,10 @@ func runSearch(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to initialize storage: %w", err)
 	}
 
-	sel := selector.NewFuzzyFinderSelector()
+	sel, err := newSelector()
+	if err != nil {
+		return fmt.Errorf("failed to initialize selector: %w", err)
+	}
 
 	selectUc := usecase.NewSearchBookmark(repo, sel)
 
@@ -58,17 +81,42 @@ func runSearch(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to search bookmark: %w", err)
 	}
 
-	op := opener.NewBrowserOpener()
+	return openBookmark(repo, bookmark, opts)
+}
+
+func searchContent(query string, tags []string, opts openOptions) error {
+	repo, err := storage.NewDefaultJSONStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	index, err := storage.NewDefaultIndexJSONStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize index storage: %w", err)
+	}
 
-	openUc := usecase.NewOpenBookmark(op)
+	// Pick up pages archived since the last search. This only reads local
+	// files, so it is cheap when nothing changed.
+	if _, err := updateContentIndex(repo, index, false); err != nil {
+		return err
+	}
 
-	openInput := usecase.OpenBookmarkInput{
-		Bookmark: bookmark,
+	sel, err := newSelector()
+	if err != nil {
+		return fmt.Errorf("failed to initialize selector: %w", err)
 	}
 
-	if err := openUc.Execute(openInput); err != nil {
-		return fmt.Errorf("failed to open bookmark: %w", err)
+	searchUc := usecase.NewSearchBookmarkContent(repo, index, sel)
+	bm, err := searchUc.Execute(usecase.SearchBookmarkContentInput{Query: query, Tags: tags})
+	if err != nil {
+		if errors.Is(err, fulltext.ErrNoMatches) {
+			fmt.Println("No bookmarks found.")
+			return nil
+		}
+		if errors.Is(err, selector.ErrCancelled) {
+			return nil
+		}
+		return fmt.Errorf("failed to search bookmark content: %w", err)
 	}
 
-	return nil
+	return openBookmark(repo, bm, opts)
 }
@@ -0,0 +1,297 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/storage"
+	"github.com/airRnot1106/bkm/internal/usecase"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+// sessionCmd represents the session command
+var sessionCmd = &cobra.Command{
+	Use:   "session",
+	Short: "Manage named, ordered sets of bookmarks",
+	Long: `Sessions are named, ordered sets of bookmarks that are opened together.
+
+Create a session and add bookmarks to it by keyword or with the fuzzy finder:
+  bkm session create incident-response
+  bkm session add incident-response pager runbook logs
+  bkm session add incident-response
+
+Then open all of them in order:
+  bkm session open incident-response
+
+Sessions refer to bookmarks by ID, so editing a bookmark keeps its sessions intact.`,
+}
+
+var sessionCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create an empty session",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSessionCreate,
+}
+
+var sessionAddCmd = &cobra.Command{
+	Use:   "add <name> [keyword...]",
+	Short: "Add bookmarks to the end of a session",
+	Long: `Add the bookmarks with the given keywords to the end of a session.
+
+Without keywords, mark bookmarks with Tab in the fuzzy finder. Bookmarks that
+are already in the session are skipped.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runSessionAdd,
+}
+
+var sessionRmCmd = &cobra.Command{
+	Use:   "rm <name>",
+	Short: "Remove bookmarks from a session",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSessionRm,
+}
+
+var sessionOpenCmd = &cobra.Command{
+	Use:   "open <name>",
+	Short: "Open every bookmark of a session in order",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSessionOpen,
+}
+
+var sessionListCmd = &cobra.Command{
+	Use:   "list [name]",
+	Short: "List sessions, or the bookmarks of a session",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runSessionList,
+}
+
+var sessionDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a session without deleting its bookmarks",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSessionDelete,
+}
+
+func init() {
+	rootCmd.AddCommand(sessionCmd)
+	sessionCmd.AddCommand(sessionCreateCmd, sessionAddCmd, sessionRmCmd, sessionOpenCmd, sessionListCmd, sessionDeleteCmd)
+
+	sessionAddCmd.Flags().StringSliceP("tags", "T", []string{}, "Filter bookmarks to choose from by tags (comma-separated)")
+	addBulkOpenFlags(sessionOpenCmd)
+	sessionOpenCmd.Flags().Bool("keep-queued", false, "Keep queued bookmarks in the queue, marked as reading, instead of marking them done")
+}
+
+func runSessionCreate(cmd *cobra.Command, args []string) error {
+	sessions, err := storage.NewDefaultSessionJSONStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize session storage: %w", err)
+	}
+
+	uc := usecase.NewCreateSession(sessions)
+	sess, err := uc.Execute(usecase.CreateSessionInput{Name: args[0]})
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	fmt.Printf("✓ Session created: %s\n", sess.Name.Value())
+	return nil
+}
+
+func runSessionAdd(cmd *cobra.Command, args []string) error {
+	tags, err := cmd.Flags().GetStringSlice("tags")
+	if err != nil {
+		return fmt.Errorf("failed to get tags flag: %w", err)
+	}
+
+	repo, err := storage.NewDefaultJSONStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	sessions, err := storage.NewDefaultSessionJSONStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize session storage: %w", err)
+	}
+
+	var bookmarks []bookmark.Bookmark
+	if keywords := args[1:]; len(keywords) > 0 {
+		findUc := usecase.NewFindBookmarkByKeyword(repo)
+		for _, keyword := range keywords {
+			bm, err := findUc.Execute(usecase.FindBookmarkByKeywordInput{Keyword: keyword})
+			if err != nil {
+				return fmt.Errorf("failed to find bookmark: %w", err)
+			}
+			bookmarks = append(bookmarks, bm)
+		}
+	} else {
+		listUc := usecase.NewListBookmarks(repo)
+		candidates, err := listUc.Execute(usecase.ListBookmarksInput{Tags: tags})
+		if err != nil {
+			return fmt.Errorf("failed to list bookmarks: %w", err)
+		}
+		bookmarks, err = selectBookmarks(candidates)
+		if err != nil || len(bookmarks) == 0 {
+			return err
+		}
+	}
+
+	uc := usecase.NewAddToSession(sessions)
+	sess, err := uc.Execute(usecase.AddToSessionInput{Name: args[0], Bookmarks: bookmarks})
+	if err != nil {
+		return fmt.Errorf("failed to add to session: %w", err)
+	}
+
+	fmt.Printf("✓ Session %s now has %d bookmark(s)\n", sess.Name.Value(), len(sess.BookmarkIDs))
+	return nil
+}
+
+func runSessionRm(cmd *cobra.Command, args []string) error {
+	repo, err := storage.NewDefaultJSONStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	sessions, err := storage.NewDefaultSessionJSONStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize session storage: %w", err)
+	}
+
+	getUc := usecase.NewGetSessionBookmarks(sessions, repo)
+	resolved, err := getUc.Execute(usecase.GetSessionBookmarksInput{Name: args[0]})
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+
+	// Deleted bookmarks cannot be shown in the fuzzy finder, so they are
+	// removed along with the selected ones.
+	ids := resolved.Missing
+	if len(resolved.Bookmarks) > 0 {
+		selected, err := selectBookmarks(resolved.Bookmarks)
+		if err != nil || len(selected) == 0 {
+			return err
+		}
+		for _, bm := range selected {
+			ids = append(ids, bm.ID)
+		}
+	}
+	if len(ids) == 0 {
+		fmt.Println("The session is empty.")
+		return nil
+	}
+
+	uc := usecase.NewRemoveFromSession(sessions)
+	sess, err := uc.Execute(usecase.RemoveFromSessionInput{Name: args[0], BookmarkIDs: ids})
+	if err != nil {
+		return fmt.Errorf("failed to remove from session: %w", err)
+	}
+
+	fmt.Printf("✓ Session %s now has %d bookmark(s)\n", sess.Name.Value(), len(sess.BookmarkIDs))
+	return nil
+}
+
+func runSessionOpen(cmd *cobra.Command, args []string) error {
+	bulk, err := getBulkOpenOptions(cmd)
+	if err != nil {
+		return err
+	}
+	keepQueued, err := cmd.Flags().GetBool("keep-queued")
+	if err != nil {
+		return fmt.Errorf("failed to get keep-queued flag: %w", err)
+	}
+
+	repo, err := storage.NewDefaultJSONStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	sessions, err := storage.NewDefaultSessionJSONStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize session storage: %w", err)
+	}
+	op, err := newOpener()
+	if err != nil {
+		return fmt.Errorf("failed to initialize opener: %w", err)
+	}
+
+	hist, err := newHistory()
+	if err != nil {
+		return err
+	}
+
+	uc := usecase.NewOpenSession(sessions, repo, op, hist)
+	output, err := uc.Execute(usecase.OpenSessionInput{
+		Name:        args[0],
+		Delay:       bulk.delay,
+		Concurrency: bulk.concurrency,
+		KeepStatus:  keepQueued,
+	})
+	warnHistory(output.HistoryErrs...)
+	if err != nil {
+		return fmt.Errorf("failed to open session: %w", err)
+	}
+
+	return nil
+}
+
+func runSessionList(cmd *cobra.Command, args []string) error {
+	sessions, err := storage.NewDefaultSessionJSONStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize session storage: %w", err)
+	}
+
+	if len(args) == 0 {
+		uc := usecase.NewListSessions(sessions)
+		all, err := uc.Execute()
+		if err != nil {
+			return err
+		}
+		if len(all) == 0 {
+			fmt.Println("No sessions found.")
+			return nil
+		}
+		for _, sess := range all {
+			fmt.Printf("%s (%d)\n", sess.Name.Value(), len(sess.BookmarkIDs))
+		}
+		return nil
+	}
+
+	repo, err := storage.NewDefaultJSONStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	uc := usecase.NewGetSessionBookmarks(sessions, repo)
+	resolved, err := uc.Execute(usecase.GetSessionBookmarksInput{Name: args[0]})
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+	for i, bm := range resolved.Bookmarks {
+		fmt.Printf("%d. %s | %s\n", i+1, bm.Title.Value(), bm.URL.Value())
+	}
+	if len(resolved.Missing) > 0 {
+		fmt.Printf("%d deleted bookmark(s); remove them with \"bkm session rm %s\"\n", len(resolved.Missing), resolved.Session.Name.Value())
+	}
+	return nil
+}
+
+func runSessionDelete(cmd *cobra.Command, args []string) error {
+	sessions, err := storage.NewDefaultSessionJSONStorage()
+	if err != nil {
+		return fmt.Errorf("failed to initialize session storage: %w", err)
+	}
+
+	prompt := promptui.Prompt{
+		Label:     fmt.Sprintf("Are you sure you want to delete the session %s", args[0]),
+		IsConfirm: true,
+	}
+	if _, err := prompt.Run(); err != nil {
+		fmt.Println("Deletion cancelled.")
+		return nil
+	}
+
+	uc := usecase.NewDeleteSession(sessions)
+	if err := uc.Execute(usecase.DeleteSessionInput{Name: args[0]}); err != nil {
+		return err
+	}
+
+	fmt.Println("Session deleted successfully.")
+	return nil
+}
@@ -8,7 +8,7 @@ buildGoModule (finalAttrs: {
 
   src = ./.;
 
-  vendorHash = "sha256-QRixhOMcYd0FENifqzSyakM/9UgXOAaZtwKoaJUEshI=";
+  vendorHash = "sha256-fnR3RCSfgbfgue13UnmPBx5EiZ9+55MgiW/ZE+mWOvs=";
 
   ldflags = [
     "-s"
@@ -2,7 +2,11 @@ package bookmark
 
 import (
 	"errors"
+	"fmt"
+	"net"
 	"net/url"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -36,16 +40,168 @@ func (u BookmarkURL) Value() string {
 	return u.value
 }
 
+// Scheme returns the lower-cased scheme of the URL.
+func (u BookmarkURL) Scheme() string {
+	scheme, _, _ := strings.Cut(u.value, ":")
+	return strings.ToLower(scheme)
+}
+
+// Canonical returns the URL normalized for finding duplicates: the scheme
+// and host are lower-cased, default ports and trailing slashes are removed and
+// query parameters are sorted. URLs that cannot be parsed, such as templates,
+// are returned as they are.
+func (u BookmarkURL) Canonical() string {
+	parsed, err := url.Parse(u.value)
+	if err != nil || parsed.Opaque != "" {
+		return u.value
+	}
+
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	host := strings.ToLower(parsed.Hostname())
+	if port := parsed.Port(); port != "" && !isDefaultPort(parsed.Scheme, port) {
+		host = net.JoinHostPort(host, port)
+	} else if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+	parsed.Host = host
+
+	parsed.Path = strings.TrimRight(parsed.Path, "/")
+	parsed.RawPath = strings.TrimRight(parsed.RawPath, "/")
+	if parsed.RawQuery != "" {
+		params := strings.Split(parsed.RawQuery, "&")
+		slices.Sort(params)
+		parsed.RawQuery = strings.Join(params, "&")
+	}
+	parsed.ForceQuery = false
+	return parsed.String()
+}
+
+func isDefaultPort(scheme, port string) bool {
+	return (scheme == "http" && port == "80") || (scheme == "https" && port == "443")
+}
+
+// IsTemplate reports whether the URL contains placeholders that have to be
+// filled before it can be opened.
+func (u BookmarkURL) IsTemplate() bool {
+	return strings.Contains(u.value, templatePlaceholder)
+}
+
+// Fill replaces the placeholders of a URL template with args in order. When
+// there are more args than placeholders, the remaining args are joined with
+// spaces into the last placeholder. Values are escaped for the part of the
+// URL they end up in.
+func (u BookmarkURL) Fill(args []string) (BookmarkURL, error) {
+	if !u.IsTemplate() {
+		return BookmarkURL{}, ErrNotTemplate
+	}
+
+	count := strings.Count(u.value, templatePlaceholder)
+	if len(args) < count {
+		return BookmarkURL{}, fmt.Errorf("%w: expected %d, got %d", ErrMissingTemplateArgs, count, len(args))
+	}
+
+	values := make([]string, 0, count)
+	values = append(values, args[:count-1]...)
+	values = append(values, strings.Join(args[count-1:], " "))
+
+	queryStart := strings.IndexAny(u.value, "?#")
+	parts := strings.Split(u.value, templatePlaceholder)
+
+	var b strings.Builder
+	pos := 0
+	for i, part := range parts {
+		b.WriteString(part)
+		if i == len(values) {
+			break
+		}
+		pos += len(part)
+		if queryStart >= 0 && pos > queryStart {
+			b.WriteString(url.QueryEscape(values[i]))
+		} else {
+			b.WriteString(url.PathEscape(values[i]))
+		}
+		pos += len(templatePlaceholder)
+	}
+
+	return NewBookmarkURL(b.String())
+}
+
+// Variables returns the names of the ${NAME} references in the URL in order
+// of appearance, without duplicates.
+func (u BookmarkURL) Variables() []string {
+	var names []string
+	for _, match := range variablePattern.FindAllStringSubmatch(u.value, -1) {
+		if !slices.Contains(names, match[1]) {
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
+
+// Expand replaces the ${NAME} references in the URL with values from lookup.
+func (u BookmarkURL) Expand(lookup func(name string) (string, bool)) (BookmarkURL, error) {
+	var missing []string
+	expanded := variablePattern.ReplaceAllStringFunc(u.value, func(ref string) string {
+		name := variablePattern.FindStringSubmatch(ref)[1]
+		value, ok := lookup(name)
+		if !ok {
+			if !slices.Contains(missing, name) {
+				missing = append(missing, name)
+			}
+			return ref
+		}
+		return value
+	})
+	if len(missing) > 0 {
+		return BookmarkURL{}, fmt.Errorf("%w: %s", ErrUndefinedVariable, strings.Join(missing, ", "))
+	}
+
+	return NewBookmarkURL(expanded)
+}
+
+const (
+	templatePlaceholder = "%s"
+	placeholderSample   = "bkmplaceholder"
+	// variableSample stands in for ${NAME} references during validation. It is
+	// numeric so that references are also accepted in the port.
+	variableSample = "0"
+)
+
+var variablePattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+var (
+	ErrNotTemplate         = errors.New("URL is not a template")
+	ErrMissingTemplateArgs = errors.New("not enough arguments for URL template")
+	ErrUndefinedVariable   = errors.New("undefined URL variable")
+)
+
 func NewBookmarkURL(rawURL string) (BookmarkURL, error) {
 	if rawURL == "" {
 		return BookmarkURL{}, errors.New("URL cannot be empty")
 	}
-	parsed, err := url.ParseRequestURI(rawURL)
+	// Placeholders are not valid percent-encodings and variable references are
+	// not valid in a host, so validate a sample instead.
+	sample := strings.ReplaceAll(rawURL, templatePlaceholder, placeholderSample)
+	sample = variablePattern.ReplaceAllString(sample, variableSample)
+	parsed, err := url.Parse(sample)
+
+	if err != nil || parsed.Scheme == "" {
+		return BookmarkURL{}, errors.New("invalid URL format")
+	}
 
-	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+	switch {
+	case isNetworkScheme(parsed.Scheme):
+		if parsed.Host == "" {
+			return BookmarkURL{}, errors.New("invalid URL format")
+		}
+	case parsed.Opaque == "" && parsed.Host == "" && parsed.Path == "":
 		return BookmarkURL{}, errors.New("invalid URL format")
 	}
 
+	if strings.Contains(parsed.Scheme, placeholderSample) || strings.Contains(parsed.Host, placeholderSample) {
+		return BookmarkURL{}, errors.New("URL template placeholders are only allowed in the path, query or fragment")
+	}
+
 	return BookmarkURL{value: rawURL}, nil
 }
 
@@ -81,6 +237,21 @@ func NewBookmarkDescription(description string) BookmarkDescription {
 	return BookmarkDescription{value: trimmed}
 }
 
+type BookmarkNotes struct {
+	value string
+}
+
+func (n BookmarkNotes) Value() string {
+	return n.value
+}
+
+// NewBookmarkNotes keeps the Markdown body as-is apart from surrounding blank space.
+func NewBookmarkNotes(notes string) BookmarkNotes {
+	trimmed := strings.TrimSpace(notes)
+
+	return BookmarkNotes{value: trimmed}
+}
+
 type BookmarkTag struct {
 	value string
 }
@@ -99,16 +270,143 @@ func NewBookmarkTag(tag string) (BookmarkTag, error) {
 	return BookmarkTag{value: trimmed}, nil
 }
 
+type BookmarkKeyword struct {
+	value string
+}
+
+func (k BookmarkKeyword) Value() string {
+	return k.value
+}
+
+var keywordPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+func NewBookmarkKeyword(keyword string) (BookmarkKeyword, error) {
+	trimmed := strings.TrimSpace(keyword)
+
+	if trimmed == "" {
+		return BookmarkKeyword{}, errors.New("keyword cannot be empty")
+	}
+	if !keywordPattern.MatchString(trimmed) {
+		return BookmarkKeyword{}, errors.New("keyword may only contain letters, digits, '.', '_' and '-'")
+	}
+
+	return BookmarkKeyword{value: trimmed}, nil
+}
+
+type BookmarkVariantName struct {
+	value string
+}
+
+func (n BookmarkVariantName) Value() string {
+	return n.value
+}
+
+func NewBookmarkVariantName(name string) (BookmarkVariantName, error) {
+	trimmed := strings.TrimSpace(name)
+
+	if trimmed == "" {
+		return BookmarkVariantName{}, errors.New("variant name cannot be empty")
+	}
+	if !keywordPattern.MatchString(trimmed) {
+		return BookmarkVariantName{}, errors.New("variant name may only contain letters, digits, '.', '_' and '-'")
+	}
+
+	return BookmarkVariantName{value: trimmed}, nil
+}
+
+var ErrUnknownVariant = errors.New("unknown URL variant")
+
+type BookmarkStatus struct {
+	value string
+}
+
+var (
+	StatusNone    = BookmarkStatus{}
+	StatusUnread  = BookmarkStatus{value: "unread"}
+	StatusReading = BookmarkStatus{value: "reading"}
+	StatusDone    = BookmarkStatus{value: "done"}
+)
+
+func (s BookmarkStatus) Value() string {
+	return s.value
+}
+
+// IsQueued reports whether the bookmark is still waiting in the read-later queue.
+func (s BookmarkStatus) IsQueued() bool {
+	return s == StatusUnread || s == StatusReading
+}
+
+func NewBookmarkStatus(status string) (BookmarkStatus, error) {
+	switch strings.TrimSpace(status) {
+	case StatusNone.value:
+		return StatusNone, nil
+	case StatusUnread.value:
+		return StatusUnread, nil
+	case StatusReading.value:
+		return StatusReading, nil
+	case StatusDone.value:
+		return StatusDone, nil
+	default:
+		return BookmarkStatus{}, errors.New("status must be one of unread, reading or done")
+	}
+}
+
+// LinkCheck is the outcome of the last check of the bookmark URL. StatusCode
+// is 0 when the server could not be reached. The zero value means the URL was
+// never checked.
+type LinkCheck struct {
+	StatusCode int
+	CheckedAt  time.Time
+}
+
+// Snapshot refers to the offline copy of the page by the digest of its
+// content. The zero value means the page was never archived.
+type Snapshot struct {
+	Digest     string
+	ArchivedAt time.Time
+}
+
 type Bookmark struct {
 	ID          BookmarkID
 	URL         BookmarkURL
 	Title       BookmarkTitle
 	Description BookmarkDescription
+	Notes       BookmarkNotes
 	Tags        []BookmarkTag
+	Keyword     BookmarkKeyword
+	Variants    map[BookmarkVariantName]BookmarkURL
+	Status      BookmarkStatus
+	LastCheck   LinkCheck
+	Snapshot    Snapshot
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
 
+// VariantNames returns the names of the URL variants in alphabetical order.
+func (b Bookmark) VariantNames() []BookmarkVariantName {
+	names := make([]BookmarkVariantName, 0, len(b.Variants))
+	for name := range b.Variants {
+		names = append(names, name)
+	}
+	slices.SortFunc(names, func(x, y BookmarkVariantName) int {
+		return strings.Compare(x.value, y.value)
+	})
+	return names
+}
+
+// URLFor returns the URL of the named variant, or the primary URL when name
+// is the zero value.
+func (b Bookmark) URLFor(name BookmarkVariantName) (BookmarkURL, error) {
+	if name == (BookmarkVariantName{}) {
+		return b.URL, nil
+	}
+	url, ok := b.Variants[name]
+	if !ok {
+		return BookmarkURL{}, fmt.Errorf("%w: %s", ErrUnknownVariant, name.value)
+	}
+	return url, nil
+}
+
 func NewBookmark(id BookmarkID, url BookmarkURL, title BookmarkTitle, description BookmarkDescription, tags []BookmarkTag, createdAt time.Time, updatedAt time.Time) Bookmark {
 	return Bookmark{
 		ID:          id,
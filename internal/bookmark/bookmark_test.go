@@ -1,6 +1,7 @@
 package bookmark_test
 
 import (
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -309,3 +310,333 @@ func TestCreateBookmark_ValidInputAlwaysSucceeds(t *testing.T) {
 		}
 	})
 }
+
+func TestNewBookmarkStatus_KnownStatusesAlwaysSucceed(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		status := rapid.SampledFrom([]bookmark.BookmarkStatus{
+			bookmark.StatusNone,
+			bookmark.StatusUnread,
+			bookmark.StatusReading,
+			bookmark.StatusDone,
+		}).Draw(t, "status")
+
+		parsed, err := bookmark.NewBookmarkStatus(status.Value())
+		if err != nil {
+			t.Fatalf("known status %q should succeed, got error: %v", status.Value(), err)
+		}
+
+		if parsed != status {
+			t.Fatalf("status should round-trip: expected %q, got %q", status.Value(), parsed.Value())
+		}
+	})
+}
+
+func TestNewBookmarkStatus_UnknownStatusesAlwaysFail(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		status := rapid.StringMatching(`[a-z]+`).Filter(func(s string) bool {
+			return s != "unread" && s != "reading" && s != "done"
+		}).Draw(t, "status")
+
+		_, err := bookmark.NewBookmarkStatus(status)
+
+		if err == nil {
+			t.Fatalf("unknown status %q should fail", status)
+		}
+	})
+}
+
+func TestBookmarkStatus_IsQueued(t *testing.T) {
+	tests := []struct {
+		status   bookmark.BookmarkStatus
+		expected bool
+	}{
+		{bookmark.StatusNone, false},
+		{bookmark.StatusUnread, true},
+		{bookmark.StatusReading, true},
+		{bookmark.StatusDone, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.status.IsQueued(); got != tt.expected {
+			t.Errorf("IsQueued() for %q: expected %v, got %v", tt.status.Value(), tt.expected, got)
+		}
+	}
+}
+
+func TestNewBookmarkNotes_AnyStringAlwaysSucceeds(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		notes := rapid.String().Draw(t, "notes")
+
+		bookmarkNotes := bookmark.NewBookmarkNotes(notes)
+
+		trimmed := strings.TrimSpace(notes)
+
+		if bookmarkNotes.Value() != trimmed {
+			t.Fatalf("notes value should be preserved: expected %q, got %q",
+				trimmed, bookmarkNotes.Value())
+		}
+	})
+}
+
+func TestNewBookmarkKeyword_ValidKeywordsAlwaysSucceed(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		keyword := rapid.StringMatching(`[a-zA-Z0-9][a-zA-Z0-9._-]{0,15}`).Draw(t, "keyword")
+
+		bookmarkKeyword, err := bookmark.NewBookmarkKeyword(keyword)
+		if err != nil {
+			t.Fatalf("valid keyword %q should succeed, got error: %v", keyword, err)
+		}
+
+		if bookmarkKeyword.Value() != keyword {
+			t.Fatalf("keyword value should be preserved: expected %q, got %q",
+				keyword, bookmarkKeyword.Value())
+		}
+	})
+}
+
+func TestNewBookmarkKeyword_KeywordsWithWhitespaceAlwaysFail(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		keyword := rapid.StringMatching(`[a-z]+\s+[a-z]+`).Draw(t, "keyword")
+
+		_, err := bookmark.NewBookmarkKeyword(keyword)
+
+		if err == nil {
+			t.Fatalf("keyword with whitespace %q should fail", keyword)
+		}
+	})
+}
+
+func TestNewBookmarkKeyword_EmptyOrWhitespaceOnlyAlwaysFails(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		keyword := rapid.StringMatching(`\s*`).Draw(t, "keyword")
+
+		_, err := bookmark.NewBookmarkKeyword(keyword)
+
+		if err == nil {
+			t.Fatalf("empty or whitespace-only keyword %q should fail", keyword)
+		}
+	})
+}
+
+func TestNewBookmarkURL_TemplatesAlwaysSucceed(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		host := rapid.StringMatching(`[a-z0-9]+\.[a-z]{2,}`).Draw(t, "host")
+		rawURL := rapid.SampledFrom([]string{
+			"https://" + host + "/search?q=%s",
+			"https://" + host + "/browse/%s",
+			"https://" + host + "/%s/issues/%s",
+			"https://" + host + "/docs#%s",
+		}).Draw(t, "template")
+
+		bookmarkURL, err := bookmark.NewBookmarkURL(rawURL)
+		if err != nil {
+			t.Fatalf("valid template %q should succeed, got error: %v", rawURL, err)
+		}
+
+		if !bookmarkURL.IsTemplate() {
+			t.Fatalf("URL %q should be a template", rawURL)
+		}
+	})
+}
+
+func TestNewBookmarkURL_PlaceholderInSchemeOrHostAlwaysFails(t *testing.T) {
+	for _, rawURL := range []string{
+		"https://%s.example.com/",
+		"https://example.%s/",
+		"%s://example.com/",
+	} {
+		if _, err := bookmark.NewBookmarkURL(rawURL); err == nil {
+			t.Errorf("template %q with placeholder outside path, query or fragment should fail", rawURL)
+		}
+	}
+}
+
+func TestBookmarkURL_Canonical(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        string
+		b        string
+		expected bool
+	}{
+		{"scheme and host case", "HTTPS://Example.COM/Path", "https://example.com/Path", true},
+		{"path case is kept", "https://example.com/Path", "https://example.com/path", false},
+		{"default port", "https://example.com:443/a", "https://example.com/a", true},
+		{"other port", "https://example.com:8443/a", "https://example.com/a", false},
+		{"trailing slash", "https://example.com/docs/", "https://example.com/docs", true},
+		{"query order", "https://example.com/?b=2&a=1", "https://example.com/?a=1&b=2", true},
+		{"query values differ", "https://example.com/?a=1", "https://example.com/?a=2", false},
+		{"ipv6 host", "http://[::1]:80/", "http://[::1]", true},
+		{"scheme differs", "http://example.com/", "https://example.com/", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := bookmark.NewBookmarkURL(tt.a)
+			if err != nil {
+				t.Fatalf("setup failed: %v", err)
+			}
+			b, err := bookmark.NewBookmarkURL(tt.b)
+			if err != nil {
+				t.Fatalf("setup failed: %v", err)
+			}
+
+			if got := a.Canonical() == b.Canonical(); got != tt.expected {
+				t.Errorf("expected equal=%v, got %q and %q", tt.expected, a.Canonical(), b.Canonical())
+			}
+		})
+	}
+}
+
+func TestBookmarkURL_Fill(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		args     []string
+		expected string
+	}{
+		{
+			name:     "query placeholder is query-escaped",
+			template: "https://pkg.go.dev/search?q=%s",
+			args:     []string{"net/http", "client"},
+			expected: "https://pkg.go.dev/search?q=net%2Fhttp+client",
+		},
+		{
+			name:     "path placeholder is path-escaped",
+			template: "https://jira.example.com/browse/%s",
+			args:     []string{"ABC 123"},
+			expected: "https://jira.example.com/browse/ABC%20123",
+		},
+		{
+			name:     "multiple placeholders are filled in order",
+			template: "https://github.com/%s/%s/issues?q=%s",
+			args:     []string{"golang", "go", "is:open", "label:bug"},
+			expected: "https://github.com/golang/go/issues?q=is%3Aopen+label%3Abug",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template, err := bookmark.NewBookmarkURL(tt.template)
+			if err != nil {
+				t.Fatalf("setup failed: %v", err)
+			}
+
+			filled, err := template.Fill(tt.args)
+			if err != nil {
+				t.Fatalf("Fill should succeed: %v", err)
+			}
+
+			if filled.Value() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, filled.Value())
+			}
+			if filled.IsTemplate() {
+				t.Errorf("filled URL %q should not be a template", filled.Value())
+			}
+		})
+	}
+}
+
+func TestBookmarkURL_FillWithTooFewArgsFails(t *testing.T) {
+	template, _ := bookmark.NewBookmarkURL("https://github.com/%s/%s")
+
+	_, err := template.Fill([]string{"golang"})
+	if !errors.Is(err, bookmark.ErrMissingTemplateArgs) {
+		t.Fatalf("expected ErrMissingTemplateArgs, got %v", err)
+	}
+}
+
+func TestBookmarkURL_FillPlainURLFails(t *testing.T) {
+	plain, _ := bookmark.NewBookmarkURL("https://example.com")
+
+	_, err := plain.Fill([]string{"query"})
+	if !errors.Is(err, bookmark.ErrNotTemplate) {
+		t.Fatalf("expected ErrNotTemplate, got %v", err)
+	}
+}
+
+func TestNewBookmarkURL_VariableReferencesAlwaysSucceed(t *testing.T) {
+	for _, rawURL := range []string{
+		"http://localhost:${API_PORT}/debug",
+		"https://${ENV}.grafana.internal",
+		"https://grafana.internal/d/${DASHBOARD}?var-env=${ENV}",
+	} {
+		if _, err := bookmark.NewBookmarkURL(rawURL); err != nil {
+			t.Errorf("URL with variables %q should succeed, got error: %v", rawURL, err)
+		}
+	}
+}
+
+func TestBookmarkURL_Variables(t *testing.T) {
+	u, _ := bookmark.NewBookmarkURL("https://${ENV}.grafana.internal:${PORT}/?env=${ENV}")
+
+	names := u.Variables()
+
+	if len(names) != 2 || names[0] != "ENV" || names[1] != "PORT" {
+		t.Fatalf("expected [ENV PORT], got %v", names)
+	}
+}
+
+func TestBookmarkURL_Expand(t *testing.T) {
+	u, _ := bookmark.NewBookmarkURL("http://localhost:${API_PORT}/debug")
+	vars := map[string]string{"API_PORT": "8080"}
+
+	expanded, err := u.Expand(func(name string) (string, bool) {
+		value, ok := vars[name]
+		return value, ok
+	})
+	if err != nil {
+		t.Fatalf("Expand should succeed: %v", err)
+	}
+
+	if expanded.Value() != "http://localhost:8080/debug" {
+		t.Errorf("expected %q, got %q", "http://localhost:8080/debug", expanded.Value())
+	}
+}
+
+func TestBookmarkURL_ExpandUndefinedVariableFails(t *testing.T) {
+	u, _ := bookmark.NewBookmarkURL("https://${ENV}.grafana.internal")
+
+	_, err := u.Expand(func(string) (string, bool) { return "", false })
+	if !errors.Is(err, bookmark.ErrUndefinedVariable) {
+		t.Fatalf("expected ErrUndefinedVariable, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "ENV") {
+		t.Errorf("error should name the missing variable, got %q", err.Error())
+	}
+}
+
+func TestNewBookmarkVariantName_EmptyOrWhitespaceOnlyAlwaysFails(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		name := rapid.StringMatching(`\s*`).Draw(t, "name")
+
+		_, err := bookmark.NewBookmarkVariantName(name)
+
+		if err == nil {
+			t.Fatalf("empty or whitespace-only variant name %q should fail", name)
+		}
+	})
+}
+
+func TestBookmark_URLFor(t *testing.T) {
+	url, _ := bookmark.NewBookmarkURL("https://grafana.dev.example.com")
+	prodURL, _ := bookmark.NewBookmarkURL("https://grafana.example.com")
+	prod, _ := bookmark.NewBookmarkVariantName("prod")
+	staging, _ := bookmark.NewBookmarkVariantName("staging")
+	title, _ := bookmark.NewBookmarkTitle("Grafana")
+	bm := bookmark.CreateBookmark(url, title, bookmark.NewBookmarkDescription(""), nil)
+	bm.Variants = map[bookmark.BookmarkVariantName]bookmark.BookmarkURL{prod: prodURL}
+
+	primary, err := bm.URLFor(bookmark.BookmarkVariantName{})
+	if err != nil || primary != url {
+		t.Errorf("zero variant name should yield the primary URL, got %q, %v", primary.Value(), err)
+	}
+
+	variant, err := bm.URLFor(prod)
+	if err != nil || variant != prodURL {
+		t.Errorf("expected variant URL %q, got %q, %v", prodURL.Value(), variant.Value(), err)
+	}
+
+	if _, err := bm.URLFor(staging); !errors.Is(err, bookmark.ErrUnknownVariant) {
+		t.Errorf("expected ErrUnknownVariant, got %v", err)
+	}
+}
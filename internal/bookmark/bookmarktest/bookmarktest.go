@@ -0,0 +1,31 @@
+// Package bookmarktest builds bookmarks for tests.
+package bookmarktest
+
+import (
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+)
+
+// New returns a bookmark for rawURL, titled with the URL and tagged with
+// tagNames. Invalid values fail the test.
+func New(t testing.TB, rawURL string, tagNames ...string) bookmark.Bookmark {
+	t.Helper()
+	url, err := bookmark.NewBookmarkURL(rawURL)
+	if err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+	title, err := bookmark.NewBookmarkTitle(rawURL)
+	if err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+	var tags []bookmark.BookmarkTag
+	for _, name := range tagNames {
+		tag, err := bookmark.NewBookmarkTag(name)
+		if err != nil {
+			t.Fatalf("setup failed: %v", err)
+		}
+		tags = append(tags, tag)
+	}
+	return bookmark.CreateBookmark(url, title, bookmark.NewBookmarkDescription(""), tags)
+}
@@ -1,7 +1,25 @@
 package bookmark
 
+import "errors"
+
+var (
+	ErrBookmarkNotFound = errors.New("bookmark not found")
+	ErrDuplicateKeyword = errors.New("keyword is already used by another bookmark")
+	ErrReservedKeyword  = errors.New("keyword is reserved")
+)
+
 type Repository interface {
 	Add(bookmark Bookmark) error
 	List() ([]Bookmark, error)
+	Update(bookmark Bookmark) error
+	// UpdateAll replaces the given bookmarks at once.
+	UpdateAll(bookmarks []Bookmark) error
 	Delete(id BookmarkID) error
 }
+
+// BulkAdder is a Repository that can also store many bookmarks at once, as
+// imports do.
+type BulkAdder interface {
+	Repository
+	AddAll(bookmarks []Bookmark) error
+}
@@ -0,0 +1,46 @@
+package bookmark
+
+import (
+	"slices"
+	"strings"
+)
+
+// DefaultSchemes are the URL schemes accepted without any configuration.
+var DefaultSchemes = []string{"http", "https", "ftp", "ssh", "file", "mailto", "man"}
+
+// networkSchemes always address a remote host, so their URLs must have one.
+var networkSchemes = map[string]struct{}{
+	"http":  {},
+	"https": {},
+	"ftp":   {},
+	"ssh":   {},
+}
+
+// SchemeAllowlist holds the URL schemes that new bookmarks may use. It is
+// checked when URLs are entered, not when stored bookmarks are read, so that
+// removing a scheme from the config does not make existing data unreadable.
+type SchemeAllowlist struct {
+	allowed map[string]struct{}
+}
+
+// NewSchemeAllowlist allows DefaultSchemes and extra.
+func NewSchemeAllowlist(extra ...string) SchemeAllowlist {
+	allowed := make(map[string]struct{}, len(DefaultSchemes)+len(extra))
+	for _, name := range append(slices.Clone(DefaultSchemes), extra...) {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			allowed[name] = struct{}{}
+		}
+	}
+	return SchemeAllowlist{allowed: allowed}
+}
+
+// Allows reports whether scheme is in the allowlist.
+func (a SchemeAllowlist) Allows(scheme string) bool {
+	_, ok := a.allowed[strings.ToLower(scheme)]
+	return ok
+}
+
+func isNetworkScheme(scheme string) bool {
+	_, ok := networkSchemes[scheme]
+	return ok
+}
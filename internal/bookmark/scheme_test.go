@@ -0,0 +1,92 @@
+package bookmark_test
+
+import (
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"pgregory.net/rapid"
+)
+
+func TestNewBookmarkURL_NonNetworkSchemesSucceed(t *testing.T) {
+	for _, rawURL := range []string{
+		"file:///home/me/notes.pdf",
+		"mailto:team@example.com",
+		"man:ls(1)",
+		"ssh://deploy@bastion.example.com:2222",
+	} {
+		bookmarkURL, err := bookmark.NewBookmarkURL(rawURL)
+		if err != nil {
+			t.Errorf("URL %q should succeed, got error: %v", rawURL, err)
+			continue
+		}
+		if bookmarkURL.Value() != rawURL {
+			t.Errorf("URL value should be preserved: expected %q, got %q", rawURL, bookmarkURL.Value())
+		}
+	}
+}
+
+func TestNewBookmarkURL_EmptyTargetFails(t *testing.T) {
+	for _, rawURL := range []string{"mailto:", "file://", "ssh://", "http:example.com"} {
+		if _, err := bookmark.NewBookmarkURL(rawURL); err == nil {
+			t.Errorf("URL %q should fail", rawURL)
+		}
+	}
+}
+
+func TestSchemeAllowlist_UnknownSchemesAreNotAllowed(t *testing.T) {
+	allowlist := bookmark.NewSchemeAllowlist()
+
+	rapid.Check(t, func(t *rapid.T) {
+		scheme := rapid.StringMatching(`[a-z][a-z0-9+.-]{1,10}`).Filter(func(s string) bool {
+			for _, known := range bookmark.DefaultSchemes {
+				if s == known {
+					return false
+				}
+			}
+			return true
+		}).Draw(t, "scheme")
+
+		if allowlist.Allows(scheme) {
+			t.Fatalf("unknown scheme %q should not be allowed", scheme)
+		}
+	})
+}
+
+func TestNewSchemeAllowlist(t *testing.T) {
+	allowlist := bookmark.NewSchemeAllowlist(" Obsidian ")
+
+	for _, scheme := range append([]string{"obsidian", "OBSIDIAN"}, bookmark.DefaultSchemes...) {
+		if !allowlist.Allows(scheme) {
+			t.Errorf("scheme %q should be allowed", scheme)
+		}
+	}
+	if bookmark.NewSchemeAllowlist().Allows("obsidian") {
+		t.Error("scheme obsidian should only be allowed when it is listed")
+	}
+}
+
+func TestNewBookmarkURL_DoesNotCheckTheAllowlist(t *testing.T) {
+	rawURL := "obsidian://open?vault=work&file=todo"
+	if _, err := bookmark.NewBookmarkURL(rawURL); err != nil {
+		t.Errorf("URL %q should succeed, got error: %v", rawURL, err)
+	}
+}
+
+func TestBookmarkURL_Scheme(t *testing.T) {
+	tests := map[string]string{
+		"https://example.com":       "https",
+		"HTTP://example.com":        "http",
+		"file:///home/me/notes.pdf": "file",
+		"mailto:team@example.com":   "mailto",
+	}
+
+	for rawURL, expected := range tests {
+		u, err := bookmark.NewBookmarkURL(rawURL)
+		if err != nil {
+			t.Fatalf("setup failed for %q: %v", rawURL, err)
+		}
+		if got := u.Scheme(); got != expected {
+			t.Errorf("Scheme() of %q: expected %q, got %q", rawURL, expected, got)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package bookmark
+
+import (
+	"cmp"
+	"slices"
+)
+
+// TagCount is a tag with the number of bookmarks that have it.
+type TagCount struct {
+	Tag   BookmarkTag
+	Count int
+}
+
+// CountTags returns every tag used by bookmarks, most used first and then in
+// alphabetical order.
+func CountTags(bookmarks []Bookmark) []TagCount {
+	counts := make(map[BookmarkTag]int)
+	for _, bm := range bookmarks {
+		for _, tag := range bm.Tags {
+			counts[tag]++
+		}
+	}
+
+	result := make([]TagCount, 0, len(counts))
+	for tag, count := range counts {
+		result = append(result, TagCount{Tag: tag, Count: count})
+	}
+	slices.SortFunc(result, func(a, b TagCount) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Tag.Value(), b.Tag.Value())
+	})
+	return result
+}
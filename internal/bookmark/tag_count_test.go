@@ -0,0 +1,39 @@
+package bookmark_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+)
+
+func TestCountTags(t *testing.T) {
+	var bookmarks []bookmark.Bookmark
+	for i, names := range [][]string{{"go", "cli"}, {"go", "web"}, {"rust", "cli"}, {"go"}} {
+		url, _ := bookmark.NewBookmarkURL(fmt.Sprintf("https://example.com/%d", i))
+		title, _ := bookmark.NewBookmarkTitle("Example")
+		var tags []bookmark.BookmarkTag
+		for _, name := range names {
+			tag, _ := bookmark.NewBookmarkTag(name)
+			tags = append(tags, tag)
+		}
+		bookmarks = append(bookmarks, bookmark.CreateBookmark(url, title, bookmark.NewBookmarkDescription(""), tags))
+	}
+
+	counts := bookmark.CountTags(bookmarks)
+
+	var got []string
+	for _, c := range counts {
+		got = append(got, fmt.Sprintf("%s:%d", c.Tag.Value(), c.Count))
+	}
+	expected := "[go:3 cli:2 rust:1 web:1]"
+	if fmt.Sprint(got) != expected {
+		t.Errorf("expected %s, got %v", expected, got)
+	}
+}
+
+func TestCountTags_NoBookmarks(t *testing.T) {
+	if counts := bookmark.CountTags(nil); len(counts) != 0 {
+		t.Errorf("expected no tags, got %v", counts)
+	}
+}
@@ -0,0 +1,205 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/adrg/xdg"
+)
+
+var ErrUnknownProfile = errors.New("unknown profile")
+
+type Config struct {
+	// Variables are available to ${NAME} references in bookmark URLs.
+	Variables map[string]string `json:"variables,omitempty"`
+	// Profile is the profile used when none is given on the command line.
+	Profile string `json:"profile,omitempty"`
+	// Profiles are named sets of variables that take precedence over Variables.
+	Profiles map[string]map[string]string `json:"profiles,omitempty"`
+	// Env names the environment variables that ${NAME} references may read.
+	// Other environment variables are never expanded into URLs.
+	Env []string `json:"env,omitempty"`
+	// Schemes are URL schemes accepted in addition to the built-in ones.
+	Schemes []string `json:"schemes,omitempty"`
+	// Openers map URL schemes to command templates used to open them.
+	Openers map[string]string `json:"openers,omitempty"`
+	// Browsers choose the command for URLs whose scheme has no opener. The
+	// first matching rule wins.
+	Browsers []BrowserRule `json:"browsers,omitempty"`
+	// TagRules suggest tags for new bookmarks by URL.
+	TagRules []TagRule `json:"tag_rules,omitempty"`
+	// Open controls how several bookmarks are opened at once.
+	Open OpenConfig `json:"open,omitzero"`
+	// History controls the history of opened bookmarks.
+	History HistoryConfig `json:"history,omitzero"`
+	// Fetch controls how page metadata is fetched when adding bookmarks.
+	Fetch FetchConfig `json:"fetch,omitzero"`
+	// Check controls how bookmark URLs are probed by bkm check.
+	Check CheckConfig `json:"check,omitzero"`
+	// Archive controls how pages are downloaded by bkm archive.
+	Archive ArchiveConfig `json:"archive,omitzero"`
+	// CleanURLs controls which query parameters are removed from bookmark URLs.
+	CleanURLs CleanURLsConfig `json:"clean_urls,omitzero"`
+	// Secrets controls how URLs with credentials are handled.
+	Secrets SecretsConfig `json:"secrets,omitzero"`
+}
+
+// BrowserRule opens URLs matching all of its non-empty conditions with Command.
+type BrowserRule struct {
+	Domain  string `json:"domain,omitempty"`
+	Tag     string `json:"tag,omitempty"`
+	URL     string `json:"url,omitempty"`
+	Command string `json:"command"`
+}
+
+// TagRule suggests Tags for URLs matching all of its non-empty conditions.
+type TagRule struct {
+	Domain string   `json:"domain,omitempty"`
+	URL    string   `json:"url,omitempty"`
+	Tags   []string `json:"tags"`
+}
+
+type OpenConfig struct {
+	// Delay is the pause between opening two bookmarks.
+	Delay Duration `json:"delay,omitzero"`
+	// Concurrency is the number of bookmarks opened at the same time.
+	Concurrency int `json:"concurrency,omitempty"`
+	// ConfirmThreshold is the number of bookmarks above which opening by tag asks for confirmation.
+	ConfirmThreshold int `json:"confirm_threshold,omitempty"`
+}
+
+type HistoryConfig struct {
+	// Retention is how long opened bookmarks are kept in the history.
+	Retention Duration `json:"retention,omitzero"`
+}
+
+type FetchConfig struct {
+	// Timeout is the time limit for fetching a page.
+	Timeout Duration `json:"timeout,omitzero"`
+	// MaxBytes is the number of bytes of a page that are read.
+	MaxBytes int64 `json:"max_bytes,omitempty"`
+}
+
+type CheckConfig struct {
+	// Concurrency is the number of URLs checked at the same time.
+	Concurrency int `json:"concurrency,omitempty"`
+	// Timeout is the time limit for checking one URL.
+	Timeout Duration `json:"timeout,omitzero"`
+	// HostInterval is the minimum time between two requests to the same host.
+	HostInterval Duration `json:"host_interval,omitzero"`
+	// Slow is the response time above which a link is reported as slow.
+	Slow Duration `json:"slow,omitzero"`
+}
+
+type ArchiveConfig struct {
+	// Timeout is the time limit for downloading the page and each resource.
+	Timeout Duration `json:"timeout,omitzero"`
+	// MaxBytes is the largest page that is archived.
+	MaxBytes int64 `json:"max_bytes,omitempty"`
+	// MaxInlineBytes is the total size of stylesheets and images embedded in a snapshot.
+	MaxInlineBytes int64 `json:"max_inline_bytes,omitempty"`
+}
+
+type CleanURLsConfig struct {
+	// NoBuiltinRules drops the built-in list of tracking parameters.
+	NoBuiltinRules bool `json:"no_builtin_rules,omitempty"`
+	// Rules remove or keep parameters in addition to the built-in rules.
+	Rules []CleanRule `json:"rules,omitempty"`
+}
+
+// CleanRule removes the parameters matching Remove from URLs on hosts matching
+// Domain, except those matching Keep. All of them are globs.
+type CleanRule struct {
+	Domain string   `json:"domain,omitempty"`
+	Remove []string `json:"remove,omitempty"`
+	Keep   []string `json:"keep,omitempty"`
+}
+
+type SecretsConfig struct {
+	// Policy is what happens to new bookmarks with secrets in the URL: "refuse", "redact" or "warn".
+	Policy string `json:"policy,omitempty"`
+	// Params are globs of query parameter names treated as secrets in addition to the built-in ones.
+	Params []string `json:"params,omitempty"`
+}
+
+// Duration is a time.Duration written as a string such as "250ms" or "1s".
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string: %w", err)
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("invalid duration: %w", err)
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+func DefaultPath() string {
+	return filepath.Join(xdg.ConfigHome, "bkm", "config.json")
+}
+
+func LoadDefault() (Config, error) {
+	return Load(DefaultPath())
+}
+
+// Load reads the config file at filePath. A missing file yields an empty config.
+func Load(filePath string) (Config, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Config{}, nil
+		}
+		return Config{}, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// VariableLookup resolves URL variables from the given profile, falling back
+// to the configured profile, then the global variables and finally the
+// environment variables listed in Env.
+func (c Config) VariableLookup(profile string) (func(name string) (string, bool), error) {
+	if profile == "" {
+		profile = c.Profile
+	}
+
+	var profileVars map[string]string
+	if profile != "" {
+		vars, ok := c.Profiles[profile]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownProfile, profile)
+		}
+		profileVars = vars
+	}
+
+	return func(name string) (string, bool) {
+		if value, ok := profileVars[name]; ok {
+			return value, true
+		}
+		if value, ok := c.Variables[name]; ok {
+			return value, true
+		}
+		if slices.Contains(c.Env, name) {
+			return os.LookupEnv(name)
+		}
+		return "", false
+	}, nil
+}
@@ -0,0 +1,305 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/bkm/internal/config"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	filePath := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(filePath, []byte(content), 0o600); err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+	return filePath
+}
+
+func TestLoad_MissingFileYieldsEmptyConfig(t *testing.T) {
+	cfg, err := config.Load(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("Load should succeed: %v", err)
+	}
+
+	if len(cfg.Variables) != 0 || len(cfg.Profiles) != 0 || cfg.Profile != "" {
+		t.Errorf("expected empty config, got %+v", cfg)
+	}
+}
+
+func TestLoad_InvalidJSONFails(t *testing.T) {
+	filePath := writeConfig(t, "{")
+
+	if _, err := config.Load(filePath); err == nil {
+		t.Fatal("Load should fail for invalid JSON")
+	}
+}
+
+func TestLoad_ParsesVariablesAndProfiles(t *testing.T) {
+	filePath := writeConfig(t, `{
+  "variables": {"API_PORT": "8080"},
+  "profile": "dev",
+  "profiles": {"dev": {"ENV": "dev"}, "prod": {"ENV": "prod"}},
+  "env": ["ORG"]
+}`)
+
+	cfg, err := config.Load(filePath)
+	if err != nil {
+		t.Fatalf("Load should succeed: %v", err)
+	}
+
+	if cfg.Variables["API_PORT"] != "8080" {
+		t.Errorf("expected API_PORT=8080, got %q", cfg.Variables["API_PORT"])
+	}
+	if cfg.Profile != "dev" {
+		t.Errorf("expected default profile dev, got %q", cfg.Profile)
+	}
+	if cfg.Profiles["prod"]["ENV"] != "prod" {
+		t.Errorf("expected prod ENV=prod, got %q", cfg.Profiles["prod"]["ENV"])
+	}
+	if len(cfg.Env) != 1 || cfg.Env[0] != "ORG" {
+		t.Errorf("expected env [ORG], got %v", cfg.Env)
+	}
+}
+
+func TestConfig_VariableLookupPrecedence(t *testing.T) {
+	t.Setenv("BKM_TEST_ENV", "from-env")
+	t.Setenv("BKM_TEST_SHARED", "from-env")
+
+	cfg := config.Config{
+		Variables: map[string]string{
+			"BKM_TEST_SHARED": "from-variables",
+			"BKM_TEST_GLOBAL": "from-variables",
+		},
+		Env:     []string{"BKM_TEST_ENV", "BKM_TEST_SHARED"},
+		Profile: "dev",
+		Profiles: map[string]map[string]string{
+			"dev":  {"BKM_TEST_GLOBAL": "from-dev"},
+			"prod": {"BKM_TEST_GLOBAL": "from-prod"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		profile  string
+		variable string
+		expected string
+	}{
+		{"profile overrides variables", "prod", "BKM_TEST_GLOBAL", "from-prod"},
+		{"configured profile is the default", "", "BKM_TEST_GLOBAL", "from-dev"},
+		{"variables override environment", "", "BKM_TEST_SHARED", "from-variables"},
+		{"environment is the fallback", "", "BKM_TEST_ENV", "from-env"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookup, err := cfg.VariableLookup(tt.profile)
+			if err != nil {
+				t.Fatalf("VariableLookup should succeed: %v", err)
+			}
+
+			value, ok := lookup(tt.variable)
+			if !ok {
+				t.Fatalf("variable %s should be defined", tt.variable)
+			}
+			if value != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, value)
+			}
+		})
+	}
+}
+
+func TestConfig_VariableLookupUndefined(t *testing.T) {
+	lookup, err := config.Config{}.VariableLookup("")
+	if err != nil {
+		t.Fatalf("VariableLookup should succeed: %v", err)
+	}
+
+	if _, ok := lookup("BKM_TEST_SURELY_UNDEFINED"); ok {
+		t.Error("undefined variable should not be found")
+	}
+}
+
+func TestConfig_VariableLookupIgnoresUnlistedEnvironment(t *testing.T) {
+	t.Setenv("BKM_TEST_LISTED", "listed")
+	t.Setenv("BKM_TEST_SECRET", "secret")
+
+	lookup, err := config.Config{Env: []string{"BKM_TEST_LISTED"}}.VariableLookup("")
+	if err != nil {
+		t.Fatalf("VariableLookup should succeed: %v", err)
+	}
+
+	if value, ok := lookup("BKM_TEST_LISTED"); !ok || value != "listed" {
+		t.Errorf("expected listed environment variable, got %q, %v", value, ok)
+	}
+	if value, ok := lookup("BKM_TEST_SECRET"); ok {
+		t.Errorf("unlisted environment variable should not be expanded, got %q", value)
+	}
+}
+
+func TestConfig_VariableLookupUnknownProfileFails(t *testing.T) {
+	_, err := config.Config{}.VariableLookup("staging")
+	if !errors.Is(err, config.ErrUnknownProfile) {
+		t.Fatalf("expected ErrUnknownProfile, got %v", err)
+	}
+}
+
+func TestLoad_ParsesSchemesAndOpeners(t *testing.T) {
+	filePath := writeConfig(t, `{
+  "schemes": ["obsidian"],
+  "openers": {"ssh": "ssh {host}"}
+}`)
+
+	cfg, err := config.Load(filePath)
+	if err != nil {
+		t.Fatalf("Load should succeed: %v", err)
+	}
+
+	if len(cfg.Schemes) != 1 || cfg.Schemes[0] != "obsidian" {
+		t.Errorf("expected schemes [obsidian], got %v", cfg.Schemes)
+	}
+	if cfg.Openers["ssh"] != "ssh {host}" {
+		t.Errorf("expected ssh opener, got %q", cfg.Openers["ssh"])
+	}
+}
+
+func TestLoad_ParsesOpenSettings(t *testing.T) {
+	filePath := writeConfig(t, `{
+  "open": {"delay": "250ms", "concurrency": 3, "confirm_threshold": 5}
+}`)
+
+	cfg, err := config.Load(filePath)
+	if err != nil {
+		t.Fatalf("Load should succeed: %v", err)
+	}
+
+	if time.Duration(cfg.Open.Delay) != 250*time.Millisecond {
+		t.Errorf("expected delay 250ms, got %v", time.Duration(cfg.Open.Delay))
+	}
+	if cfg.Open.Concurrency != 3 || cfg.Open.ConfirmThreshold != 5 {
+		t.Errorf("unexpected open settings: %+v", cfg.Open)
+	}
+}
+
+func TestLoad_InvalidDurationFails(t *testing.T) {
+	filePath := writeConfig(t, `{"open": {"delay": "soon"}}`)
+
+	if _, err := config.Load(filePath); err == nil {
+		t.Fatal("Load should fail for an invalid duration")
+	}
+}
+
+func TestLoad_ParsesHistoryRetention(t *testing.T) {
+	filePath := writeConfig(t, `{"history": {"retention": "720h"}}`)
+
+	cfg, err := config.Load(filePath)
+	if err != nil {
+		t.Fatalf("Load should succeed: %v", err)
+	}
+
+	if time.Duration(cfg.History.Retention) != 30*24*time.Hour {
+		t.Errorf("expected retention 720h, got %v", time.Duration(cfg.History.Retention))
+	}
+}
+
+func TestLoad_ParsesFetchSettings(t *testing.T) {
+	filePath := writeConfig(t, `{"fetch": {"timeout": "2s", "max_bytes": 65536}}`)
+
+	cfg, err := config.Load(filePath)
+	if err != nil {
+		t.Fatalf("Load should succeed: %v", err)
+	}
+
+	if time.Duration(cfg.Fetch.Timeout) != 2*time.Second || cfg.Fetch.MaxBytes != 65536 {
+		t.Errorf("unexpected fetch settings: %+v", cfg.Fetch)
+	}
+}
+
+func TestLoad_ParsesCheckSettings(t *testing.T) {
+	filePath := writeConfig(t, `{"check": {"concurrency": 4, "timeout": "3s", "host_interval": "1s", "slow": "2s"}}`)
+
+	cfg, err := config.Load(filePath)
+	if err != nil {
+		t.Fatalf("Load should succeed: %v", err)
+	}
+
+	expected := config.CheckConfig{
+		Concurrency:  4,
+		Timeout:      config.Duration(3 * time.Second),
+		HostInterval: config.Duration(time.Second),
+		Slow:         config.Duration(2 * time.Second),
+	}
+	if cfg.Check != expected {
+		t.Errorf("expected %+v, got %+v", expected, cfg.Check)
+	}
+}
+
+func TestLoad_ParsesArchiveSettings(t *testing.T) {
+	filePath := writeConfig(t, `{"archive": {"timeout": "1m", "max_bytes": 1048576, "max_inline_bytes": 2097152}}`)
+
+	cfg, err := config.Load(filePath)
+	if err != nil {
+		t.Fatalf("Load should succeed: %v", err)
+	}
+
+	expected := config.ArchiveConfig{
+		Timeout:        config.Duration(time.Minute),
+		MaxBytes:       1 << 20,
+		MaxInlineBytes: 2 << 20,
+	}
+	if cfg.Archive != expected {
+		t.Errorf("expected %+v, got %+v", expected, cfg.Archive)
+	}
+}
+
+func TestLoad_ParsesTagRules(t *testing.T) {
+	filePath := writeConfig(t, `{"tag_rules": [{"domain": "*.go.dev", "tags": ["go", "docs"]}, {"url": "/issues/", "tags": ["issue"]}]}`)
+
+	cfg, err := config.Load(filePath)
+	if err != nil {
+		t.Fatalf("Load should succeed: %v", err)
+	}
+
+	if len(cfg.TagRules) != 2 {
+		t.Fatalf("expected 2 tag rules, got %d", len(cfg.TagRules))
+	}
+	if cfg.TagRules[0].Domain != "*.go.dev" || len(cfg.TagRules[0].Tags) != 2 || cfg.TagRules[1].URL != "/issues/" {
+		t.Errorf("unexpected tag rules: %+v", cfg.TagRules)
+	}
+}
+
+func TestLoad_ParsesCleanURLsSettings(t *testing.T) {
+	filePath := writeConfig(t, `{"clean_urls": {"no_builtin_rules": true, "rules": [{"domain": "*.example.com", "remove": ["ref"], "keep": ["utm_source"]}]}}`)
+
+	cfg, err := config.Load(filePath)
+	if err != nil {
+		t.Fatalf("Load should succeed: %v", err)
+	}
+
+	if !cfg.CleanURLs.NoBuiltinRules {
+		t.Error("expected built-in rules to be disabled")
+	}
+	if len(cfg.CleanURLs.Rules) != 1 {
+		t.Fatalf("expected 1 rule, got %d", len(cfg.CleanURLs.Rules))
+	}
+	rule := cfg.CleanURLs.Rules[0]
+	if rule.Domain != "*.example.com" || len(rule.Remove) != 1 || len(rule.Keep) != 1 {
+		t.Errorf("unexpected rule: %+v", rule)
+	}
+}
+
+func TestLoad_ParsesSecretsSettings(t *testing.T) {
+	filePath := writeConfig(t, `{"secrets": {"policy": "redact", "params": ["session_*"]}}`)
+
+	cfg, err := config.Load(filePath)
+	if err != nil {
+		t.Fatalf("Load should succeed: %v", err)
+	}
+
+	if cfg.Secrets.Policy != "redact" || len(cfg.Secrets.Params) != 1 || cfg.Secrets.Params[0] != "session_*" {
+		t.Errorf("unexpected secrets settings: %+v", cfg.Secrets)
+	}
+}
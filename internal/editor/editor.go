@@ -0,0 +1,5 @@
+package editor
+
+type Editor interface {
+	Edit(content string) (string, error)
+}
@@ -0,0 +1,76 @@
+package editor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// ExternalEditor edits text in the user's editor, taken from $VISUAL or
+// $EDITOR and falling back to a platform default.
+type ExternalEditor struct{}
+
+var _ Editor = (*ExternalEditor)(nil)
+
+func NewExternalEditor() *ExternalEditor {
+	return &ExternalEditor{}
+}
+
+func (e *ExternalEditor) Edit(content string) (_ string, retErr error) {
+	command := editorCommand()
+	if len(command) == 0 {
+		return "", errors.New("no editor configured: set $VISUAL or $EDITOR")
+	}
+
+	file, err := os.CreateTemp("", "bkm-*.md")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	path := file.Name()
+	defer func() {
+		if rmErr := os.Remove(path); rmErr != nil && retErr == nil {
+			retErr = fmt.Errorf("failed to remove temporary file: %w", rmErr)
+		}
+	}()
+
+	if _, err := file.WriteString(content); err != nil {
+		return "", errors.Join(fmt.Errorf("failed to write temporary file: %w", err), file.Close())
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("failed to close temporary file: %w", err)
+	}
+
+	args := append(command[1:], path)
+	cmd := exec.Command(command[0], args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("editor exited with error: %w", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read temporary file: %w", err)
+	}
+
+	return string(data), nil
+}
+
+func editorCommand() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) > 0 {
+			return fields
+		}
+	}
+
+	switch runtime.GOOS {
+	case "windows":
+		return []string{"notepad"}
+	default:
+		return []string{"vi"}
+	}
+}
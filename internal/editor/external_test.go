@@ -0,0 +1,72 @@
+package editor_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/editor"
+)
+
+func writeFakeEditor(t *testing.T, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake editor script requires a POSIX shell")
+	}
+
+	path := filepath.Join(t.TempDir(), "fake-editor")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0o700); err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+	return path
+}
+
+func TestNewExternalEditor(t *testing.T) {
+	ed := editor.NewExternalEditor()
+	if ed == nil {
+		t.Fatal("ExternalEditor should not be nil")
+	}
+}
+
+func TestExternalEditor_ReturnsEditedContent(t *testing.T) {
+	fake := writeFakeEditor(t, `echo "appended" >> "$1"`)
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", fake)
+
+	edited, err := editor.NewExternalEditor().Edit("original\n")
+	if err != nil {
+		t.Fatalf("Edit should succeed: %v", err)
+	}
+
+	if edited != "original\nappended\n" {
+		t.Errorf("unexpected content: %q", edited)
+	}
+}
+
+func TestExternalEditor_VisualTakesPrecedence(t *testing.T) {
+	visual := writeFakeEditor(t, `echo "visual" > "$1"`)
+	fallback := writeFakeEditor(t, `echo "editor" > "$1"`)
+	t.Setenv("VISUAL", visual)
+	t.Setenv("EDITOR", fallback)
+
+	edited, err := editor.NewExternalEditor().Edit("")
+	if err != nil {
+		t.Fatalf("Edit should succeed: %v", err)
+	}
+
+	if edited != "visual\n" {
+		t.Errorf("expected $VISUAL to be used, got %q", edited)
+	}
+}
+
+func TestExternalEditor_EditorFailureFails(t *testing.T) {
+	fake := writeFakeEditor(t, `exit 1`)
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", fake)
+
+	_, err := editor.NewExternalEditor().Edit("original")
+	if err == nil {
+		t.Fatal("Edit should fail when the editor exits with an error")
+	}
+}
@@ -0,0 +1,143 @@
+// Package exporter writes bookmarks in formats that browsers can import.
+package exporter
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+)
+
+// FolderStrategy decides which folder a bookmark is written to.
+type FolderStrategy string
+
+const (
+	// FolderFirstTag puts each bookmark in the folder of its first tag.
+	FolderFirstTag FolderStrategy = "first-tag"
+	// FolderHierarchical puts each bookmark in the folder of its most nested
+	// tag, so a bookmark tagged "go" and "dev/go" goes to Dev > Go.
+	FolderHierarchical FolderStrategy = "hierarchical"
+	// FolderFlat writes all bookmarks without folders.
+	FolderFlat FolderStrategy = "flat"
+)
+
+func ParseFolderStrategy(s string) (FolderStrategy, error) {
+	switch strategy := FolderStrategy(s); strategy {
+	case FolderFirstTag, FolderHierarchical, FolderFlat:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("folder strategy must be one of first-tag, hierarchical or flat, got %q", s)
+	}
+}
+
+type folder struct {
+	name      string
+	folders   []*folder
+	bookmarks []bookmark.Bookmark
+}
+
+func (f *folder) child(name string) *folder {
+	for _, c := range f.folders {
+		if c.name == name {
+			return c
+		}
+	}
+	c := &folder{name: name}
+	f.folders = append(f.folders, c)
+	return c
+}
+
+// WriteNetscape writes bookmarks in the bookmarks.html format. Bookmarks
+// without tags are written outside any folder. Folders are sorted by name and
+// bookmarks keep their order.
+func WriteNetscape(w io.Writer, bookmarks []bookmark.Bookmark, strategy FolderStrategy) error {
+	root := &folder{}
+	for _, bm := range bookmarks {
+		f := root
+		for _, name := range folderPath(bm, strategy) {
+			f = f.child(name)
+		}
+		f.bookmarks = append(f.bookmarks, bm)
+	}
+
+	var b strings.Builder
+	b.WriteString(`<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<!-- This is an automatically generated file.
+     It will be read and overwritten.
+     DO NOT EDIT! -->
+<META HTTP-EQUIV="Content-Type" CONTENT="text/html; charset=UTF-8">
+<TITLE>Bookmarks</TITLE>
+<H1>Bookmarks</H1>
+`)
+	writeFolder(&b, root, 0)
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write bookmarks: %w", err)
+	}
+	return nil
+}
+
+// folderPath returns the folders of the tag chosen by strategy. Tags are
+// split at slashes into nested folders, since import turns folders back into
+// tags that way: "dev/go" becomes Dev > Go and not a folder named "dev/go".
+func folderPath(bm bookmark.Bookmark, strategy FolderStrategy) []string {
+	if len(bm.Tags) == 0 || strategy == FolderFlat {
+		return nil
+	}
+
+	var path []string
+	for _, tag := range bm.Tags {
+		var names []string
+		for _, name := range strings.Split(tag.Value(), "/") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+		if strategy != FolderHierarchical {
+			return names
+		}
+		if len(names) > len(path) {
+			path = names
+		}
+	}
+	return path
+}
+
+func writeFolder(w *strings.Builder, f *folder, depth int) {
+	indent := strings.Repeat("    ", depth)
+	w.WriteString(indent + "<DL><p>\n")
+
+	slices.SortStableFunc(f.folders, func(a, b *folder) int {
+		return strings.Compare(strings.ToLower(a.name), strings.ToLower(b.name))
+	})
+	for _, c := range f.folders {
+		fmt.Fprintf(w, "%s    <DT><H3>%s</H3>\n", indent, html.EscapeString(c.name))
+		writeFolder(w, c, depth+1)
+	}
+	for _, bm := range f.bookmarks {
+		writeBookmark(w, bm, indent+"    ")
+	}
+
+	w.WriteString(indent + "</DL><p>\n")
+}
+
+func writeBookmark(w *strings.Builder, bm bookmark.Bookmark, indent string) {
+	fmt.Fprintf(w, `%s<DT><A HREF="%s" ADD_DATE="%d" LAST_MODIFIED="%d"`,
+		indent, html.EscapeString(bm.URL.Value()), bm.CreatedAt.Unix(), bm.UpdatedAt.Unix())
+	if len(bm.Tags) > 0 {
+		tags := make([]string, len(bm.Tags))
+		for i, tag := range bm.Tags {
+			tags[i] = tag.Value()
+		}
+		fmt.Fprintf(w, ` TAGS="%s"`, html.EscapeString(strings.Join(tags, ",")))
+	}
+	if keyword := bm.Keyword.Value(); keyword != "" {
+		fmt.Fprintf(w, ` SHORTCUTURL="%s"`, html.EscapeString(keyword))
+	}
+	fmt.Fprintf(w, ">%s</A>\n", html.EscapeString(bm.Title.Value()))
+	if desc := bm.Description.Value(); desc != "" {
+		fmt.Fprintf(w, "%s<DD>%s\n", indent, html.EscapeString(desc))
+	}
+}
@@ -0,0 +1,139 @@
+package exporter_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/bookmark/bookmarktest"
+	"github.com/airRnot1106/bkm/internal/exporter"
+	"github.com/airRnot1106/bkm/internal/importer"
+)
+
+func TestWriteNetscape_Attributes(t *testing.T) {
+	bm := bookmarktest.New(t, "https://example.com/?a=1&b=2", "cartoons", "tv")
+	bm.Title, _ = bookmark.NewBookmarkTitle("Tom & Jerry")
+	bm.CreatedAt = time.Unix(1500000000, 0)
+	bm.UpdatedAt = bm.CreatedAt.Add(time.Hour)
+	bm.Description = bookmark.NewBookmarkDescription("<classic>")
+	bm.Keyword, _ = bookmark.NewBookmarkKeyword("tj")
+
+	var b strings.Builder
+	if err := exporter.WriteNetscape(&b, []bookmark.Bookmark{bm}, exporter.FolderFlat); err != nil {
+		t.Fatalf("WriteNetscape should succeed: %v", err)
+	}
+
+	out := b.String()
+	expected := `<DT><A HREF="https://example.com/?a=1&amp;b=2" ADD_DATE="1500000000" LAST_MODIFIED="1500003600" TAGS="cartoons,tv" SHORTCUTURL="tj">Tom &amp; Jerry</A>`
+	if !strings.Contains(out, expected) {
+		t.Errorf("expected %s in\n%s", expected, out)
+	}
+	if !strings.Contains(out, "<DD>&lt;classic&gt;") {
+		t.Errorf("expected an escaped description in\n%s", out)
+	}
+	if strings.Contains(out, "<H3>") {
+		t.Errorf("expected no folders in\n%s", out)
+	}
+}
+
+func TestWriteNetscape_FolderStrategies(t *testing.T) {
+	bookmarks := []bookmark.Bookmark{
+		bookmarktest.New(t, "https://go.dev/", "dev/go", "lang"),
+		bookmarktest.New(t, "https://www.rust-lang.org/", "lang", "dev/rust"),
+		bookmarktest.New(t, "https://example.com/"),
+	}
+
+	tests := []struct {
+		strategy exporter.FolderStrategy
+		expected []string
+	}{
+		{exporter.FolderFirstTag, []string{"dev/go", "lang", ""}},
+		{exporter.FolderHierarchical, []string{"dev/go", "dev/rust", ""}},
+		{exporter.FolderFlat, []string{"", "", ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			var b strings.Builder
+			if err := exporter.WriteNetscape(&b, bookmarks, tt.strategy); err != nil {
+				t.Fatalf("WriteNetscape should succeed: %v", err)
+			}
+
+			// Reading the file back gives the folders as tags again.
+			entries, err := importer.ParseNetscape(strings.NewReader(b.String()))
+			if err != nil {
+				t.Fatalf("ParseNetscape should succeed: %v", err)
+			}
+			folders := make(map[string]string, len(entries))
+			for _, entry := range entries {
+				folders[entry.URL] = entry.FolderTag()
+			}
+			for i, bm := range bookmarks {
+				if got := folders[bm.URL.Value()]; got != tt.expected[i] {
+					t.Errorf("%s: expected folder %q, got %q", bm.URL.Value(), tt.expected[i], got)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteNetscape_HierarchicalNestsFolders(t *testing.T) {
+	bookmarks := []bookmark.Bookmark{
+		bookmarktest.New(t, "https://go.dev/", "dev/go"),
+		bookmarktest.New(t, "https://www.rust-lang.org/", "dev/rust"),
+	}
+
+	var b strings.Builder
+	if err := exporter.WriteNetscape(&b, bookmarks, exporter.FolderHierarchical); err != nil {
+		t.Fatalf("WriteNetscape should succeed: %v", err)
+	}
+
+	if count := strings.Count(b.String(), "<H3>dev</H3>"); count != 1 {
+		t.Errorf("expected one dev folder, got %d in\n%s", count, b.String())
+	}
+}
+
+func TestWriteNetscape_RoundTripsTags(t *testing.T) {
+	bookmarks := []bookmark.Bookmark{
+		bookmarktest.New(t, "https://go.dev/", "dev/go", "lang"),
+		bookmarktest.New(t, "https://www.rust-lang.org/", "lang", "dev/rust"),
+	}
+
+	for _, strategy := range []exporter.FolderStrategy{exporter.FolderFirstTag, exporter.FolderHierarchical, exporter.FolderFlat} {
+		t.Run(string(strategy), func(t *testing.T) {
+			var b strings.Builder
+			if err := exporter.WriteNetscape(&b, bookmarks, strategy); err != nil {
+				t.Fatalf("WriteNetscape should succeed: %v", err)
+			}
+			entries, err := importer.ParseNetscape(strings.NewReader(b.String()))
+			if err != nil {
+				t.Fatalf("ParseNetscape should succeed: %v", err)
+			}
+
+			// Import adds the folder as a tag, which must be one the bookmark
+			// already has.
+			for _, entry := range entries {
+				tags := entry.Tags
+				if folderTag := entry.FolderTag(); folderTag != "" && !slices.Contains(tags, folderTag) {
+					tags = append(tags, folderTag)
+				}
+				for _, bm := range bookmarks {
+					if bm.URL.Value() == entry.URL && len(tags) != len(bm.Tags) {
+						t.Errorf("%s: expected tags %v, got %v", entry.URL, bm.Tags, tags)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestParseFolderStrategy(t *testing.T) {
+	if strategy, err := exporter.ParseFolderStrategy("hierarchical"); err != nil || strategy != exporter.FolderHierarchical {
+		t.Errorf("expected hierarchical, got %q (%v)", strategy, err)
+	}
+	if _, err := exporter.ParseFolderStrategy("by-date"); err == nil {
+		t.Error("expected an error for an unknown strategy")
+	}
+}
@@ -0,0 +1,68 @@
+package fulltext
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// hiddenElements hold content that is not read as part of the page.
+var hiddenElements = map[string]bool{
+	"script":   true,
+	"style":    true,
+	"noscript": true,
+	"template": true,
+	"svg":      true,
+	"iframe":   true,
+}
+
+// blockElements separate words, so their boundaries become spaces in the
+// extracted text.
+var blockElements = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "br": true,
+	"dd": true, "div": true, "dl": true, "dt": true, "figcaption": true,
+	"footer": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true,
+	"h6": true, "header": true, "hr": true, "li": true, "main": true, "nav": true,
+	"ol": true, "p": true, "pre": true, "section": true, "table": true, "td": true,
+	"th": true, "title": true, "tr": true, "ul": true,
+}
+
+// ExtractText returns the readable text of an HTML page, including its title,
+// with whitespace collapsed.
+func ExtractText(src string) string {
+	var b strings.Builder
+	// hidden counts the open hidden elements. svg can nest, the others
+	// cannot but closing them is harmless.
+	hidden := 0
+
+	z := html.NewTokenizer(strings.NewReader(src))
+	for z.Next() != html.ErrorToken {
+		tok := z.Token()
+		switch tok.Type {
+		case html.StartTagToken:
+			if hiddenElements[tok.Data] {
+				hidden++
+			}
+			if blockElements[tok.Data] {
+				b.WriteByte(' ')
+			}
+		case html.EndTagToken:
+			if hiddenElements[tok.Data] && hidden > 0 {
+				hidden--
+			}
+			if blockElements[tok.Data] {
+				b.WriteByte(' ')
+			}
+		case html.SelfClosingTagToken:
+			if blockElements[tok.Data] {
+				b.WriteByte(' ')
+			}
+		case html.TextToken:
+			if hidden == 0 {
+				b.WriteString(tok.Data)
+			}
+		}
+	}
+
+	return strings.Join(strings.Fields(b.String()), " ")
+}
@@ -0,0 +1,20 @@
+package fulltext_test
+
+import (
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/fulltext"
+)
+
+func TestExtractText(t *testing.T) {
+	src := `<!DOCTYPE html><html><head><title>Tuning &amp; Tips</title>` +
+		`<style>body { color: red }</style><script>var hidden = 1;</script></head>` +
+		`<body><h1>Scheduler</h1><p>Low<b>latency</b> matters.</p>` +
+		`<svg><title>icon</title><text>chart</text></svg>` +
+		`<ul><li>one</li><li>two</li></ul><noscript>Enable JS</noscript></body></html>`
+
+	expected := "Tuning & Tips Scheduler Lowlatency matters. one two"
+	if got := fulltext.ExtractText(src); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
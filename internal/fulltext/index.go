@@ -0,0 +1,180 @@
+// Package fulltext indexes the text of bookmarked pages and ranks them for a
+// query with BM25.
+package fulltext
+
+import (
+	"cmp"
+	"errors"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// BM25 parameters, the common defaults.
+const (
+	k1 = 1.2
+	b  = 0.75
+)
+
+var ErrNoMatches = errors.New("no bookmarks match the query")
+
+// Repository persists the index.
+type Repository interface {
+	// Load returns the saved index, or an empty one when none was saved.
+	Load() (*Index, error)
+	Save(index *Index) error
+}
+
+// Document is the indexed text of one bookmark.
+type Document struct {
+	// ID is the ID of the bookmark.
+	ID string
+	// Digest identifies the content the document was built from, so that
+	// unchanged pages are not indexed again.
+	Digest string
+	// Terms counts the occurrences of each term.
+	Terms map[string]int
+}
+
+func NewDocument(id, digest, text string) Document {
+	terms := make(map[string]int)
+	for _, term := range Tokenize(text) {
+		terms[term]++
+	}
+	return Document{ID: id, Digest: digest, Terms: terms}
+}
+
+func (d Document) length() int {
+	n := 0
+	for _, count := range d.Terms {
+		n += count
+	}
+	return n
+}
+
+// Tokenize splits text into lower-cased terms of letters and digits.
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// Hit is a document matching a query. Higher scores rank first.
+type Hit struct {
+	ID    string
+	Score float64
+}
+
+// Index is an inverted index from terms to the documents containing them.
+type Index struct {
+	docs map[string]Document
+	// postings maps each term to the term frequency in every document
+	// containing it.
+	postings    map[string]map[string]int
+	lengths     map[string]int
+	totalLength int
+}
+
+func NewIndex(docs ...Document) *Index {
+	idx := &Index{
+		docs:     make(map[string]Document),
+		postings: make(map[string]map[string]int),
+		lengths:  make(map[string]int),
+	}
+	for _, doc := range docs {
+		idx.Put(doc)
+	}
+	return idx
+}
+
+// Put adds doc, replacing the document with the same ID.
+func (idx *Index) Put(doc Document) {
+	idx.Remove(doc.ID)
+
+	idx.docs[doc.ID] = doc
+	idx.lengths[doc.ID] = doc.length()
+	idx.totalLength += idx.lengths[doc.ID]
+	for term, count := range doc.Terms {
+		if idx.postings[term] == nil {
+			idx.postings[term] = make(map[string]int)
+		}
+		idx.postings[term][doc.ID] = count
+	}
+}
+
+func (idx *Index) Remove(id string) {
+	doc, ok := idx.docs[id]
+	if !ok {
+		return
+	}
+
+	idx.totalLength -= idx.lengths[id]
+	delete(idx.docs, id)
+	delete(idx.lengths, id)
+	for term := range doc.Terms {
+		delete(idx.postings[term], id)
+		if len(idx.postings[term]) == 0 {
+			delete(idx.postings, term)
+		}
+	}
+}
+
+func (idx *Index) Document(id string) (Document, bool) {
+	doc, ok := idx.docs[id]
+	return doc, ok
+}
+
+// Documents returns every document ordered by ID.
+func (idx *Index) Documents() []Document {
+	ids := slices.Sorted(maps.Keys(idx.docs))
+	docs := make([]Document, len(ids))
+	for i, id := range ids {
+		docs[i] = idx.docs[id]
+	}
+	return docs
+}
+
+// Search ranks the documents containing any term of the query with BM25,
+// best match first. Ties are ordered by ID so that results are stable.
+func (idx *Index) Search(query string) []Hit {
+	n := float64(len(idx.docs))
+	if n == 0 {
+		return nil
+	}
+	avgLength := float64(idx.totalLength) / n
+
+	scores := make(map[string]float64)
+	for _, term := range uniqueTerms(query) {
+		postings := idx.postings[term]
+		df := float64(len(postings))
+		if df == 0 {
+			continue
+		}
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		for id, count := range postings {
+			tf := float64(count)
+			length := float64(idx.lengths[id])
+			scores[id] += idf * tf * (k1 + 1) / (tf + k1*(1-b+b*length/avgLength))
+		}
+	}
+
+	hits := make([]Hit, 0, len(scores))
+	for id, score := range scores {
+		hits = append(hits, Hit{ID: id, Score: score})
+	}
+	slices.SortFunc(hits, func(x, y Hit) int {
+		if c := cmp.Compare(y.Score, x.Score); c != 0 {
+			return c
+		}
+		return cmp.Compare(x.ID, y.ID)
+	})
+	return hits
+}
+
+func uniqueTerms(query string) []string {
+	terms := Tokenize(query)
+	slices.Sort(terms)
+	return slices.Compact(terms)
+}
@@ -0,0 +1,113 @@
+package fulltext_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/fulltext"
+	"pgregory.net/rapid"
+)
+
+func hitIDs(hits []fulltext.Hit) []string {
+	ids := make([]string, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.ID
+	}
+	return ids
+}
+
+func TestTokenize(t *testing.T) {
+	got := fulltext.Tokenize("Go's net/http: HTTP/2 server — Überblick")
+	expected := []string{"go", "s", "net", "http", "http", "2", "server", "überblick"}
+	if fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestIndex_SearchRanksByRelevance(t *testing.T) {
+	idx := fulltext.NewIndex(
+		fulltext.NewDocument("garden", "", "Growing tomatoes in a small garden. Tomatoes need sun."),
+		fulltext.NewDocument("recipe", "", "A pasta recipe with fresh tomatoes, garlic and basil."),
+		fulltext.NewDocument("kernel", "", "Tuning the Linux kernel scheduler for low latency."),
+	)
+
+	hits := idx.Search("tomatoes garden")
+	if got := hitIDs(hits); fmt.Sprint(got) != "[garden recipe]" {
+		t.Fatalf("expected [garden recipe], got %v", got)
+	}
+	if hits[0].Score <= hits[1].Score {
+		t.Errorf("expected scores in descending order, got %v", hits)
+	}
+
+	if hits := idx.Search("kubernetes"); len(hits) != 0 {
+		t.Errorf("expected no hits, got %v", hits)
+	}
+}
+
+func TestIndex_RareTermsWeighMore(t *testing.T) {
+	idx := fulltext.NewIndex(
+		fulltext.NewDocument("a", "", "the the the the zebra"),
+		fulltext.NewDocument("b", "", "the the the the the"),
+		fulltext.NewDocument("c", "", "the quick fox"),
+	)
+
+	hits := idx.Search("the zebra")
+	if len(hits) != 3 || hits[0].ID != "a" {
+		t.Errorf("expected the document with the rare term first, got %v", hits)
+	}
+}
+
+func TestIndex_PutReplacesAndRemoveDeletes(t *testing.T) {
+	idx := fulltext.NewIndex(fulltext.NewDocument("a", "v1", "old words"))
+
+	idx.Put(fulltext.NewDocument("a", "v2", "new words"))
+	if hits := idx.Search("old"); len(hits) != 0 {
+		t.Errorf("expected replaced terms to be gone, got %v", hits)
+	}
+	if doc, ok := idx.Document("a"); !ok || doc.Digest != "v2" {
+		t.Errorf("expected the new document, got %+v", doc)
+	}
+
+	idx.Remove("a")
+	if hits := idx.Search("new"); len(hits) != 0 {
+		t.Errorf("expected removed documents not to match, got %v", hits)
+	}
+	if len(idx.Documents()) != 0 {
+		t.Errorf("expected an empty index, got %v", idx.Documents())
+	}
+}
+
+// Building an index in one go or by replacing and removing documents along
+// the way must give the same ranking.
+func TestIndex_IncrementalUpdatesMatchRebuild(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		words := rapid.SliceOfN(rapid.SampledFrom([]string{"go", "rust", "zig", "web", "cli", "db"}), 1, 8)
+		texts := rapid.SliceOfN(rapid.Map(words, joinWords), 1, 6).Draw(t, "texts")
+		removed := rapid.IntRange(0, len(texts)-1).Draw(t, "removed")
+
+		incremental := fulltext.NewIndex()
+		for i, text := range texts {
+			incremental.Put(fulltext.NewDocument(fmt.Sprint(i), "", "stale "+text))
+		}
+		var docs []fulltext.Document
+		for i, text := range texts {
+			doc := fulltext.NewDocument(fmt.Sprint(i), "", text)
+			incremental.Put(doc)
+			if i != removed {
+				docs = append(docs, doc)
+			}
+		}
+		incremental.Remove(fmt.Sprint(removed))
+		rebuilt := fulltext.NewIndex(docs...)
+
+		query := rapid.Map(words, joinWords).Draw(t, "query")
+		if got, expected := incremental.Search(query), rebuilt.Search(query); fmt.Sprint(got) != fmt.Sprint(expected) {
+			t.Fatalf("expected %v, got %v", expected, got)
+		}
+	})
+}
+
+func joinWords(words []string) string {
+	return strings.Join(words, " ")
+}
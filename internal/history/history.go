@@ -0,0 +1,23 @@
+package history
+
+import (
+	"time"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+)
+
+// Entry records that a bookmark was opened. URL is the URL that was actually
+// opened, i.e. the chosen variant with its template filled.
+type Entry struct {
+	BookmarkID bookmark.BookmarkID
+	URL        bookmark.BookmarkURL
+	OpenedAt   time.Time
+}
+
+func NewEntry(bookmarkID bookmark.BookmarkID, url bookmark.BookmarkURL, openedAt time.Time) Entry {
+	return Entry{
+		BookmarkID: bookmarkID,
+		URL:        url,
+		OpenedAt:   openedAt,
+	}
+}
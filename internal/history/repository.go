@@ -0,0 +1,11 @@
+package history
+
+import "time"
+
+type Repository interface {
+	Append(entry Entry) error
+	// List returns the entries in the order they were appended.
+	List() ([]Entry, error)
+	// Prune removes the entries opened before the given time.
+	Prune(before time.Time) error
+}
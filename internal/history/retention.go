@@ -0,0 +1,48 @@
+package history
+
+import (
+	"fmt"
+	"time"
+)
+
+// RetainingRepository removes the entries older than its retention whenever
+// an entry is appended, so that the history does not grow without bound.
+// Entries that expired since the last append are left out of List.
+type RetainingRepository struct {
+	Repository
+	retention time.Duration
+}
+
+// NewRetainingRepository keeps the entries of repo for retention. Zero keeps
+// every entry.
+func NewRetainingRepository(repo Repository, retention time.Duration) *RetainingRepository {
+	return &RetainingRepository{Repository: repo, retention: retention}
+}
+
+func (r *RetainingRepository) Append(entry Entry) error {
+	if err := r.Repository.Append(entry); err != nil {
+		return err
+	}
+	if r.retention <= 0 {
+		return nil
+	}
+	if err := r.Prune(entry.OpenedAt.Add(-r.retention)); err != nil {
+		return fmt.Errorf("failed to prune history: %w", err)
+	}
+	return nil
+}
+
+func (r *RetainingRepository) List() ([]Entry, error) {
+	entries, err := r.Repository.List()
+	if err != nil || r.retention <= 0 {
+		return entries, err
+	}
+	cutoff := time.Now().Add(-r.retention)
+	kept := entries[:0:0]
+	for _, entry := range entries {
+		if !entry.OpenedAt.Before(cutoff) {
+			kept = append(kept, entry)
+		}
+	}
+	return kept, nil
+}
@@ -0,0 +1,116 @@
+package history_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/history"
+)
+
+type memoryHistory struct {
+	entries  []history.Entry
+	pruneErr error
+}
+
+func (m *memoryHistory) Append(entry history.Entry) error {
+	m.entries = append(m.entries, entry)
+	return nil
+}
+
+func (m *memoryHistory) List() ([]history.Entry, error) {
+	return m.entries, nil
+}
+
+func (m *memoryHistory) Prune(before time.Time) error {
+	if m.pruneErr != nil {
+		return m.pruneErr
+	}
+	var kept []history.Entry
+	for _, entry := range m.entries {
+		if !entry.OpenedAt.Before(before) {
+			kept = append(kept, entry)
+		}
+	}
+	m.entries = kept
+	return nil
+}
+
+func newEntry(t *testing.T, openedAt time.Time) history.Entry {
+	t.Helper()
+	url, err := bookmark.NewBookmarkURL("https://example.com")
+	if err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+	return history.NewEntry(bookmark.GenerateBookmarkID(), url, openedAt)
+}
+
+func TestRetainingRepository_AppendPrunesExpiredEntries(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	repo := &memoryHistory{}
+	repo.entries = []history.Entry{newEntry(t, now.Add(-48*time.Hour)), newEntry(t, now.Add(-time.Hour))}
+	hist := history.NewRetainingRepository(repo, 24*time.Hour)
+
+	if err := hist.Append(newEntry(t, now)); err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	entries := repo.entries
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if !entries[0].OpenedAt.Equal(now.Add(-time.Hour)) || !entries[1].OpenedAt.Equal(now) {
+		t.Errorf("expected the expired entry to be removed, got %+v", entries)
+	}
+}
+
+func TestRetainingRepository_ListLeavesOutExpiredEntries(t *testing.T) {
+	now := time.Now()
+	repo := &memoryHistory{pruneErr: errors.New("prune should not be called")}
+	repo.entries = []history.Entry{newEntry(t, now.Add(-48*time.Hour)), newEntry(t, now.Add(-time.Hour))}
+	hist := history.NewRetainingRepository(repo, 24*time.Hour)
+
+	entries, err := hist.List()
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if len(entries) != 1 || !entries[0].OpenedAt.Equal(now.Add(-time.Hour)) {
+		t.Errorf("expected only the recent entry, got %+v", entries)
+	}
+	if len(repo.entries) != 2 {
+		t.Errorf("expected listing not to change the history, got %d entries", len(repo.entries))
+	}
+}
+
+func TestRetainingRepository_ZeroRetentionKeepsEveryEntry(t *testing.T) {
+	now := time.Now()
+	repo := &memoryHistory{pruneErr: errors.New("prune should not be called")}
+	repo.entries = []history.Entry{newEntry(t, now.AddDate(-1, 0, 0))}
+	hist := history.NewRetainingRepository(repo, 0)
+
+	if err := hist.Append(newEntry(t, now)); err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if len(repo.entries) != 2 {
+		t.Errorf("expected 2 entries, got %d", len(repo.entries))
+	}
+	if entries, _ := hist.List(); len(entries) != 2 {
+		t.Errorf("expected every entry to be listed, got %d", len(entries))
+	}
+}
+
+func TestRetainingRepository_PruneErrorIsReported(t *testing.T) {
+	pruneErr := errors.New("disk full")
+	repo := &memoryHistory{pruneErr: pruneErr}
+	hist := history.NewRetainingRepository(repo, time.Hour)
+
+	if err := hist.Append(newEntry(t, time.Now())); !errors.Is(err, pruneErr) {
+		t.Errorf("expected the prune error, got %v", err)
+	}
+	if len(repo.entries) != 1 {
+		t.Errorf("expected the entry to be appended, got %d entries", len(repo.entries))
+	}
+}
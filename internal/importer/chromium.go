@@ -0,0 +1,120 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// webkitEpochOffset is the number of microseconds between 1601-01-01, the
+// epoch of Chromium timestamps, and the Unix epoch.
+const webkitEpochOffset = 11644473600 * 1_000_000
+
+// chromiumBrowserDirs are the directories of Chromium-based browsers below a
+// config directory on Linux.
+var chromiumBrowserDirs = []string{
+	"google-chrome",
+	"google-chrome-beta",
+	"google-chrome-unstable",
+	"chromium",
+	"BraveSoftware/Brave-Browser",
+	"microsoft-edge",
+	"vivaldi",
+}
+
+type chromiumFile struct {
+	Roots map[string]chromiumNode `json:"roots"`
+}
+
+type chromiumNode struct {
+	Type         string         `json:"type"`
+	Name         string         `json:"name"`
+	URL          string         `json:"url"`
+	DateAdded    string         `json:"date_added"`
+	DateModified string         `json:"date_modified"`
+	Children     []chromiumNode `json:"children"`
+}
+
+// chromiumRoots are the root folders in the order browsers show them. They
+// are not part of the folder path.
+var chromiumRoots = []string{"bookmark_bar", "other", "synced"}
+
+// ParseChromium reads the Bookmarks file that Chrome, Chromium, Brave and
+// other Chromium-based browsers keep in the profile directory.
+func ParseChromium(r io.Reader) ([]Entry, error) {
+	var file chromiumFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON: %w", err)
+	}
+	if file.Roots == nil {
+		return nil, fmt.Errorf("no bookmark roots found")
+	}
+
+	names := make([]string, 0, len(file.Roots))
+	for name := range file.Roots {
+		names = append(names, name)
+	}
+	slices.SortFunc(names, func(a, b string) int {
+		return rootOrder(a) - rootOrder(b)
+	})
+
+	var entries []Entry
+	for _, name := range names {
+		entries = appendChromiumEntries(entries, file.Roots[name].Children, nil)
+	}
+	return entries, nil
+}
+
+func rootOrder(name string) int {
+	if i := slices.Index(chromiumRoots, name); i >= 0 {
+		return i
+	}
+	return len(chromiumRoots)
+}
+
+func appendChromiumEntries(entries []Entry, nodes []chromiumNode, folder []string) []Entry {
+	for _, node := range nodes {
+		switch node.Type {
+		case "url":
+			entries = append(entries, Entry{
+				URL:       node.URL,
+				Title:     node.Name,
+				Folder:    folder,
+				CreatedAt: webkitTime(node.DateAdded),
+				UpdatedAt: webkitTime(node.DateModified),
+			})
+		case "folder":
+			entries = appendChromiumEntries(entries, node.Children, append(slices.Clone(folder), node.Name))
+		}
+	}
+	return entries
+}
+
+// webkitTime reads a timestamp in microseconds since 1601-01-01 UTC.
+func webkitTime(raw string) time.Time {
+	n, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || n <= webkitEpochOffset {
+		return time.Time{}
+	}
+	return time.UnixMicro(n - webkitEpochOffset)
+}
+
+// FindChromiumBookmarks returns the Bookmarks files of every profile of the
+// Chromium-based browsers below the given config directories.
+func FindChromiumBookmarks(configDirs ...string) ([]string, error) {
+	var files []string
+	for _, dir := range configDirs {
+		for _, browser := range chromiumBrowserDirs {
+			matches, err := filepath.Glob(filepath.Join(dir, browser, "*", "Bookmarks"))
+			if err != nil {
+				return nil, fmt.Errorf("failed to search for profiles: %w", err)
+			}
+			files = append(files, matches...)
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,141 @@
+package importer_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/bkm/internal/importer"
+)
+
+const chromiumFile = `{
+   "checksum": "0123456789abcdef",
+   "roots": {
+      "bookmark_bar": {
+         "children": [ {
+            "date_added": "13146473600000000",
+            "guid": "00000000-0000-4000-a000-000000000001",
+            "id": "5",
+            "name": "The Go Programming Language",
+            "type": "url",
+            "url": "https://go.dev/"
+         }, {
+            "children": [ {
+               "children": [ {
+                  "date_added": "13146473700000000",
+                  "id": "8",
+                  "name": "Rust",
+                  "type": "url",
+                  "url": "https://www.rust-lang.org/"
+               } ],
+               "date_added": "13146473600000000",
+               "date_modified": "13146473700000000",
+               "id": "7",
+               "name": "Rust",
+               "type": "folder"
+            } ],
+            "id": "6",
+            "name": "Dev",
+            "type": "folder"
+         } ],
+         "id": "1",
+         "name": "Bookmarks bar",
+         "type": "folder"
+      },
+      "other": {
+         "children": [ {
+            "date_added": "0",
+            "id": "9",
+            "name": "Example",
+            "type": "url",
+            "url": "https://example.com/"
+         } ],
+         "id": "2",
+         "name": "Other bookmarks",
+         "type": "folder"
+      },
+      "synced": {
+         "children": [ ],
+         "id": "3",
+         "name": "Mobile bookmarks",
+         "type": "folder"
+      }
+   },
+   "version": 1
+}`
+
+func TestParseChromium(t *testing.T) {
+	entries, err := importer.ParseChromium(strings.NewReader(chromiumFile))
+	if err != nil {
+		t.Fatalf("ParseChromium should succeed: %v", err)
+	}
+
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(entries))
+	}
+
+	tests := []struct {
+		url       string
+		title     string
+		folderTag string
+	}{
+		{"https://go.dev/", "The Go Programming Language", ""},
+		{"https://www.rust-lang.org/", "Rust", "Dev/Rust"},
+		{"https://example.com/", "Example", ""},
+	}
+	for i, tt := range tests {
+		if entries[i].URL != tt.url || entries[i].Title != tt.title || entries[i].FolderTag() != tt.folderTag {
+			t.Errorf("entry %d: expected %s %q in %q, got %s %q in %q",
+				i, tt.url, tt.title, tt.folderTag, entries[i].URL, entries[i].Title, entries[i].FolderTag())
+		}
+	}
+
+	// 13146473600000000 microseconds after 1601-01-01 is 2017-08-06.
+	if expected := time.Unix(1502000000, 0); !entries[0].CreatedAt.Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, entries[0].CreatedAt)
+	}
+	if !entries[2].CreatedAt.IsZero() {
+		t.Errorf("expected no time for 0, got %v", entries[2].CreatedAt)
+	}
+}
+
+func TestParseChromium_InvalidFile(t *testing.T) {
+	if _, err := importer.ParseChromium(strings.NewReader(`<html>`)); err == nil {
+		t.Error("expected an error for a file that is not JSON")
+	}
+	if _, err := importer.ParseChromium(strings.NewReader(`{"version": 1}`)); err == nil {
+		t.Error("expected an error for a file without roots")
+	}
+}
+
+func TestFindChromiumBookmarks(t *testing.T) {
+	configDir := t.TempDir()
+	expected := []string{
+		filepath.Join(configDir, "google-chrome", "Default", "Bookmarks"),
+		filepath.Join(configDir, "google-chrome", "Profile 1", "Bookmarks"),
+		filepath.Join(configDir, "BraveSoftware", "Brave-Browser", "Default", "Bookmarks"),
+	}
+	for _, path := range expected {
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatalf("setup failed: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(chromiumFile), 0o600); err != nil {
+			t.Fatalf("setup failed: %v", err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(configDir, "chromium", "Guest Profile"), 0o750); err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+
+	files, err := importer.FindChromiumBookmarks(configDir, filepath.Join(configDir, "missing"))
+	if err != nil {
+		t.Fatalf("FindChromiumBookmarks should succeed: %v", err)
+	}
+
+	if !slices.Equal(files, expected) {
+		t.Errorf("expected %v, got %v", expected, files)
+	}
+}
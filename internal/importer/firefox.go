@@ -0,0 +1,124 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	firefoxBookmark  = "text/x-moz-place"
+	firefoxFolder    = "text/x-moz-place-container"
+	firefoxTagsRoot  = "tagsFolder"
+	firefoxPlaceNode = "place:"
+)
+
+type firefoxNode struct {
+	Type         string        `json:"type"`
+	Title        string        `json:"title"`
+	URI          string        `json:"uri"`
+	Root         string        `json:"root"`
+	Tags         string        `json:"tags"`
+	DateAdded    int64         `json:"dateAdded"`
+	LastModified int64         `json:"lastModified"`
+	Children     []firefoxNode `json:"children"`
+}
+
+// ParseFirefox reads a Firefox bookmark backup, either compressed (.jsonlz4)
+// or plain (.json). Folders and Firefox tags both become tags; the root
+// folders such as the bookmarks menu and toolbar are left out of the path.
+func ParseFirefox(r io.Reader) ([]Entry, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	if IsMozLz4(data) {
+		if data, err = DecodeMozLz4(data); err != nil {
+			return nil, fmt.Errorf("failed to decompress file: %w", err)
+		}
+	}
+
+	var root firefoxNode
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON: %w", err)
+	}
+	if root.Type != firefoxFolder {
+		return nil, fmt.Errorf("not a Firefox bookmark backup")
+	}
+
+	p := firefoxParser{tags: make(map[string][]string)}
+	p.collectTags(root)
+	p.walk(root, nil)
+	return p.entries, nil
+}
+
+type firefoxParser struct {
+	entries []Entry
+	// tags maps URLs to the tags of the tags folder, which older backups
+	// use instead of the tags attribute.
+	tags map[string][]string
+}
+
+func (p *firefoxParser) collectTags(node firefoxNode) {
+	for _, child := range node.Children {
+		if child.Root != firefoxTagsRoot {
+			p.collectTags(child)
+			continue
+		}
+		for _, tag := range child.Children {
+			for _, place := range tag.Children {
+				if place.URI != "" {
+					p.tags[place.URI] = append(p.tags[place.URI], tag.Title)
+				}
+			}
+		}
+	}
+}
+
+func (p *firefoxParser) walk(node firefoxNode, folder []string) {
+	for _, child := range node.Children {
+		switch child.Type {
+		case firefoxBookmark:
+			if child.URI == "" || strings.HasPrefix(child.URI, firefoxPlaceNode) {
+				continue
+			}
+			p.entries = append(p.entries, Entry{
+				URL:       child.URI,
+				Title:     child.Title,
+				Folder:    folder,
+				Tags:      p.entryTags(child),
+				CreatedAt: prTime(child.DateAdded),
+				UpdatedAt: prTime(child.LastModified),
+			})
+		case firefoxFolder:
+			switch {
+			case child.Root == firefoxTagsRoot:
+			case child.Root != "":
+				p.walk(child, folder)
+			default:
+				p.walk(child, append(slices.Clone(folder), child.Title))
+			}
+		}
+	}
+}
+
+func (p *firefoxParser) entryTags(node firefoxNode) []string {
+	var tags []string
+	for _, tag := range append(strings.Split(node.Tags, ","), p.tags[node.URI]...) {
+		if tag = strings.TrimSpace(tag); tag != "" && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// prTime reads a Firefox timestamp in microseconds since the Unix epoch.
+func prTime(micros int64) time.Time {
+	if micros <= 0 {
+		return time.Time{}
+	}
+	return time.UnixMicro(micros)
+}
@@ -0,0 +1,72 @@
+package importer_test
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/bkm/internal/importer"
+)
+
+const firefoxBackup = `{"guid":"root________","title":"","index":0,"dateAdded":1500000000000000,"lastModified":1500000000000000,"id":1,"typeCode":2,"type":"text/x-moz-place-container","root":"placesRoot","children":[
+  {"guid":"menu________","title":"menu","index":0,"id":2,"typeCode":2,"type":"text/x-moz-place-container","root":"bookmarksMenuFolder","children":[
+    {"guid":"aaaaaaaaaaaa","title":"Dev","index":0,"id":10,"typeCode":2,"type":"text/x-moz-place-container","children":[
+      {"guid":"bbbbbbbbbbbb","title":"The Go Programming Language","index":0,"dateAdded":1500000100000000,"lastModified":1500000200000000,"id":11,"typeCode":1,"tags":"go, lang","type":"text/x-moz-place","uri":"https://go.dev/"},
+      {"guid":"cccccccccccc","title":"","index":1,"id":12,"typeCode":3,"type":"text/x-moz-place-separator"}
+    ]},
+    {"guid":"dddddddddddd","title":"Most Visited","index":1,"id":13,"typeCode":1,"type":"text/x-moz-place","uri":"place:sort=8&maxResults=10"}
+  ]},
+  {"guid":"toolbar_____","title":"toolbar","index":1,"id":3,"typeCode":2,"type":"text/x-moz-place-container","root":"toolbarFolder","children":[
+    {"guid":"eeeeeeeeeeee","title":"Example","index":0,"dateAdded":1500000300000000,"id":14,"typeCode":1,"type":"text/x-moz-place","uri":"https://example.com/"}
+  ]},
+  {"guid":"tags________","title":"tags","index":2,"id":4,"typeCode":2,"type":"text/x-moz-place-container","root":"tagsFolder","children":[
+    {"guid":"ffffffffffff","title":"reference","index":0,"id":15,"typeCode":2,"type":"text/x-moz-place-container","children":[
+      {"guid":"gggggggggggg","title":"","index":0,"id":16,"typeCode":1,"type":"text/x-moz-place","uri":"https://example.com/"}
+    ]}
+  ]},
+  {"guid":"unfiled_____","title":"unfiled","index":3,"id":5,"typeCode":2,"type":"text/x-moz-place-container","root":"unfiledBookmarksFolder"}
+]}`
+
+func TestParseFirefox(t *testing.T) {
+	entries, err := importer.ParseFirefox(strings.NewReader(firefoxBackup))
+	if err != nil {
+		t.Fatalf("ParseFirefox should succeed: %v", err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d: %+v", len(entries), entries)
+	}
+
+	golang := entries[0]
+	if golang.URL != "https://go.dev/" || golang.FolderTag() != "Dev" || !slices.Equal(golang.Tags, []string{"go", "lang"}) {
+		t.Errorf("unexpected entry: %+v", golang)
+	}
+	if !golang.CreatedAt.Equal(time.Unix(1500000100, 0)) || !golang.UpdatedAt.Equal(time.Unix(1500000200, 0)) {
+		t.Errorf("unexpected times: %v, %v", golang.CreatedAt, golang.UpdatedAt)
+	}
+
+	example := entries[1]
+	if example.URL != "https://example.com/" || example.FolderTag() != "" || !slices.Equal(example.Tags, []string{"reference"}) {
+		t.Errorf("expected the tag from the tags folder, got %+v", example)
+	}
+}
+
+func TestParseFirefox_Compressed(t *testing.T) {
+	content := []byte(firefoxBackup)
+
+	entries, err := importer.ParseFirefox(bytes.NewReader(mozLz4(literalBlock(content), len(content))))
+	if err != nil {
+		t.Fatalf("ParseFirefox should succeed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("expected 2 entries, got %d", len(entries))
+	}
+}
+
+func TestParseFirefox_NotABackup(t *testing.T) {
+	if _, err := importer.ParseFirefox(strings.NewReader(`{"roots": {}}`)); err == nil {
+		t.Error("expected an error for a file that is not a Firefox backup")
+	}
+}
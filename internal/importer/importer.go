@@ -0,0 +1,33 @@
+// Package importer reads bookmarks exported by browsers.
+package importer
+
+import (
+	"strings"
+	"time"
+)
+
+// Entry is a bookmark read from a browser file. Folder is the path of folder
+// names below the browser's root folders, outermost first. Zero times mean
+// the file did not record them.
+type Entry struct {
+	URL         string
+	Title       string
+	Description string
+	Folder      []string
+	Tags        []string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// FolderTag returns the folder path as one tag, e.g. "dev/go", or "" for
+// entries outside any folder. Slashes in folder names become dashes so that
+// they do not add levels.
+func (e Entry) FolderTag() string {
+	names := make([]string, 0, len(e.Folder))
+	for _, name := range e.Folder {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, strings.ReplaceAll(name, "/", "-"))
+		}
+	}
+	return strings.Join(names, "/")
+}
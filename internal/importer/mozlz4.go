@@ -0,0 +1,129 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// mozLz4Magic starts the files Firefox compresses with LZ4, such as its
+// bookmark backups (.jsonlz4).
+var mozLz4Magic = []byte("mozLz40\x00")
+
+// maxMozLz4Size guards against headers that claim absurd sizes.
+const maxMozLz4Size = 1 << 30
+
+// maxLZ4Ratio bounds how many bytes one byte of an LZ4 block decodes to: a
+// length byte of 255 adds 255 bytes to a match.
+const maxLZ4Ratio = 255
+
+var errCorruptLZ4 = errors.New("corrupt LZ4 block")
+
+// IsMozLz4 reports whether data starts with the mozLz4 magic number.
+func IsMozLz4(data []byte) bool {
+	return bytes.HasPrefix(data, mozLz4Magic)
+}
+
+// DecodeMozLz4 decompresses a mozLz4 file: the magic number, the size of the
+// decompressed data as a little-endian uint32 and one LZ4 block.
+func DecodeMozLz4(data []byte) ([]byte, error) {
+	if !IsMozLz4(data) {
+		return nil, errors.New("not a mozLz4 file")
+	}
+	header := len(mozLz4Magic) + 4
+	if len(data) < header {
+		return nil, errors.New("truncated mozLz4 header")
+	}
+	size := binary.LittleEndian.Uint32(data[len(mozLz4Magic):header])
+	if size > maxMozLz4Size {
+		return nil, fmt.Errorf("mozLz4 content too large: %d bytes", size)
+	}
+
+	out, err := decodeLZ4Block(data[header:], int(size))
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// decodeLZ4Block decodes an LZ4 block of sequences of literals followed by a
+// match that copies from the output already written. The last sequence has
+// literals only.
+func decodeLZ4Block(src []byte, size int) ([]byte, error) {
+	// The size comes from the header, so check that src can produce it
+	// before allocating.
+	if size > maxLZ4Ratio*len(src) {
+		return nil, fmt.Errorf("%w: %d bytes cannot decode to %d", errCorruptLZ4, len(src), size)
+	}
+	dst := make([]byte, 0, size)
+	for pos := 0; pos < len(src); {
+		token := src[pos]
+		pos++
+
+		literals, next, err := lz4Length(src, pos, int(token>>4))
+		if err != nil {
+			return nil, err
+		}
+		pos = next
+		if literals > len(src)-pos || len(dst)+literals > size {
+			return nil, errCorruptLZ4
+		}
+		dst = append(dst, src[pos:pos+literals]...)
+		pos += literals
+		if pos == len(src) {
+			break
+		}
+
+		if pos+2 > len(src) {
+			return nil, errCorruptLZ4
+		}
+		offset := int(binary.LittleEndian.Uint16(src[pos:]))
+		pos += 2
+		if offset == 0 || offset > len(dst) {
+			return nil, errCorruptLZ4
+		}
+
+		matchLen, next, err := lz4Length(src, pos, int(token&0x0f))
+		if err != nil {
+			return nil, err
+		}
+		pos = next
+		matchLen += 4
+		if len(dst)+matchLen > size {
+			return nil, errCorruptLZ4
+		}
+		// Matches may overlap the bytes they produce, so copy byte by byte.
+		start := len(dst) - offset
+		for i := range matchLen {
+			dst = append(dst, dst[start+i])
+		}
+	}
+
+	if len(dst) != size {
+		return nil, fmt.Errorf("%w: expected %d bytes, got %d", errCorruptLZ4, size, len(dst))
+	}
+	return dst, nil
+}
+
+// lz4Length reads a length that continues in extra bytes when its 4-bit
+// value in the token is 15.
+func lz4Length(src []byte, pos, n int) (int, int, error) {
+	if n != 15 {
+		return n, pos, nil
+	}
+	for {
+		if pos >= len(src) {
+			return 0, 0, errCorruptLZ4
+		}
+		b := src[pos]
+		pos++
+		n += int(b)
+		if n > maxMozLz4Size {
+			return 0, 0, errCorruptLZ4
+		}
+		if b != 255 {
+			return n, pos, nil
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package importer_test
+
+import (
+	"encoding/binary"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/importer"
+)
+
+// mozLz4 wraps an LZ4 block in the mozLz4 container.
+func mozLz4(block []byte, size int) []byte {
+	data := []byte("mozLz40\x00")
+	data = binary.LittleEndian.AppendUint32(data, uint32(size))
+	return append(data, block...)
+}
+
+// literalBlock encodes data as an LZ4 block of a single literal run.
+func literalBlock(data []byte) []byte {
+	n := len(data)
+	if n < 15 {
+		return append([]byte{byte(n << 4)}, data...)
+	}
+	block := []byte{0xf0}
+	for n -= 15; n >= 255; n -= 255 {
+		block = append(block, 255)
+	}
+	block = append(block, byte(n))
+	return append(block, data...)
+}
+
+func TestDecodeMozLz4_Literals(t *testing.T) {
+	content := []byte(strings.Repeat("bookmarks ", 60))
+
+	got, err := importer.DecodeMozLz4(mozLz4(literalBlock(content), len(content)))
+	if err != nil {
+		t.Fatalf("DecodeMozLz4 should succeed: %v", err)
+	}
+	if string(got) != string(content) {
+		t.Errorf("expected %q, got %q", content, got)
+	}
+}
+
+func TestDecodeMozLz4_OverlappingMatch(t *testing.T) {
+	// "abc", then a 9 byte match at offset 3, then the literal "X".
+	block := []byte{0x35, 'a', 'b', 'c', 0x03, 0x00, 0x10, 'X'}
+
+	got, err := importer.DecodeMozLz4(mozLz4(block, 13))
+	if err != nil {
+		t.Fatalf("DecodeMozLz4 should succeed: %v", err)
+	}
+	if expected := "abcabcabcabcX"; string(got) != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestDecodeMozLz4_Corrupt(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"no magic", []byte(`{"type": "text/x-moz-place-container"}`)},
+		{"truncated header", []byte("mozLz40\x00\x01")},
+		{"offset before start", mozLz4([]byte{0x15, 'a', 0x05, 0x00, 0x10, 'X'}, 11)},
+		{"truncated literals", mozLz4([]byte{0x50, 'a', 'b'}, 5)},
+		{"wrong size", mozLz4(literalBlock([]byte("abc")), 4)},
+		{"size beyond what the block can hold", mozLz4(literalBlock([]byte("abc")), 1<<29)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := importer.DecodeMozLz4(tt.data); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestDecodeMozLz4_DoesNotTrustTheHeaderSize(t *testing.T) {
+	data := mozLz4(literalBlock([]byte("abc")), 1<<29)
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	_, err := importer.DecodeMozLz4(data)
+	runtime.ReadMemStats(&after)
+
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 1<<20 {
+		t.Errorf("expected no buffer of the claimed size, %d bytes were allocated", allocated)
+	}
+}
@@ -0,0 +1,153 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+)
+
+// rootFolderAttrs mark the toolbar and "other bookmarks" folders. Browsers
+// create them by themselves, so they are not part of the folder path.
+var rootFolderAttrs = []string{"personal_toolbar_folder", "unfiled_bookmarks_folder"}
+
+// ParseNetscape reads the bookmarks.html format that browsers import and
+// export. Folder names come from <H3> headings, and the TAGS attribute that
+// some browsers write is split into tags.
+func ParseNetscape(r io.Reader) ([]Entry, error) {
+	p := netscapeParser{z: html.NewTokenizer(r)}
+	for p.z.Next() != html.ErrorToken {
+		p.handle(p.z.Token())
+	}
+	if err := p.z.Err(); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return p.entries, nil
+}
+
+type netscapeParser struct {
+	z       *html.Tokenizer
+	entries []Entry
+	// folders holds the folder of each open <DL>. Root folders and the
+	// outermost list are empty.
+	folders []string
+	// heading is the folder named by the last <H3>, opened by the next <DL>.
+	heading string
+}
+
+func (p *netscapeParser) handle(tok html.Token) {
+	switch {
+	case tok.Type == html.StartTagToken && tok.Data == "h3":
+		name := p.readText("h3")
+		if isRootFolder(tok) {
+			name = ""
+		}
+		p.heading = name
+	case tok.Type == html.StartTagToken && tok.Data == "dl":
+		p.folders = append(p.folders, p.heading)
+		p.heading = ""
+	case tok.Type == html.EndTagToken && tok.Data == "dl":
+		if len(p.folders) > 0 {
+			p.folders = p.folders[:len(p.folders)-1]
+		}
+	case tok.Type == html.StartTagToken && tok.Data == "a":
+		p.addEntry(tok)
+	case tok.Type == html.StartTagToken && tok.Data == "dd":
+		if len(p.entries) > 0 {
+			p.entries[len(p.entries)-1].Description = p.readText("")
+		}
+	}
+}
+
+func (p *netscapeParser) addEntry(tok html.Token) {
+	href, _ := attrValue(tok, "href")
+	entry := Entry{
+		URL:       strings.TrimSpace(href),
+		Title:     p.readText("a"),
+		Folder:    p.folderPath(),
+		CreatedAt: attrTime(tok, "add_date"),
+		UpdatedAt: attrTime(tok, "last_modified"),
+	}
+	if tags, ok := attrValue(tok, "tags"); ok {
+		for _, tag := range strings.Split(tags, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				entry.Tags = append(entry.Tags, tag)
+			}
+		}
+	}
+	p.entries = append(p.entries, entry)
+}
+
+// readText collects text up to the end tag of element, or up to the next tag
+// when element is empty. The tag that ends the text is handled as usual.
+func (p *netscapeParser) readText(element string) string {
+	var b strings.Builder
+	for p.z.Next() != html.ErrorToken {
+		tok := p.z.Token()
+		if tok.Type == html.TextToken {
+			b.WriteString(tok.Data)
+			continue
+		}
+		if tok.Type == html.CommentToken {
+			continue
+		}
+		if element == "" || tok.Type != html.EndTagToken || tok.Data != element {
+			p.handle(tok)
+		}
+		break
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+func (p *netscapeParser) folderPath() []string {
+	var path []string
+	for _, name := range p.folders {
+		if name != "" {
+			path = append(path, name)
+		}
+	}
+	return path
+}
+
+func isRootFolder(tok html.Token) bool {
+	for _, key := range rootFolderAttrs {
+		if _, ok := attrValue(tok, key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func attrValue(tok html.Token, key string) (string, bool) {
+	for _, attr := range tok.Attr {
+		if attr.Key == key {
+			return attr.Val, true
+		}
+	}
+	return "", false
+}
+
+// attrTime reads a Unix time in seconds. Some tools write milliseconds or
+// microseconds instead, which are told apart by their size.
+func attrTime(tok html.Token, key string) time.Time {
+	raw, ok := attrValue(tok, key)
+	if !ok {
+		return time.Time{}
+	}
+	n, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+	if err != nil || n <= 0 {
+		return time.Time{}
+	}
+	switch {
+	case n >= 1e15:
+		return time.UnixMicro(n)
+	case n >= 1e12:
+		return time.UnixMilli(n)
+	default:
+		return time.Unix(n, 0)
+	}
+}
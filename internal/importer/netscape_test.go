@@ -0,0 +1,105 @@
+package importer_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/bkm/internal/importer"
+)
+
+const netscapeFile = `<!DOCTYPE NETSCAPE-Bookmark-file-1>
+<!-- This is an automatically generated file.
+     It will be read and overwritten.
+     DO NOT EDIT! -->
+<META HTTP-EQUIV="Content-Type" CONTENT="text/html; charset=UTF-8">
+<TITLE>Bookmarks</TITLE>
+<H1>Bookmarks</H1>
+<DL><p>
+    <DT><H3 ADD_DATE="1500000000" LAST_MODIFIED="1600000000" PERSONAL_TOOLBAR_FOLDER="true">Bookmarks bar</H3>
+    <DL><p>
+        <DT><A HREF="https://go.dev/" ADD_DATE="1500000100" LAST_MODIFIED="1500000200" ICON="data:image/png;base64,AAAA">The Go Programming Language</A>
+        <DT><H3 ADD_DATE="1500000000">Dev</H3>
+        <DL><p>
+            <DT><H3>Rust &amp; C</H3>
+            <DL><p>
+                <DT><A HREF="https://www.rust-lang.org/" ADD_DATE="1500000300" TAGS="rust,lang">Rust</A>
+                <DD>A language empowering everyone
+to build reliable software.
+            </DL><p>
+            <DT><A HREF="https://pkg.go.dev/" ADD_DATE="1500000400">pkg.go.dev</A>
+        </DL><p>
+    </DL><p>
+    <DT><A HREF="https://example.com/">Example</A>
+</DL><p>
+`
+
+func TestParseNetscape(t *testing.T) {
+	entries, err := importer.ParseNetscape(strings.NewReader(netscapeFile))
+	if err != nil {
+		t.Fatalf("ParseNetscape should succeed: %v", err)
+	}
+
+	if len(entries) != 4 {
+		t.Fatalf("expected 4 entries, got %d", len(entries))
+	}
+
+	tests := []struct {
+		url       string
+		title     string
+		folderTag string
+	}{
+		{"https://go.dev/", "The Go Programming Language", ""},
+		{"https://www.rust-lang.org/", "Rust", "Dev/Rust & C"},
+		{"https://pkg.go.dev/", "pkg.go.dev", "Dev"},
+		{"https://example.com/", "Example", ""},
+	}
+	for i, tt := range tests {
+		if entries[i].URL != tt.url || entries[i].Title != tt.title || entries[i].FolderTag() != tt.folderTag {
+			t.Errorf("entry %d: expected %s %q in %q, got %s %q in %q",
+				i, tt.url, tt.title, tt.folderTag, entries[i].URL, entries[i].Title, entries[i].FolderTag())
+		}
+	}
+
+	if !entries[0].CreatedAt.Equal(time.Unix(1500000100, 0)) || !entries[0].UpdatedAt.Equal(time.Unix(1500000200, 0)) {
+		t.Errorf("unexpected times: %v, %v", entries[0].CreatedAt, entries[0].UpdatedAt)
+	}
+	if !entries[3].CreatedAt.IsZero() {
+		t.Errorf("expected no creation time, got %v", entries[3].CreatedAt)
+	}
+	if strings.Join(entries[1].Tags, ",") != "rust,lang" {
+		t.Errorf("expected tags rust,lang, got %v", entries[1].Tags)
+	}
+	if expected := "A language empowering everyone to build reliable software."; entries[1].Description != expected {
+		t.Errorf("expected description %q, got %q", expected, entries[1].Description)
+	}
+}
+
+func TestParseNetscape_TimeUnits(t *testing.T) {
+	src := `<DL><p>
+<DT><A HREF="https://a.example/" ADD_DATE="1500000000000">A</A>
+<DT><A HREF="https://b.example/" ADD_DATE="1500000000000000">B</A>
+<DT><A HREF="https://c.example/" ADD_DATE="not a number">C</A>
+</DL>`
+
+	entries, err := importer.ParseNetscape(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("ParseNetscape should succeed: %v", err)
+	}
+
+	expected := time.Unix(1500000000, 0)
+	if !entries[0].CreatedAt.Equal(expected) || !entries[1].CreatedAt.Equal(expected) {
+		t.Errorf("expected %v from milliseconds and microseconds, got %v and %v", expected, entries[0].CreatedAt, entries[1].CreatedAt)
+	}
+	if !entries[2].CreatedAt.IsZero() {
+		t.Errorf("expected no time for an invalid value, got %v", entries[2].CreatedAt)
+	}
+}
+
+func TestEntry_FolderTag(t *testing.T) {
+	entry := importer.Entry{Folder: []string{"Dev", " CI/CD ", ""}}
+
+	if got := entry.FolderTag(); got != "Dev/CI-CD" {
+		t.Errorf("expected Dev/CI-CD, got %q", got)
+	}
+}
@@ -0,0 +1,248 @@
+package linkcheck
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultConcurrency  = 8
+	DefaultTimeout      = 10 * time.Second
+	DefaultHostInterval = 500 * time.Millisecond
+
+	maxRedirects = 10
+)
+
+// HTTPChecker probes URLs with a HEAD request and falls back to GET when the
+// server rejects HEAD or fails to answer it. Requests to the same host are
+// spaced by the host interval so that no site is flooded.
+type HTTPChecker struct {
+	client       *http.Client
+	concurrency  int
+	hostInterval time.Duration
+}
+
+var _ Checker = (*HTTPChecker)(nil)
+
+type Option func(*HTTPChecker)
+
+func WithConcurrency(concurrency int) Option {
+	return func(c *HTTPChecker) {
+		c.concurrency = concurrency
+	}
+}
+
+// WithTimeout limits each request, including the redirects it follows.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *HTTPChecker) {
+		c.client.Timeout = timeout
+	}
+}
+
+// WithHostInterval sets the minimum time between two requests to the same host.
+func WithHostInterval(interval time.Duration) Option {
+	return func(c *HTTPChecker) {
+		c.hostInterval = interval
+	}
+}
+
+func NewHTTPChecker(opts ...Option) *HTTPChecker {
+	c := &HTTPChecker{
+		client:       &http.Client{Timeout: DefaultTimeout},
+		concurrency:  DefaultConcurrency,
+		hostInterval: DefaultHostInterval,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Check probes the URLs with a fixed number of workers. The workers are
+// handed the next URL whose host is due, so that URLs waiting for a busy host
+// do not hold up URLs of other hosts.
+func (c *HTTPChecker) Check(urls []string) []Result {
+	results := make([]Result, len(urls))
+	if len(urls) == 0 {
+		return results
+	}
+
+	limiter := newHostLimiter(c.hostInterval)
+	workers := min(max(c.concurrency, 1), len(urls))
+	// Workers announce that they are idle on ready before taking a job, so
+	// that a host is only reserved once a request to it can start.
+	ready := make(chan struct{}, workers)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				ready <- struct{}{}
+				i, ok := <-jobs
+				if !ok {
+					return
+				}
+				results[i] = c.check(urls[i], limiter)
+			}
+		}()
+	}
+
+	pending := make([]int, len(urls))
+	for i := range pending {
+		pending[i] = i
+	}
+	for len(pending) > 0 {
+		<-ready
+		var i int
+		i, pending = limiter.take(urls, pending)
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
+
+// check probes the URL. The host of the URL has already been reserved for the
+// first request.
+func (c *HTTPChecker) check(rawURL string, limiter *hostLimiter) Result {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return Result{URL: rawURL, Err: fmt.Errorf("invalid URL: %w", err)}
+	}
+
+	start := time.Now()
+	result := c.probe(http.MethodHead, rawURL)
+	duration := time.Since(start)
+	if result.Err != nil || result.StatusCode >= 400 {
+		// Many servers do not implement HEAD properly, so only trust a GET.
+		limiter.wait(strings.ToLower(parsed.Host))
+		start = time.Now()
+		result = c.probe(http.MethodGet, rawURL)
+		duration += time.Since(start)
+	}
+	result.Duration = duration
+	return result
+}
+
+func (c *HTTPChecker) probe(method, rawURL string) Result {
+	result := Result{URL: rawURL}
+
+	req, err := http.NewRequest(method, rawURL, nil)
+	if err != nil {
+		result.Err = fmt.Errorf("failed to create request: %w", err)
+		return result
+	}
+	req.Header.Set("User-Agent", "bkm")
+
+	client := *c.client
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+		result.Redirects = append(result.Redirects, Redirect{
+			StatusCode: req.Response.StatusCode,
+			URL:        req.URL.String(),
+		})
+		return nil
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		result.Err = unwrapURLError(err)
+		return result
+	}
+	result.StatusCode = resp.StatusCode
+	result.FinalURL = resp.Request.URL.String()
+	// The body is never read; closing it only releases the connection.
+	if err := resp.Body.Close(); err != nil {
+		result.Err = fmt.Errorf("failed to close response body: %w", err)
+	} else if resp.StatusCode >= 400 {
+		result.Err = errors.New(resp.Status)
+	}
+	return result
+}
+
+// unwrapURLError drops the "Head \"https://...\":" prefix of client errors
+// because results are always reported next to their URL.
+func unwrapURLError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
+
+// hostOf returns the host requests to rawURL are spaced by. Invalid URLs
+// fail before any request is made and have no host.
+func hostOf(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(parsed.Host)
+}
+
+// hostLimiter spaces requests to the same host by interval.
+type hostLimiter struct {
+	interval time.Duration
+	mu       sync.Mutex
+	next     map[string]time.Time
+}
+
+func newHostLimiter(interval time.Duration) *hostLimiter {
+	return &hostLimiter{
+		interval: interval,
+		next:     make(map[string]time.Time),
+	}
+}
+
+// reserve lets a request to host start now if the host is due and otherwise
+// returns how long until it is.
+func (l *hostLimiter) reserve(host string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	if wait := l.next[host].Sub(now); wait > 0 {
+		return wait
+	}
+	l.next[host] = now.Add(l.interval)
+	return 0
+}
+
+// wait blocks until a request to host may start.
+func (l *hostLimiter) wait(host string) {
+	for {
+		wait := l.reserve(host)
+		if wait == 0 {
+			return
+		}
+		time.Sleep(wait)
+	}
+}
+
+// take waits until the host of one of the pending URLs is due, and returns
+// that URL and the URLs that are still pending. URLs are taken in order when
+// their hosts are due.
+func (l *hostLimiter) take(urls []string, pending []int) (int, []int) {
+	for {
+		wait := time.Duration(-1)
+		for j, i := range pending {
+			w := l.reserve(hostOf(urls[i]))
+			if w == 0 {
+				return i, slices.Delete(pending, j, j+1)
+			}
+			if wait < 0 || w < wait {
+				wait = w
+			}
+		}
+		time.Sleep(wait)
+	}
+}
@@ -0,0 +1,194 @@
+package linkcheck_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/bkm/internal/linkcheck"
+)
+
+func TestHTTPChecker_Check(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("/gone", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	})
+	mux.HandleFunc("/forbidden", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ok", http.StatusMovedPermanently)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	checker := linkcheck.NewHTTPChecker(linkcheck.WithHostInterval(0))
+	results := checker.Check([]string{server.URL + "/ok", server.URL + "/gone", server.URL + "/forbidden", server.URL + "/old"})
+
+	if len(results) != 4 {
+		t.Fatalf("expected 4 results, got %d", len(results))
+	}
+
+	ok := results[0]
+	if ok.StatusCode != http.StatusOK || ok.Err != nil || ok.IsDead() || ok.IsFailing() || ok.IsRedirected() {
+		t.Errorf("expected a healthy result, got %+v", ok)
+	}
+
+	gone := results[1]
+	if gone.StatusCode != http.StatusGone || !gone.IsDead() || gone.Err == nil {
+		t.Errorf("expected a dead result, got %+v", gone)
+	}
+
+	forbidden := results[2]
+	if forbidden.IsDead() || !forbidden.IsFailing() {
+		t.Errorf("expected a failing but not dead result, got %+v", forbidden)
+	}
+
+	old := results[3]
+	if old.StatusCode != http.StatusOK || old.FinalURL != server.URL+"/ok" {
+		t.Errorf("expected the redirect to be followed, got %+v", old)
+	}
+	expected := linkcheck.Redirect{StatusCode: http.StatusMovedPermanently, URL: server.URL + "/ok"}
+	if len(old.Redirects) != 1 || old.Redirects[0] != expected {
+		t.Errorf("expected redirects [%+v], got %+v", expected, old.Redirects)
+	}
+}
+
+func TestHTTPChecker_FallsBackToGet(t *testing.T) {
+	var methods []string
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		methods = append(methods, r.Method)
+		mu.Unlock()
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	defer server.Close()
+
+	results := linkcheck.NewHTTPChecker(linkcheck.WithHostInterval(0)).Check([]string{server.URL})
+
+	if results[0].StatusCode != http.StatusOK || results[0].Err != nil {
+		t.Errorf("expected the GET result, got %+v", results[0])
+	}
+	if len(methods) != 2 || methods[0] != http.MethodHead || methods[1] != http.MethodGet {
+		t.Errorf("expected HEAD then GET, got %v", methods)
+	}
+}
+
+func TestHTTPChecker_UnreachableIsNotDead(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	checker := linkcheck.NewHTTPChecker(linkcheck.WithTimeout(50*time.Millisecond), linkcheck.WithHostInterval(0))
+	result := checker.Check([]string{server.URL})[0]
+
+	if result.StatusCode != 0 || result.Err == nil || !result.IsUnreachable() || result.IsDead() || result.IsFailing() {
+		t.Errorf("expected an unreachable result, got %+v", result)
+	}
+}
+
+func TestHTTPChecker_LimitsConcurrency(t *testing.T) {
+	var running, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	urls := make([]string, 8)
+	for i := range urls {
+		urls[i] = server.URL
+	}
+	linkcheck.NewHTTPChecker(linkcheck.WithConcurrency(2), linkcheck.WithHostInterval(0)).Check(urls)
+
+	if got := peak.Load(); got > 2 {
+		t.Errorf("expected at most 2 requests at a time, got %d", got)
+	}
+}
+
+func TestHTTPChecker_DoesNotStartAGoroutinePerURL(t *testing.T) {
+	var peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int32(runtime.NumGoroutine())
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+	}))
+	defer server.Close()
+
+	urls := make([]string, 200)
+	for i := range urls {
+		urls[i] = server.URL
+	}
+	before := runtime.NumGoroutine()
+	linkcheck.NewHTTPChecker(linkcheck.WithConcurrency(2), linkcheck.WithHostInterval(0)).Check(urls)
+
+	if got := int(peak.Load()) - before; got > 20 {
+		t.Errorf("expected a bounded number of goroutines, got %d more", got)
+	}
+}
+
+func TestHTTPChecker_SpacesRequestsToTheSameHost(t *testing.T) {
+	var times []time.Time
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		times = append(times, time.Now())
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	interval := 50 * time.Millisecond
+	checker := linkcheck.NewHTTPChecker(linkcheck.WithConcurrency(3), linkcheck.WithHostInterval(interval))
+	checker.Check([]string{server.URL, server.URL, server.URL})
+
+	if len(times) != 3 {
+		t.Fatalf("expected 3 requests, got %d", len(times))
+	}
+	if elapsed := times[2].Sub(times[0]); elapsed < 2*interval-10*time.Millisecond {
+		t.Errorf("expected requests to be spaced by %v, all three took %v", interval, elapsed)
+	}
+}
+
+func TestHTTPChecker_WaitingForAHostDoesNotBlockOtherHosts(t *testing.T) {
+	var mu sync.Mutex
+	var otherAt time.Time
+	busy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer busy.Close()
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		otherAt = time.Now()
+		mu.Unlock()
+	}))
+	defer other.Close()
+
+	interval := 300 * time.Millisecond
+	checker := linkcheck.NewHTTPChecker(linkcheck.WithConcurrency(1), linkcheck.WithHostInterval(interval))
+	start := time.Now()
+	checker.Check([]string{busy.URL, busy.URL, busy.URL, other.URL})
+
+	if elapsed := otherAt.Sub(start); elapsed >= interval {
+		t.Errorf("expected the other host to be checked while the busy one waits, it took %v", elapsed)
+	}
+}
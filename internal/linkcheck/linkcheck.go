@@ -0,0 +1,105 @@
+package linkcheck
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Checker probes URLs and reports whether they are still reachable.
+type Checker interface {
+	// Check probes every URL and returns one result per URL in the same order.
+	Check(urls []string) []Result
+}
+
+// Redirect is one hop of a redirect chain. URL is the location the server
+// redirected to with StatusCode.
+type Redirect struct {
+	StatusCode int
+	URL        string
+}
+
+type Result struct {
+	URL string
+	// StatusCode is the status of the final response, or 0 when the server
+	// could not be reached.
+	StatusCode int
+	// FinalURL is the URL of the final response after following redirects.
+	FinalURL  string
+	Redirects []Redirect
+	Duration  time.Duration
+	Err       error
+}
+
+// IsDead reports whether the server answered that the page does not exist.
+func (r Result) IsDead() bool {
+	return r.StatusCode == http.StatusNotFound || r.StatusCode == http.StatusGone
+}
+
+// IsUnreachable reports whether no response was received, because of a DNS
+// failure, a timeout or being offline. This says nothing about the page, so
+// such links are not dead.
+func (r Result) IsUnreachable() bool {
+	return r.StatusCode == 0
+}
+
+// IsFailing reports whether the server answered with an error that does not
+// necessarily mean the page is gone, such as 403 or 503.
+func (r Result) IsFailing() bool {
+	return !r.IsDead() && r.StatusCode >= 400
+}
+
+func (r Result) IsRedirected() bool {
+	return len(r.Redirects) > 0
+}
+
+// MovedURL returns the URL the checked URL has permanently moved to. Redirects
+// are followed while they are permanent (301 or 308) or only upgrade http to
+// https, and only when the final response succeeded.
+func (r Result) MovedURL() (string, bool) {
+	if r.Err != nil || r.StatusCode == 0 || r.StatusCode >= 400 {
+		return "", false
+	}
+
+	current := r.URL
+	for _, hop := range r.Redirects {
+		if !isPermanentRedirect(hop.StatusCode) && !isHTTPSUpgrade(current, hop.URL) {
+			break
+		}
+		current = hop.URL
+	}
+	if current == r.URL {
+		return "", false
+	}
+	return current, true
+}
+
+func isPermanentRedirect(statusCode int) bool {
+	return statusCode == http.StatusMovedPermanently || statusCode == http.StatusPermanentRedirect
+}
+
+// isHTTPSUpgrade reports whether to is from with the scheme changed from http
+// to https.
+func isHTTPSUpgrade(from, to string) bool {
+	fromURL, err := url.Parse(from)
+	if err != nil {
+		return false
+	}
+	toURL, err := url.Parse(to)
+	if err != nil {
+		return false
+	}
+	return fromURL.Scheme == "http" && toURL.Scheme == "https" &&
+		strings.EqualFold(fromURL.Hostname(), toURL.Hostname()) &&
+		portOrDefault(fromURL, "80") == "80" && portOrDefault(toURL, "443") == "443" &&
+		fromURL.EscapedPath() == toURL.EscapedPath() &&
+		fromURL.RawQuery == toURL.RawQuery
+}
+
+func portOrDefault(u *url.URL, defaultPort string) string {
+	if port := u.Port(); port != "" {
+		return port
+	}
+	return defaultPort
+}
@@ -0,0 +1,99 @@
+package linkcheck_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/linkcheck"
+)
+
+func TestResult_MovedURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   linkcheck.Result
+		expected string
+	}{
+		{
+			name:     "no redirect",
+			result:   linkcheck.Result{URL: "https://example.com/", StatusCode: 200},
+			expected: "",
+		},
+		{
+			name: "permanent redirect",
+			result: linkcheck.Result{URL: "https://example.com/old", StatusCode: 200, Redirects: []linkcheck.Redirect{
+				{StatusCode: 301, URL: "https://example.com/new"},
+			}},
+			expected: "https://example.com/new",
+		},
+		{
+			name: "chain of permanent redirects",
+			result: linkcheck.Result{URL: "http://example.com/old", StatusCode: 200, Redirects: []linkcheck.Redirect{
+				{StatusCode: 308, URL: "https://example.com/old"},
+				{StatusCode: 301, URL: "https://example.com/new"},
+			}},
+			expected: "https://example.com/new",
+		},
+		{
+			name: "temporary https upgrade",
+			result: linkcheck.Result{URL: "http://example.com/docs?v=1", StatusCode: 200, Redirects: []linkcheck.Redirect{
+				{StatusCode: 302, URL: "https://example.com/docs?v=1"},
+			}},
+			expected: "https://example.com/docs?v=1",
+		},
+		{
+			name: "temporary redirect to another path",
+			result: linkcheck.Result{URL: "http://example.com/docs", StatusCode: 200, Redirects: []linkcheck.Redirect{
+				{StatusCode: 302, URL: "https://example.com/login"},
+			}},
+			expected: "",
+		},
+		{
+			name: "stops at the first temporary redirect",
+			result: linkcheck.Result{URL: "https://example.com/old", StatusCode: 200, Redirects: []linkcheck.Redirect{
+				{StatusCode: 301, URL: "https://example.com/new"},
+				{StatusCode: 307, URL: "https://example.com/login"},
+			}},
+			expected: "https://example.com/new",
+		},
+		{
+			name: "redirect to a dead page",
+			result: linkcheck.Result{URL: "https://example.com/old", StatusCode: 404, Err: errors.New("404 Not Found"), Redirects: []linkcheck.Redirect{
+				{StatusCode: 301, URL: "https://example.com/new"},
+			}},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.result.MovedURL()
+			if got != tt.expected || ok != (tt.expected != "") {
+				t.Errorf("expected %q, got %q (ok=%v)", tt.expected, got, ok)
+			}
+		})
+	}
+}
+
+func TestResult_Classification(t *testing.T) {
+	tests := []struct {
+		statusCode  int
+		dead        bool
+		unreachable bool
+		failing     bool
+	}{
+		{0, false, true, false},
+		{200, false, false, false},
+		{403, false, false, true},
+		{404, true, false, false},
+		{410, true, false, false},
+		{503, false, false, true},
+	}
+
+	for _, tt := range tests {
+		result := linkcheck.Result{StatusCode: tt.statusCode}
+		if result.IsDead() != tt.dead || result.IsUnreachable() != tt.unreachable || result.IsFailing() != tt.failing {
+			t.Errorf("status %d: expected dead=%v unreachable=%v failing=%v, got %v %v %v",
+				tt.statusCode, tt.dead, tt.unreachable, tt.failing, result.IsDead(), result.IsUnreachable(), result.IsFailing())
+		}
+	}
+}
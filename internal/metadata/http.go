@@ -0,0 +1,88 @@
+package metadata
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	DefaultTimeout  = 5 * time.Second
+	DefaultMaxBytes = 1 << 20
+)
+
+// HTTPFetcher fetches pages with a GET request. Only the first MaxBytes of a
+// page are read, which is enough for the <head> of virtually every page.
+type HTTPFetcher struct {
+	client   *http.Client
+	maxBytes int64
+}
+
+var _ Fetcher = (*HTTPFetcher)(nil)
+
+type Option func(*HTTPFetcher)
+
+func WithTimeout(timeout time.Duration) Option {
+	return func(f *HTTPFetcher) {
+		f.client.Timeout = timeout
+	}
+}
+
+func WithMaxBytes(maxBytes int64) Option {
+	return func(f *HTTPFetcher) {
+		f.maxBytes = maxBytes
+	}
+}
+
+func NewHTTPFetcher(opts ...Option) *HTTPFetcher {
+	f := &HTTPFetcher{
+		client:   &http.Client{Timeout: DefaultTimeout},
+		maxBytes: DefaultMaxBytes,
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+func (f *HTTPFetcher) Fetch(rawURL string) (meta Metadata, retErr error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return Metadata{}, ErrUnsupportedURL
+	}
+
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	req.Header.Set("User-Agent", "bkm")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("failed to fetch page: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil && retErr == nil {
+			retErr = fmt.Errorf("failed to close response body: %w", err)
+		}
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return Metadata{}, fmt.Errorf("failed to fetch page: %s", resp.Status)
+	}
+	if mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type")); err == nil &&
+		mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return Metadata{}, fmt.Errorf("%w: %s", ErrNotHTML, mediaType)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, f.maxBytes))
+	if err != nil {
+		return Metadata{}, fmt.Errorf("failed to read page: %w", err)
+	}
+
+	return Parse(string(body)), nil
+}
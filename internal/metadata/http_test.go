@@ -0,0 +1,91 @@
+package metadata_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/bkm/internal/metadata"
+)
+
+func TestHTTPFetcher_Fetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, `<title>Example</title><meta name="description" content="An example">`)
+	}))
+	defer server.Close()
+
+	meta, err := metadata.NewHTTPFetcher().Fetch(server.URL)
+	if err != nil {
+		t.Fatalf("Fetch should succeed: %v", err)
+	}
+
+	expected := metadata.Metadata{Title: "Example", Description: "An example"}
+	if meta != expected {
+		t.Errorf("expected %+v, got %+v", expected, meta)
+	}
+}
+
+func TestHTTPFetcher_ReadsAtMostMaxBytes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, `<title>Early</title>`+strings.Repeat(" ", 1024)+`<meta name="description" content="Late">`)
+	}))
+	defer server.Close()
+
+	meta, err := metadata.NewHTTPFetcher(metadata.WithMaxBytes(512)).Fetch(server.URL)
+	if err != nil {
+		t.Fatalf("Fetch should succeed: %v", err)
+	}
+
+	if meta.Title != "Early" || meta.Description != "" {
+		t.Errorf("expected only the title within the limit, got %+v", meta)
+	}
+}
+
+func TestHTTPFetcher_TimesOut(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	_, err := metadata.NewHTTPFetcher(metadata.WithTimeout(50 * time.Millisecond)).Fetch(server.URL)
+	if err == nil {
+		t.Fatal("Fetch should fail after the timeout")
+	}
+}
+
+func TestHTTPFetcher_ErrorStatusFails(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	if _, err := metadata.NewHTTPFetcher().Fetch(server.URL); err == nil {
+		t.Fatal("Fetch should fail for 404")
+	}
+}
+
+func TestHTTPFetcher_NonHTMLFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		fmt.Fprint(w, "%PDF-1.7")
+	}))
+	defer server.Close()
+
+	_, err := metadata.NewHTTPFetcher().Fetch(server.URL)
+	if !errors.Is(err, metadata.ErrNotHTML) {
+		t.Fatalf("expected ErrNotHTML, got %v", err)
+	}
+}
+
+func TestHTTPFetcher_UnsupportedSchemeFails(t *testing.T) {
+	_, err := metadata.NewHTTPFetcher().Fetch("ssh://example.com")
+	if !errors.Is(err, metadata.ErrUnsupportedURL) {
+		t.Fatalf("expected ErrUnsupportedURL, got %v", err)
+	}
+}
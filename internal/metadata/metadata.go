@@ -0,0 +1,96 @@
+package metadata
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+var (
+	ErrUnsupportedURL = errors.New("metadata can only be fetched for http and https URLs")
+	ErrNotHTML        = errors.New("response is not an HTML page")
+)
+
+// Metadata describes a page. Empty fields were not found.
+type Metadata struct {
+	Title       string
+	Description string
+}
+
+type Fetcher interface {
+	Fetch(url string) (Metadata, error)
+}
+
+// Parse reads the title and description of an HTML page. OpenGraph tags take
+// precedence over <title> and <meta name="description"> because they usually
+// lack the site name suffix.
+func Parse(src string) Metadata {
+	var (
+		title, ogTitle, description, ogDescription string
+		inTitle, inSVG                             bool
+	)
+
+	z := html.NewTokenizer(strings.NewReader(src))
+	for z.Next() != html.ErrorToken {
+		tok := z.Token()
+		switch tok.Type {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			switch tok.Data {
+			case "title":
+				inTitle = tok.Type == html.StartTagToken && !inSVG && title == ""
+			case "svg":
+				inSVG = tok.Type == html.StartTagToken
+			case "meta":
+				content, name, property := attr(tok, "content"), attr(tok, "name"), attr(tok, "property")
+				switch {
+				case strings.EqualFold(property, "og:title") && ogTitle == "":
+					ogTitle = clean(content)
+				case strings.EqualFold(property, "og:description") && ogDescription == "":
+					ogDescription = clean(content)
+				case strings.EqualFold(name, "description") && description == "":
+					description = clean(content)
+				}
+			}
+		case html.EndTagToken:
+			switch tok.Data {
+			case "title":
+				inTitle = false
+			case "svg":
+				inSVG = false
+			}
+		case html.TextToken:
+			if inTitle {
+				title = clean(tok.Data)
+			}
+		}
+	}
+
+	return Metadata{
+		Title:       firstNonEmpty(ogTitle, title),
+		Description: firstNonEmpty(ogDescription, description),
+	}
+}
+
+func attr(tok html.Token, key string) string {
+	for _, a := range tok.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// clean collapses runs of white space, which are common in page titles.
+func clean(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,54 @@
+package metadata_test
+
+import (
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/metadata"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		expected metadata.Metadata
+	}{
+		{
+			name: "title and meta description",
+			src: `<html><head><title>
+				Example   Domain
+			</title><meta name="Description" content="An example &amp; nothing more"></head></html>`,
+			expected: metadata.Metadata{Title: "Example Domain", Description: "An example & nothing more"},
+		},
+		{
+			name: "OpenGraph takes precedence",
+			src: `<head><title>Go 1.25 Release Notes - The Go Programming Language</title>
+				<meta name="description" content="Plain description">
+				<meta property="og:title" content="Go 1.25 Release Notes">
+				<meta property="og:description" content="What is new in Go 1.25"></head>`,
+			expected: metadata.Metadata{Title: "Go 1.25 Release Notes", Description: "What is new in Go 1.25"},
+		},
+		{
+			name:     "SVG titles are ignored",
+			src:      `<body><svg><title>icon</title></svg><title>Page</title></body>`,
+			expected: metadata.Metadata{Title: "Page"},
+		},
+		{
+			name:     "first title wins",
+			src:      `<title>First</title><title>Second</title>`,
+			expected: metadata.Metadata{Title: "First"},
+		},
+		{
+			name:     "no metadata",
+			src:      `<p>Hello</p>`,
+			expected: metadata.Metadata{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := metadata.Parse(tt.src); got != tt.expected {
+				t.Errorf("expected %+v, got %+v", tt.expected, got)
+			}
+		})
+	}
+}
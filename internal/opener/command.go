@@ -0,0 +1,166 @@
+package opener
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+)
+
+// CommandOpener runs a command built from a template such as
+// "firefox -P work {url}" or "$EDITOR {path}". The template is split into
+// arguments like a shell would, so quotes and backslashes keep spaces in an
+// argument, and environment variables outside single quotes are expanded.
+// An expanded value is never split, even when it has spaces. Then each
+// argument has its placeholders replaced with parts of the bookmark URL:
+//
+//	{url}    the whole URL
+//	{scheme} the scheme
+//	{user}   the user name
+//	{host}   the host name without port
+//	{port}   the port
+//	{path}   the unescaped path
+//	{opaque} the opaque part, e.g. "ls(1)" in "man:ls(1)"
+//
+// When the template has no placeholder, the URL is appended as the last argument.
+type CommandOpener struct {
+	args []string
+}
+
+var _ Opener = (*CommandOpener)(nil)
+
+func NewCommandOpener(template string) (*CommandOpener, error) {
+	args, err := splitCommand(template)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 0 {
+		return nil, errors.New("command template cannot be empty")
+	}
+	if !strings.Contains(strings.Join(args, " "), "{") {
+		args = append(args, "{url}")
+	}
+	return &CommandOpener{args: args}, nil
+}
+
+func (o *CommandOpener) Open(bm bookmark.Bookmark) error {
+	parsed, err := url.Parse(bm.URL.Value())
+	if err != nil {
+		return fmt.Errorf("failed to parse URL: %w", err)
+	}
+
+	replacer := strings.NewReplacer(
+		"{url}", bm.URL.Value(),
+		"{scheme}", parsed.Scheme,
+		"{user}", parsed.User.Username(),
+		"{host}", parsed.Hostname(),
+		"{port}", parsed.Port(),
+		"{path}", parsed.Path,
+		"{opaque}", parsed.Opaque,
+	)
+
+	args := make([]string, len(o.args))
+	for i, arg := range o.args {
+		args[i] = replacer.Replace(arg)
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// splitCommand splits template into arguments at unquoted spaces.
+func splitCommand(template string) ([]string, error) {
+	var (
+		args   []string
+		word   strings.Builder
+		inWord bool
+	)
+	runes := []rune(template)
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; {
+		case unicode.IsSpace(r):
+			if inWord {
+				args = append(args, word.String())
+				word.Reset()
+				inWord = false
+			}
+			continue
+		case r == '\'':
+			end := slices.Index(runes[i+1:], '\'')
+			if end < 0 {
+				return nil, errors.New("unterminated single quote in command template")
+			}
+			word.WriteString(string(runes[i+1 : i+1+end]))
+			i += end + 1
+		case r == '"':
+			n, err := splitDoubleQuoted(runes[i+1:], &word)
+			if err != nil {
+				return nil, err
+			}
+			i += n
+		case r == '\\' && i+1 < len(runes):
+			i++
+			word.WriteRune(runes[i])
+		case r == '$':
+			i += expandVariable(runes[i+1:], &word)
+		default:
+			word.WriteRune(r)
+		}
+		inWord = true
+	}
+	if inWord {
+		args = append(args, word.String())
+	}
+	return args, nil
+}
+
+// splitDoubleQuoted writes the text up to the closing double quote to word,
+// expanding environment variables, and returns the number of runes read.
+func splitDoubleQuoted(runes []rune, word *strings.Builder) (int, error) {
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; {
+		case r == '"':
+			return i + 1, nil
+		case r == '\\' && i+1 < len(runes) && strings.ContainsRune(`"\$`, runes[i+1]):
+			i++
+			word.WriteRune(runes[i])
+		case r == '$':
+			i += expandVariable(runes[i+1:], word)
+		default:
+			word.WriteRune(r)
+		}
+	}
+	return 0, errors.New("unterminated double quote in command template")
+}
+
+// expandVariable writes the value of the variable named at the start of
+// runes, as in $NAME or ${NAME}, to word and returns the number of runes read.
+// Without a name, the dollar sign is kept.
+func expandVariable(runes []rune, word *strings.Builder) int {
+	if len(runes) > 0 && runes[0] == '{' {
+		if end := slices.Index(runes, '}'); end > 1 {
+			word.WriteString(os.Getenv(string(runes[1:end])))
+			return end + 1
+		}
+	}
+
+	n := 0
+	for n < len(runes) && (runes[n] == '_' || unicode.IsLetter(runes[n]) || unicode.IsDigit(runes[n])) {
+		n++
+	}
+	if n == 0 {
+		word.WriteRune('$')
+		return 0
+	}
+	word.WriteString(os.Getenv(string(runes[:n])))
+	return n
+}
@@ -0,0 +1,123 @@
+package opener_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/bookmark/bookmarktest"
+	"github.com/airRnot1106/bkm/internal/opener"
+)
+
+// writeRecorder creates a command that writes its arguments, one per line, to a file.
+func writeRecorder(t *testing.T) (command string, output string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("recorder script requires a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	output = filepath.Join(dir, "args")
+	command = filepath.Join(dir, "recorder")
+	script := "#!/bin/sh\nfor arg in \"$@\"; do echo \"$arg\" >> " + output + "; done\n"
+	if err := os.WriteFile(command, []byte(script), 0o700); err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+	return command, output
+}
+
+func readArgs(t *testing.T, output string) []string {
+	t.Helper()
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("failed to read recorded args: %v", err)
+	}
+	return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+}
+
+func TestNewCommandOpener_EmptyTemplateFails(t *testing.T) {
+	if _, err := opener.NewCommandOpener("   "); err == nil {
+		t.Fatal("empty template should fail")
+	}
+}
+
+func TestCommandOpener_ReplacesPlaceholders(t *testing.T) {
+	command, output := writeRecorder(t)
+
+	op, err := opener.NewCommandOpener(command + " -l {user} -p {port} {host}")
+	if err != nil {
+		t.Fatalf("NewCommandOpener should succeed: %v", err)
+	}
+
+	if err := op.Open(bookmarktest.New(t, "ssh://deploy@bastion.example.com:2222")); err != nil {
+		t.Fatalf("Open should succeed: %v", err)
+	}
+
+	expected := []string{"-l", "deploy", "-p", "2222", "bastion.example.com"}
+	if got := readArgs(t, output); strings.Join(got, " ") != strings.Join(expected, " ") {
+		t.Errorf("expected args %v, got %v", expected, got)
+	}
+}
+
+func TestCommandOpener_AppendsURLWithoutPlaceholder(t *testing.T) {
+	command, output := writeRecorder(t)
+
+	op, err := opener.NewCommandOpener(command + " -P work")
+	if err != nil {
+		t.Fatalf("NewCommandOpener should succeed: %v", err)
+	}
+
+	if err := op.Open(bookmarktest.New(t, "https://example.com/a b")); err != nil {
+		t.Fatalf("Open should succeed: %v", err)
+	}
+
+	expected := []string{"-P", "work", "https://example.com/a b"}
+	if got := readArgs(t, output); strings.Join(got, "|") != strings.Join(expected, "|") {
+		t.Errorf("expected args %v, got %v", expected, got)
+	}
+}
+
+func TestCommandOpener_ExpandsEnvironment(t *testing.T) {
+	command, output := writeRecorder(t)
+	t.Setenv("BKM_TEST_VIEWER", command)
+
+	op, err := opener.NewCommandOpener("$BKM_TEST_VIEWER {path}")
+	if err != nil {
+		t.Fatalf("NewCommandOpener should succeed: %v", err)
+	}
+
+	if err := op.Open(bookmarktest.New(t, "file:///home/me/notes.pdf")); err != nil {
+		t.Fatalf("Open should succeed: %v", err)
+	}
+
+	if got := readArgs(t, output); len(got) != 1 || got[0] != "/home/me/notes.pdf" {
+		t.Errorf("expected path argument, got %v", got)
+	}
+}
+
+func TestCommandOpener_KeepsQuotedArguments(t *testing.T) {
+	command, output := writeRecorder(t)
+	t.Setenv("BKM_TEST_PROFILE", "My Profile")
+
+	op, err := opener.NewCommandOpener(command + ` --profile "My Profile" -P 'Work Profile' $BKM_TEST_PROFILE a\ b {url}`)
+	if err != nil {
+		t.Fatalf("NewCommandOpener should succeed: %v", err)
+	}
+
+	if err := op.Open(bookmarktest.New(t, "https://example.com")); err != nil {
+		t.Fatalf("Open should succeed: %v", err)
+	}
+
+	expected := []string{"--profile", "My Profile", "-P", "Work Profile", "My Profile", "a b", "https://example.com"}
+	if got := readArgs(t, output); strings.Join(got, "|") != strings.Join(expected, "|") {
+		t.Errorf("expected args %v, got %v", expected, got)
+	}
+}
+
+func TestNewCommandOpener_UnterminatedQuoteFails(t *testing.T) {
+	if _, err := opener.NewCommandOpener(`firefox --profile "My Profile`); err == nil {
+		t.Fatal("unterminated quote should fail")
+	}
+}
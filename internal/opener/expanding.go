@@ -0,0 +1,34 @@
+package opener
+
+import (
+	"fmt"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+)
+
+// ExpandingOpener expands ${NAME} references in the bookmark URL before
+// handing it to the next opener.
+type ExpandingOpener struct {
+	next   Opener
+	lookup func(name string) (string, bool)
+}
+
+var _ Opener = (*ExpandingOpener)(nil)
+
+func NewExpandingOpener(next Opener, lookup func(name string) (string, bool)) *ExpandingOpener {
+	return &ExpandingOpener{next: next, lookup: lookup}
+}
+
+func (o *ExpandingOpener) Open(bm bookmark.Bookmark) error {
+	if len(bm.URL.Variables()) == 0 {
+		return o.next.Open(bm)
+	}
+
+	expanded, err := bm.URL.Expand(o.lookup)
+	if err != nil {
+		return fmt.Errorf("failed to expand URL: %w", err)
+	}
+	bm.URL = expanded
+
+	return o.next.Open(bm)
+}
@@ -0,0 +1,68 @@
+package opener_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/bookmark/bookmarktest"
+	"github.com/airRnot1106/bkm/internal/opener"
+)
+
+type recordingOpener struct {
+	opened []bookmark.Bookmark
+}
+
+func (o *recordingOpener) Open(bm bookmark.Bookmark) error {
+	o.opened = append(o.opened, bm)
+	return nil
+}
+
+func lookupFrom(vars map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := vars[name]
+		return value, ok
+	}
+}
+
+func TestExpandingOpener_ExpandsVariables(t *testing.T) {
+	next := &recordingOpener{}
+	op := opener.NewExpandingOpener(next, lookupFrom(map[string]string{"ENV": "staging"}))
+
+	if err := op.Open(bookmarktest.New(t, "https://${ENV}.grafana.internal")); err != nil {
+		t.Fatalf("Open should succeed: %v", err)
+	}
+
+	if len(next.opened) != 1 {
+		t.Fatalf("expected 1 opened bookmark, got %d", len(next.opened))
+	}
+	if got := next.opened[0].URL.Value(); got != "https://staging.grafana.internal" {
+		t.Errorf("expected expanded URL, got %q", got)
+	}
+}
+
+func TestExpandingOpener_PlainURLIsPassedThrough(t *testing.T) {
+	next := &recordingOpener{}
+	op := opener.NewExpandingOpener(next, lookupFrom(nil))
+
+	if err := op.Open(bookmarktest.New(t, "https://example.com")); err != nil {
+		t.Fatalf("Open should succeed: %v", err)
+	}
+
+	if got := next.opened[0].URL.Value(); got != "https://example.com" {
+		t.Errorf("expected URL to be unchanged, got %q", got)
+	}
+}
+
+func TestExpandingOpener_UndefinedVariableFails(t *testing.T) {
+	next := &recordingOpener{}
+	op := opener.NewExpandingOpener(next, lookupFrom(nil))
+
+	err := op.Open(bookmarktest.New(t, "http://localhost:${API_PORT}/debug"))
+	if !errors.Is(err, bookmark.ErrUndefinedVariable) {
+		t.Fatalf("expected ErrUndefinedVariable, got %v", err)
+	}
+	if len(next.opened) != 0 {
+		t.Errorf("next opener should not be called")
+	}
+}
@@ -0,0 +1,27 @@
+package opener
+
+import "github.com/airRnot1106/bkm/internal/bookmark"
+
+// Registry dispatches to the opener registered for the scheme of the bookmark
+// URL and falls back to another opener for unregistered schemes.
+type Registry struct {
+	openers  map[string]Opener
+	fallback Opener
+}
+
+var _ Opener = (*Registry)(nil)
+
+func NewRegistry(fallback Opener) *Registry {
+	return &Registry{openers: map[string]Opener{}, fallback: fallback}
+}
+
+func (r *Registry) Register(scheme string, op Opener) {
+	r.openers[scheme] = op
+}
+
+func (r *Registry) Open(bm bookmark.Bookmark) error {
+	if op, ok := r.openers[bm.URL.Scheme()]; ok {
+		return op.Open(bm)
+	}
+	return r.fallback.Open(bm)
+}
@@ -0,0 +1,39 @@
+package opener_test
+
+import (
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/bookmark/bookmarktest"
+	"github.com/airRnot1106/bkm/internal/opener"
+)
+
+func TestRegistry_DispatchesByScheme(t *testing.T) {
+	fallback := &recordingOpener{}
+	ssh := &recordingOpener{}
+	file := &recordingOpener{}
+
+	registry := opener.NewRegistry(fallback)
+	registry.Register("ssh", ssh)
+	registry.Register("file", file)
+
+	for _, rawURL := range []string{
+		"ssh://deploy@bastion.example.com",
+		"file:///home/me/notes.pdf",
+		"https://example.com",
+		"mailto:team@example.com",
+	} {
+		if err := registry.Open(bookmarktest.New(t, rawURL)); err != nil {
+			t.Fatalf("Open(%q) should succeed: %v", rawURL, err)
+		}
+	}
+
+	if len(ssh.opened) != 1 || ssh.opened[0].URL.Scheme() != "ssh" {
+		t.Errorf("expected ssh opener to receive the ssh URL, got %v", ssh.opened)
+	}
+	if len(file.opened) != 1 || file.opened[0].URL.Scheme() != "file" {
+		t.Errorf("expected file opener to receive the file URL, got %v", file.opened)
+	}
+	if len(fallback.opened) != 2 {
+		t.Errorf("expected fallback to receive 2 URLs, got %d", len(fallback.opened))
+	}
+}
@@ -0,0 +1,84 @@
+package opener
+
+import (
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+)
+
+// Route sends bookmarks matching all of its non-empty conditions to Opener.
+type Route struct {
+	// Domain is a glob matched against the host name, e.g. "*.corp.example.com".
+	Domain string
+	// Tag must be one of the bookmark tags.
+	Tag string
+	// URL is matched against the bookmark URL.
+	URL *regexp.Regexp
+
+	Opener Opener
+}
+
+func (r Route) Matches(bm bookmark.Bookmark) bool {
+	if r.Domain != "" {
+		parsed, err := url.Parse(bm.URL.Value())
+		if err != nil {
+			return false
+		}
+		if ok, err := path.Match(strings.ToLower(r.Domain), strings.ToLower(parsed.Hostname())); err != nil || !ok {
+			return false
+		}
+	}
+	if r.Tag != "" && !hasTag(bm, r.Tag) {
+		return false
+	}
+	if r.URL != nil && !r.URL.MatchString(bm.URL.Value()) {
+		return false
+	}
+	return true
+}
+
+func hasTag(bm bookmark.Bookmark, tag string) bool {
+	for _, t := range bm.Tags {
+		if t.Value() == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// RoutingOpener opens a bookmark with the first matching route, or with the
+// fallback opener when no route matches.
+type RoutingOpener struct {
+	routes   []Route
+	fallback Opener
+}
+
+var _ Opener = (*RoutingOpener)(nil)
+
+func NewRoutingOpener(routes []Route, fallback Opener) *RoutingOpener {
+	return &RoutingOpener{routes: routes, fallback: fallback}
+}
+
+func (o *RoutingOpener) Open(bm bookmark.Bookmark) error {
+	for _, route := range o.routes {
+		if route.Matches(bm) {
+			return route.Opener.Open(bm)
+		}
+	}
+	return o.fallback.Open(bm)
+}
+
+// NewDefaultBrowserOpener opens URLs with the first command in $BROWSER, or
+// with the platform default browser when it is unset. $BROWSER marks the URL
+// with %s rather than {url}.
+func NewDefaultBrowserOpener() (Opener, error) {
+	browser, _, _ := strings.Cut(os.Getenv("BROWSER"), string(os.PathListSeparator))
+	if strings.TrimSpace(browser) == "" {
+		return NewBrowserOpener(), nil
+	}
+	return NewCommandOpener(strings.ReplaceAll(browser, "%s", "{url}"))
+}
@@ -0,0 +1,115 @@
+package opener_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/bookmark/bookmarktest"
+	"github.com/airRnot1106/bkm/internal/opener"
+)
+
+func TestRoute_Matches(t *testing.T) {
+	tests := []struct {
+		name     string
+		route    opener.Route
+		bm       bookmark.Bookmark
+		expected bool
+	}{
+		{"empty route matches everything", opener.Route{}, bookmarktest.New(t, "https://example.com"), true},
+		{"domain glob", opener.Route{Domain: "*.corp.example.com"}, bookmarktest.New(t, "https://wiki.corp.example.com/page"), true},
+		{"domain glob ignores port and case", opener.Route{Domain: "*.Corp.example.com"}, bookmarktest.New(t, "https://wiki.corp.example.com:8443"), true},
+		{"domain glob mismatch", opener.Route{Domain: "*.corp.example.com"}, bookmarktest.New(t, "https://example.com"), false},
+		{"tag", opener.Route{Tag: "work"}, bookmarktest.New(t, "https://example.com", "go", "work"), true},
+		{"tag mismatch", opener.Route{Tag: "work"}, bookmarktest.New(t, "https://example.com", "go"), false},
+		{"url regex", opener.Route{URL: regexp.MustCompile(`^https://github\.com/acme/`)}, bookmarktest.New(t, "https://github.com/acme/api"), true},
+		{"url regex mismatch", opener.Route{URL: regexp.MustCompile(`^https://github\.com/acme/`)}, bookmarktest.New(t, "https://github.com/golang/go"), false},
+		{"all conditions must match", opener.Route{Domain: "github.com", Tag: "work"}, bookmarktest.New(t, "https://github.com"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.route.Matches(tt.bm); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRoutingOpener_UsesFirstMatchingRoute(t *testing.T) {
+	work := &recordingOpener{}
+	personal := &recordingOpener{}
+	fallback := &recordingOpener{}
+
+	op := opener.NewRoutingOpener([]opener.Route{
+		{Tag: "work", Opener: work},
+		{Domain: "*.example.com", Opener: personal},
+	}, fallback)
+
+	bookmarks := []bookmark.Bookmark{
+		bookmarktest.New(t, "https://blog.example.com", "work"),
+		bookmarktest.New(t, "https://blog.example.com"),
+		bookmarktest.New(t, "https://golang.org"),
+	}
+	for _, bm := range bookmarks {
+		if err := op.Open(bm); err != nil {
+			t.Fatalf("Open should succeed: %v", err)
+		}
+	}
+
+	if len(work.opened) != 1 || len(personal.opened) != 1 || len(fallback.opened) != 1 {
+		t.Errorf("expected one bookmark per opener, got work=%d personal=%d fallback=%d",
+			len(work.opened), len(personal.opened), len(fallback.opened))
+	}
+}
+
+func TestNewDefaultBrowserOpener_HonorsBrowserEnv(t *testing.T) {
+	command, output := writeRecorder(t)
+	t.Setenv("BROWSER", command+" --new-window")
+
+	op, err := opener.NewDefaultBrowserOpener()
+	if err != nil {
+		t.Fatalf("NewDefaultBrowserOpener should succeed: %v", err)
+	}
+
+	if err := op.Open(bookmarktest.New(t, "https://example.com")); err != nil {
+		t.Fatalf("Open should succeed: %v", err)
+	}
+
+	got := readArgs(t, output)
+	if len(got) != 2 || got[0] != "--new-window" || got[1] != "https://example.com" {
+		t.Errorf("expected [--new-window https://example.com], got %v", got)
+	}
+}
+
+func TestNewDefaultBrowserOpener_ReplacesPercentS(t *testing.T) {
+	command, output := writeRecorder(t)
+	t.Setenv("BROWSER", command+" --url=%s")
+
+	op, err := opener.NewDefaultBrowserOpener()
+	if err != nil {
+		t.Fatalf("NewDefaultBrowserOpener should succeed: %v", err)
+	}
+
+	if err := op.Open(bookmarktest.New(t, "https://example.com")); err != nil {
+		t.Fatalf("Open should succeed: %v", err)
+	}
+
+	got := readArgs(t, output)
+	if len(got) != 1 || got[0] != "--url=https://example.com" {
+		t.Errorf("expected [--url=https://example.com], got %v", got)
+	}
+}
+
+func TestNewDefaultBrowserOpener_FallsBackWithoutBrowserEnv(t *testing.T) {
+	t.Setenv("BROWSER", "")
+
+	op, err := opener.NewDefaultBrowserOpener()
+	if err != nil {
+		t.Fatalf("NewDefaultBrowserOpener should succeed: %v", err)
+	}
+
+	if _, ok := op.(*opener.BrowserOpener); !ok {
+		t.Errorf("expected *BrowserOpener, got %T", op)
+	}
+}
@@ -0,0 +1,88 @@
+package secretscan
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Policy decides what happens to a URL with secrets.
+type Policy string
+
+const (
+	// PolicyRefuse rejects the URL.
+	PolicyRefuse Policy = "refuse"
+	// PolicyRedact replaces the secrets with Redacted.
+	PolicyRedact Policy = "redact"
+	// PolicyWarn keeps the URL as it is.
+	PolicyWarn Policy = "warn"
+)
+
+// DefaultPolicy is used when no policy is configured.
+const DefaultPolicy = PolicyRefuse
+
+func ParsePolicy(s string) (Policy, error) {
+	switch policy := Policy(strings.TrimSpace(s)); policy {
+	case "":
+		return DefaultPolicy, nil
+	case PolicyRefuse, PolicyRedact, PolicyWarn:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("secrets policy must be one of refuse, redact or warn, got %q", s)
+	}
+}
+
+// Guard applies a Policy to the secrets a Scanner finds.
+type Guard struct {
+	scanner *Scanner
+	policy  Policy
+	report  func(redactedURL string, findings []Finding)
+}
+
+type GuardOption func(*Guard)
+
+// WithReporter calls report for URLs with secrets that are stored anyway,
+// under PolicyRedact or PolicyWarn. The URL is passed redacted so that
+// reports do not leak the secrets.
+func WithReporter(report func(redactedURL string, findings []Finding)) GuardOption {
+	return func(g *Guard) {
+		g.report = report
+	}
+}
+
+func NewGuard(scanner *Scanner, policy Policy, opts ...GuardOption) *Guard {
+	g := &Guard{scanner: scanner, policy: policy}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// Check returns the URL to store in place of rawURL. It fails with
+// ErrSecretFound under PolicyRefuse.
+func (g *Guard) Check(rawURL string) (string, error) {
+	findings := g.scanner.Scan(rawURL)
+	if len(findings) == 0 {
+		return rawURL, nil
+	}
+
+	if g.policy == PolicyRefuse {
+		return "", fmt.Errorf("%w: %s", ErrSecretFound, FormatFindings(findings))
+	}
+	redacted := g.scanner.Redact(rawURL)
+	if g.report != nil {
+		g.report(redacted, findings)
+	}
+	if g.policy == PolicyRedact {
+		return redacted, nil
+	}
+	return rawURL, nil
+}
+
+// FormatFindings lists findings on one line.
+func FormatFindings(findings []Finding) string {
+	names := make([]string, len(findings))
+	for i, f := range findings {
+		names[i] = f.String()
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,64 @@
+package secretscan_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/secretscan"
+)
+
+func TestParsePolicy(t *testing.T) {
+	policy, err := secretscan.ParsePolicy("")
+	if err != nil || policy != secretscan.DefaultPolicy {
+		t.Errorf("expected the default policy, got %q (%v)", policy, err)
+	}
+	if policy, err = secretscan.ParsePolicy("redact"); err != nil || policy != secretscan.PolicyRedact {
+		t.Errorf("expected redact, got %q (%v)", policy, err)
+	}
+	if _, err = secretscan.ParsePolicy("ignore"); err == nil {
+		t.Error("expected an error for an unknown policy")
+	}
+}
+
+func TestGuard_Check(t *testing.T) {
+	rawURL := "https://example.com/?token=abc"
+
+	tests := []struct {
+		policy   secretscan.Policy
+		expected string
+	}{
+		{secretscan.PolicyRedact, "https://example.com/?token=REDACTED"},
+		{secretscan.PolicyWarn, rawURL},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			var reported string
+			guard := secretscan.NewGuard(secretscan.NewScanner(), tt.policy, secretscan.WithReporter(func(redactedURL string, findings []secretscan.Finding) {
+				reported = redactedURL
+			}))
+
+			got, err := guard.Check(rawURL)
+			if err != nil {
+				t.Fatalf("expected success, got error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+			if reported != "https://example.com/?token=REDACTED" {
+				t.Errorf("expected the redacted URL to be reported, got %q", reported)
+			}
+		})
+	}
+}
+
+func TestGuard_CheckRefuse(t *testing.T) {
+	guard := secretscan.NewGuard(secretscan.NewScanner(), secretscan.PolicyRefuse)
+
+	if _, err := guard.Check("https://example.com/?token=abc"); !errors.Is(err, secretscan.ErrSecretFound) {
+		t.Errorf("expected ErrSecretFound, got %v", err)
+	}
+	if got, err := guard.Check("https://example.com/"); err != nil || got != "https://example.com/" {
+		t.Errorf("expected clean URL to pass, got %q (%v)", got, err)
+	}
+}
@@ -0,0 +1,195 @@
+// Package secretscan finds credentials in URLs, such as access tokens in the
+// query, presigned request signatures and passwords in the userinfo.
+package secretscan
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var ErrSecretFound = errors.New("URL contains secrets")
+
+// Redacted replaces the value of a secret.
+const Redacted = "REDACTED"
+
+type Location string
+
+const (
+	LocationUserinfo Location = "userinfo"
+	LocationQuery    Location = "query"
+	LocationFragment Location = "fragment"
+)
+
+// Finding is a secret in a URL. Name is the parameter name, or "user" or
+// "password" for the userinfo.
+type Finding struct {
+	Location Location
+	Name     string
+}
+
+func (f Finding) String() string {
+	if f.Location == LocationUserinfo {
+		return fmt.Sprintf("%s in userinfo", f.Name)
+	}
+	return fmt.Sprintf("%s parameter %q", f.Location, f.Name)
+}
+
+// defaultParams are globs of parameter names whose values are secrets.
+var defaultParams = []string{
+	"token", "*_token", "*-token", "accesstoken", "access_key", "*_access_key", "accesskey",
+	"secret", "*_secret", "password", "passwd", "pwd", "api_key", "apikey", "*_api_key",
+	"auth", "authorization", "private_token", "sig", "signature",
+	"x-amz-signature", "x-amz-credential", "x-amz-security-token",
+	"x-goog-signature", "x-goog-credential", "googleaccessid",
+}
+
+// valuePatterns match well-known token formats regardless of the parameter
+// name.
+var valuePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^AKIA[0-9A-Z]{16}$`),                      // AWS access key ID
+	regexp.MustCompile(`^gh[pousr]_[A-Za-z0-9]{36,}$`),            // GitHub token
+	regexp.MustCompile(`^github_pat_[A-Za-z0-9_]{40,}$`),          // GitHub fine-grained token
+	regexp.MustCompile(`^glpat-[A-Za-z0-9_-]{20,}$`),              // GitLab token
+	regexp.MustCompile(`^xox[abposr]-[A-Za-z0-9-]{10,}$`),         // Slack token
+	regexp.MustCompile(`^AIza[0-9A-Za-z_-]{35}$`),                 // Google API key
+	regexp.MustCompile(`^eyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.`), // JWT
+}
+
+// placeholderPattern matches values that stand in for a secret instead of
+// holding one: the %s placeholder of a URL template and ${NAME} references
+// that are expanded when the bookmark is opened.
+var placeholderPattern = regexp.MustCompile(`^(%s|\$\{[A-Za-z_][A-Za-z0-9_]*\})$`)
+
+type Scanner struct {
+	params []string
+}
+
+type Option func(*Scanner)
+
+// WithParams treats parameters matching the globs as secrets too.
+func WithParams(params ...string) Option {
+	return func(s *Scanner) {
+		s.params = append(s.params, params...)
+	}
+}
+
+func NewScanner(opts ...Option) *Scanner {
+	s := &Scanner{params: slices.Clone(defaultParams)}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Scan returns the secrets in rawURL.
+func (s *Scanner) Scan(rawURL string) []Finding {
+	findings, _ := s.scan(rawURL)
+	return findings
+}
+
+// Redact returns rawURL with the value of every secret replaced by Redacted.
+// The rest of the URL is kept byte for byte.
+func (s *Scanner) Redact(rawURL string) string {
+	_, redacted := s.scan(rawURL)
+	return redacted
+}
+
+func (s *Scanner) scan(rawURL string) ([]Finding, string) {
+	rest, fragment, hasFragment := strings.Cut(rawURL, "#")
+	rest, query, hasQuery := strings.Cut(rest, "?")
+
+	findings, redacted := s.scanUserinfo(rest)
+	if hasQuery {
+		found, cleaned := s.scanParams(LocationQuery, query)
+		findings = append(findings, found...)
+		redacted += "?" + cleaned
+	}
+	if hasFragment {
+		found, cleaned := s.scanParams(LocationFragment, fragment)
+		findings = append(findings, found...)
+		redacted += "#" + cleaned
+	}
+	return findings, redacted
+}
+
+// scanUserinfo looks for a password, or a user name that is a token, in the
+// part of the URL before the query.
+func (s *Scanner) scanUserinfo(rest string) ([]Finding, string) {
+	scheme, hierarchy, ok := strings.Cut(rest, "://")
+	if !ok {
+		return nil, rest
+	}
+	authority, tail := hierarchy, ""
+	if i := strings.Index(hierarchy, "/"); i >= 0 {
+		authority, tail = hierarchy[:i], hierarchy[i:]
+	}
+	at := strings.LastIndex(authority, "@")
+	if at < 0 {
+		return nil, rest
+	}
+	userinfo, host := authority[:at], authority[at+1:]
+
+	user, password, hasPassword := strings.Cut(userinfo, ":")
+	var findings []Finding
+	if isTokenValue(user) {
+		findings = append(findings, Finding{Location: LocationUserinfo, Name: "user"})
+		user = Redacted
+	}
+	if hasPassword && password != "" && password != Redacted && !placeholderPattern.MatchString(password) {
+		findings = append(findings, Finding{Location: LocationUserinfo, Name: "password"})
+		password = Redacted
+	}
+	if len(findings) == 0 {
+		return nil, rest
+	}
+
+	userinfo = user
+	if hasPassword {
+		userinfo += ":" + password
+	}
+	return findings, scheme + "://" + userinfo + "@" + host + tail
+}
+
+func (s *Scanner) scanParams(location Location, raw string) ([]Finding, string) {
+	pairs := strings.Split(raw, "&")
+	var findings []Finding
+	for i, pair := range pairs {
+		key, value, hasValue := strings.Cut(pair, "=")
+		if !hasValue || value == "" || value == Redacted || placeholderPattern.MatchString(value) {
+			continue
+		}
+		name := unescape(key)
+		if !s.isSecretParam(name) && !isTokenValue(unescape(value)) {
+			continue
+		}
+		findings = append(findings, Finding{Location: location, Name: name})
+		pairs[i] = key + "=" + Redacted
+	}
+	return findings, strings.Join(pairs, "&")
+}
+
+func (s *Scanner) isSecretParam(name string) bool {
+	name = strings.ToLower(name)
+	return slices.ContainsFunc(s.params, func(pattern string) bool {
+		ok, err := path.Match(strings.ToLower(pattern), name)
+		return err == nil && ok
+	})
+}
+
+func isTokenValue(value string) bool {
+	return slices.ContainsFunc(valuePatterns, func(pattern *regexp.Regexp) bool {
+		return pattern.MatchString(value)
+	})
+}
+
+func unescape(s string) string {
+	if unescaped, err := url.QueryUnescape(s); err == nil {
+		return unescaped
+	}
+	return s
+}
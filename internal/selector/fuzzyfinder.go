@@ -3,18 +3,39 @@ package selector
 import (
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/airRnot1106/bkm/internal/bookmark"
 	"github.com/ktr0731/go-fuzzyfinder"
 )
 
-type FuzzyFinderSelector struct{}
+type FuzzyFinderSelector struct {
+	lookup func(name string) (string, bool)
+}
+
+var (
+	_ Selector        = (*FuzzyFinderSelector)(nil)
+	_ VariantSelector = (*FuzzyFinderSelector)(nil)
+	_ TagSelector     = (*FuzzyFinderSelector)(nil)
+)
 
-var _ Selector = (*FuzzyFinderSelector)(nil)
+type Option func(*FuzzyFinderSelector)
 
-func NewFuzzyFinderSelector() *FuzzyFinderSelector {
-	return &FuzzyFinderSelector{}
+// WithVariables shows URLs with ${NAME} references expanded in the preview.
+func WithVariables(lookup func(name string) (string, bool)) Option {
+	return func(s *FuzzyFinderSelector) {
+		s.lookup = lookup
+	}
+}
+
+func NewFuzzyFinderSelector(opts ...Option) *FuzzyFinderSelector {
+	s := &FuzzyFinderSelector{}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 func (s *FuzzyFinderSelector) Select(bookmarks []bookmark.Bookmark) (bookmark.Bookmark, error) {
@@ -27,12 +48,7 @@ func (s *FuzzyFinderSelector) Select(bookmarks []bookmark.Bookmark) (bookmark.Bo
 		func(i int) string {
 			return formatBookmarkForDisplay(bookmarks[i])
 		},
-		fuzzyfinder.WithPreviewWindow(func(i, w, h int) string {
-			if i < 0 || i >= len(bookmarks) {
-				return ""
-			}
-			return formatBookmarkForPreview(bookmarks[i])
-		}),
+		s.previewOption(bookmarks),
 	)
 	if err != nil {
 		if errors.Is(err, fuzzyfinder.ErrAbort) {
@@ -44,6 +60,126 @@ func (s *FuzzyFinderSelector) Select(bookmarks []bookmark.Bookmark) (bookmark.Bo
 	return bookmarks[idx], nil
 }
 
+// SelectMulti lets the user mark bookmarks with Tab. Without marks, the
+// bookmark under the cursor is selected.
+func (s *FuzzyFinderSelector) SelectMulti(bookmarks []bookmark.Bookmark) ([]bookmark.Bookmark, error) {
+	if len(bookmarks) == 0 {
+		return nil, fmt.Errorf("no bookmarks to select from")
+	}
+
+	idxs, err := fuzzyfinder.FindMulti(
+		bookmarks,
+		func(i int) string {
+			return formatBookmarkForDisplay(bookmarks[i])
+		},
+		s.previewOption(bookmarks),
+		fuzzyfinder.WithHeader("Tab to mark, Enter to open"),
+	)
+	if err != nil {
+		if errors.Is(err, fuzzyfinder.ErrAbort) {
+			return nil, ErrCancelled
+		}
+		return nil, fmt.Errorf("fuzzy finder error: %w", err)
+	}
+
+	selected := make([]bookmark.Bookmark, len(idxs))
+	for i, idx := range idxs {
+		selected[i] = bookmarks[idx]
+	}
+	return selected, nil
+}
+
+func (s *FuzzyFinderSelector) previewOption(bookmarks []bookmark.Bookmark) fuzzyfinder.Option {
+	return fuzzyfinder.WithPreviewWindow(func(i, w, h int) string {
+		if i < 0 || i >= len(bookmarks) {
+			return ""
+		}
+		return s.formatBookmarkForPreview(bookmarks[i])
+	})
+}
+
+func (s *FuzzyFinderSelector) SelectVariant(b bookmark.Bookmark) (bookmark.BookmarkVariantName, error) {
+	names := append([]bookmark.BookmarkVariantName{{}}, b.VariantNames()...)
+
+	idx, err := fuzzyfinder.Find(
+		names,
+		func(i int) string {
+			return formatVariantForDisplay(b, names[i])
+		},
+		fuzzyfinder.WithHeader(b.Title.Value()),
+	)
+	if err != nil {
+		if errors.Is(err, fuzzyfinder.ErrAbort) {
+			return bookmark.BookmarkVariantName{}, ErrCancelled
+		}
+		return bookmark.BookmarkVariantName{}, fmt.Errorf("fuzzy finder error: %w", err)
+	}
+
+	return names[idx], nil
+}
+
+const (
+	noTagsItem = iota
+	newTagItem
+)
+
+// SelectTags lists the tags in use with their counts. Besides the tags, the
+// list has entries to pick no tags at all and to create new ones.
+func (s *FuzzyFinderSelector) SelectTags(counts []bookmark.TagCount, preselected []bookmark.BookmarkTag) (TagSelection, error) {
+	labels := []string{"(no tags)", "+ new tag"}
+	tags := []bookmark.BookmarkTag{{}, {}}
+	for _, c := range counts {
+		labels = append(labels, fmt.Sprintf("%s (%d)", c.Tag.Value(), c.Count))
+		tags = append(tags, c.Tag)
+	}
+	for _, tag := range preselected {
+		if !slices.Contains(tags, tag) {
+			labels = append(labels, fmt.Sprintf("%s (new)", tag.Value()))
+			tags = append(tags, tag)
+		}
+	}
+
+	idxs, err := fuzzyfinder.FindMulti(
+		labels,
+		func(i int) string {
+			return labels[i]
+		},
+		fuzzyfinder.WithHeader("Tab to mark, Enter to accept"),
+		fuzzyfinder.WithPreselected(func(i int) bool {
+			return i > newTagItem && slices.Contains(preselected, tags[i])
+		}),
+	)
+	if err != nil {
+		if errors.Is(err, fuzzyfinder.ErrAbort) {
+			return TagSelection{}, ErrCancelled
+		}
+		return TagSelection{}, fmt.Errorf("fuzzy finder error: %w", err)
+	}
+
+	var selection TagSelection
+	for _, idx := range idxs {
+		switch idx {
+		case noTagsItem:
+		case newTagItem:
+			selection.CreateNew = true
+		default:
+			selection.Tags = append(selection.Tags, tags[idx])
+		}
+	}
+	return selection, nil
+}
+
+func formatVariantForDisplay(b bookmark.Bookmark, name bookmark.BookmarkVariantName) string {
+	url, err := b.URLFor(name)
+	if err != nil {
+		return name.Value()
+	}
+	if name == (bookmark.BookmarkVariantName{}) {
+		return fmt.Sprintf("(primary) | %s", url.Value())
+	}
+	return fmt.Sprintf("%s | %s", name.Value(), url.Value())
+}
+
 func formatTagsAsCommaSeparated(tags []bookmark.BookmarkTag) string {
 	tagNames := make([]string, len(tags))
 	for i, tag := range tags {
@@ -57,10 +193,29 @@ func formatBookmarkForDisplay(b bookmark.Bookmark) string {
 	return fmt.Sprintf("%s | %s | %s | %s", b.Title.Value(), b.URL.Value(), tags, b.Description.Value())
 }
 
-func formatBookmarkForPreview(b bookmark.Bookmark) string {
-	return fmt.Sprintf("%s\n\nURL: %s\nDescription: %s\nTags: %s",
-		b.Title.Value(),
-		b.URL.Value(),
+func (s *FuzzyFinderSelector) formatBookmarkForPreview(b bookmark.Bookmark) string {
+	preview := fmt.Sprintf("%s\n\nURL: %s", b.Title.Value(), b.URL.Value())
+	if s.lookup != nil && len(b.URL.Variables()) > 0 {
+		if expanded, err := b.URL.Expand(s.lookup); err != nil {
+			preview += fmt.Sprintf("\nExpanded: (%v)", err)
+		} else {
+			preview += fmt.Sprintf("\nExpanded: %s", expanded.Value())
+		}
+	}
+	for _, name := range b.VariantNames() {
+		preview += fmt.Sprintf("\nURL (%s): %s", name.Value(), b.Variants[name].Value())
+	}
+	preview += fmt.Sprintf("\nDescription: %s\nTags: %s",
 		b.Description.Value(),
 		formatTagsAsCommaSeparated(b.Tags))
+	if status := b.Status.Value(); status != "" {
+		preview += fmt.Sprintf("\nStatus: %s", status)
+	}
+	if !b.Snapshot.ArchivedAt.IsZero() {
+		preview += fmt.Sprintf("\nArchived: %s", b.Snapshot.ArchivedAt.Format(time.DateTime))
+	}
+	if notes := b.Notes.Value(); notes != "" {
+		preview += fmt.Sprintf("\n\n%s", notes)
+	}
+	return preview
 }
@@ -21,3 +21,12 @@ func TestFuzzyFinderSelector_SelectEmptyBookmarksFails(t *testing.T) {
 		t.Fatal("Selecting from empty bookmarks should return an error")
 	}
 }
+
+func TestNewFuzzyFinderSelector_WithVariables(t *testing.T) {
+	fz := selector.NewFuzzyFinderSelector(selector.WithVariables(func(string) (string, bool) {
+		return "", false
+	}))
+	if fz == nil {
+		t.Fatal("FuzzyFinderSelector should not be nil")
+	}
+}
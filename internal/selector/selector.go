@@ -10,4 +10,26 @@ var ErrCancelled = errors.New("selection cancelled")
 
 type Selector interface {
 	Select(items []bookmark.Bookmark) (bookmark.Bookmark, error)
+	// SelectMulti lets the user mark any number of items.
+	SelectMulti(items []bookmark.Bookmark) ([]bookmark.Bookmark, error)
+}
+
+// VariantSelector chooses which URL variant of a bookmark to use. The zero
+// variant name stands for the primary URL.
+type VariantSelector interface {
+	SelectVariant(item bookmark.Bookmark) (bookmark.BookmarkVariantName, error)
+}
+
+// TagSelection is the outcome of picking tags. CreateNew is set when the user
+// asked to enter tags that are not listed.
+type TagSelection struct {
+	Tags      []bookmark.BookmarkTag
+	CreateNew bool
+}
+
+// TagSelector lets the user pick any number of tags from the ones in use.
+// Preselected tags start out marked and are listed even when no bookmark has
+// them yet.
+type TagSelector interface {
+	SelectTags(counts []bookmark.TagCount, preselected []bookmark.BookmarkTag) (TagSelection, error)
 }
@@ -0,0 +1,15 @@
+package session
+
+import "errors"
+
+var (
+	ErrSessionNotFound  = errors.New("session not found")
+	ErrDuplicateSession = errors.New("session already exists")
+)
+
+type Repository interface {
+	Add(session Session) error
+	List() ([]Session, error)
+	Update(session Session) error
+	Delete(name SessionName) error
+}
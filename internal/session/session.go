@@ -0,0 +1,85 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+)
+
+type SessionName struct {
+	value string
+}
+
+func (n SessionName) Value() string {
+	return n.value
+}
+
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+func NewSessionName(name string) (SessionName, error) {
+	trimmed := strings.TrimSpace(name)
+
+	if trimmed == "" {
+		return SessionName{}, errors.New("session name cannot be empty")
+	}
+	if !namePattern.MatchString(trimmed) {
+		return SessionName{}, errors.New("session name may only contain letters, digits, '.', '_' and '-'")
+	}
+
+	return SessionName{value: trimmed}, nil
+}
+
+var (
+	ErrAlreadyInSession = errors.New("bookmark is already in the session")
+	ErrNotInSession     = errors.New("bookmark is not in the session")
+)
+
+// Session is an ordered set of bookmarks that are opened together. It refers
+// to bookmarks by ID so that editing a bookmark does not affect the session.
+type Session struct {
+	Name        SessionName
+	BookmarkIDs []bookmark.BookmarkID
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func NewSession(name SessionName, bookmarkIDs []bookmark.BookmarkID, createdAt time.Time, updatedAt time.Time) Session {
+	return Session{
+		Name:        name,
+		BookmarkIDs: bookmarkIDs,
+		CreatedAt:   createdAt,
+		UpdatedAt:   updatedAt,
+	}
+}
+
+func CreateSession(name SessionName) Session {
+	now := time.Now()
+	return NewSession(name, nil, now, now)
+}
+
+func (s Session) Contains(id bookmark.BookmarkID) bool {
+	return slices.Contains(s.BookmarkIDs, id)
+}
+
+// Append adds the bookmark to the end of the session.
+func (s *Session) Append(id bookmark.BookmarkID) error {
+	if s.Contains(id) {
+		return fmt.Errorf("%w: %s", ErrAlreadyInSession, id.Value())
+	}
+	s.BookmarkIDs = append(s.BookmarkIDs, id)
+	return nil
+}
+
+func (s *Session) Remove(id bookmark.BookmarkID) error {
+	idx := slices.Index(s.BookmarkIDs, id)
+	if idx < 0 {
+		return fmt.Errorf("%w: %s", ErrNotInSession, id.Value())
+	}
+	s.BookmarkIDs = slices.Delete(s.BookmarkIDs, idx, idx+1)
+	return nil
+}
@@ -0,0 +1,80 @@
+package session_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/session"
+	"pgregory.net/rapid"
+)
+
+func TestNewSessionName_ValidNamesAlwaysSucceed(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		name := rapid.StringMatching(`[A-Za-z0-9][A-Za-z0-9._-]{0,20}`).Draw(t, "name")
+
+		sessionName, err := session.NewSessionName("  " + name + " ")
+		if err != nil {
+			t.Fatalf("valid name %q should succeed, got error: %v", name, err)
+		}
+		if sessionName.Value() != name {
+			t.Fatalf("name should be trimmed: expected %q, got %q", name, sessionName.Value())
+		}
+	})
+}
+
+func TestNewSessionName_InvalidNamesFail(t *testing.T) {
+	for _, name := range []string{"", "   ", "incident response", "-leading", "a/b"} {
+		if _, err := session.NewSessionName(name); err == nil {
+			t.Errorf("name %q should fail", name)
+		}
+	}
+}
+
+func TestSession_AppendKeepsOrder(t *testing.T) {
+	name, _ := session.NewSessionName("incident-response")
+	s := session.CreateSession(name)
+
+	ids := []bookmark.BookmarkID{bookmark.GenerateBookmarkID(), bookmark.GenerateBookmarkID(), bookmark.GenerateBookmarkID()}
+	for _, id := range ids {
+		if err := s.Append(id); err != nil {
+			t.Fatalf("Append should succeed: %v", err)
+		}
+	}
+
+	for i, id := range ids {
+		if s.BookmarkIDs[i] != id {
+			t.Errorf("expected %s at position %d, got %s", id.Value(), i, s.BookmarkIDs[i].Value())
+		}
+	}
+}
+
+func TestSession_AppendDuplicateFails(t *testing.T) {
+	name, _ := session.NewSessionName("daily")
+	s := session.CreateSession(name)
+	id := bookmark.GenerateBookmarkID()
+
+	if err := s.Append(id); err != nil {
+		t.Fatalf("Append should succeed: %v", err)
+	}
+	if err := s.Append(id); !errors.Is(err, session.ErrAlreadyInSession) {
+		t.Fatalf("expected ErrAlreadyInSession, got %v", err)
+	}
+}
+
+func TestSession_Remove(t *testing.T) {
+	name, _ := session.NewSessionName("daily")
+	s := session.CreateSession(name)
+	first, second := bookmark.GenerateBookmarkID(), bookmark.GenerateBookmarkID()
+	s.BookmarkIDs = []bookmark.BookmarkID{first, second}
+
+	if err := s.Remove(first); err != nil {
+		t.Fatalf("Remove should succeed: %v", err)
+	}
+	if s.Contains(first) || !s.Contains(second) {
+		t.Errorf("expected only the second bookmark to remain, got %v", s.BookmarkIDs)
+	}
+	if err := s.Remove(first); !errors.Is(err, session.ErrNotInSession) {
+		t.Fatalf("expected ErrNotInSession, got %v", err)
+	}
+}
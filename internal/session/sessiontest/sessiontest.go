@@ -0,0 +1,24 @@
+// Package sessiontest builds sessions for tests.
+package sessiontest
+
+import (
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/session"
+)
+
+// New returns a session named rawName with the given bookmarks in order.
+// Invalid values fail the test.
+func New(t testing.TB, rawName string, bms ...bookmark.Bookmark) session.Session {
+	t.Helper()
+	name, err := session.NewSessionName(rawName)
+	if err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+	sess := session.CreateSession(name)
+	for _, bm := range bms {
+		sess.BookmarkIDs = append(sess.BookmarkIDs, bm.ID)
+	}
+	return sess
+}
@@ -0,0 +1,143 @@
+package snapshot
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	DefaultTimeout        = 30 * time.Second
+	DefaultMaxBytes       = 5 << 20
+	DefaultMaxInlineBytes = 10 << 20
+)
+
+var errTooLarge = errors.New("response is too large")
+
+// HTTPCapturer downloads a page and inlines its stylesheets and images as
+// long as they fit in the inline budget. Resources beyond the budget are
+// linked by their absolute URL instead, and scripts are removed.
+type HTTPCapturer struct {
+	client         *http.Client
+	maxBytes       int64
+	maxInlineBytes int64
+}
+
+var _ Capturer = (*HTTPCapturer)(nil)
+
+type Option func(*HTTPCapturer)
+
+// WithTimeout limits each request: the page and every inlined resource.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *HTTPCapturer) {
+		c.client.Timeout = timeout
+	}
+}
+
+// WithMaxBytes limits the size of the page itself.
+func WithMaxBytes(maxBytes int64) Option {
+	return func(c *HTTPCapturer) {
+		c.maxBytes = maxBytes
+	}
+}
+
+// WithMaxInlineBytes limits the total size of the inlined resources.
+func WithMaxInlineBytes(maxInlineBytes int64) Option {
+	return func(c *HTTPCapturer) {
+		c.maxInlineBytes = maxInlineBytes
+	}
+}
+
+func NewHTTPCapturer(opts ...Option) *HTTPCapturer {
+	c := &HTTPCapturer{
+		client:         &http.Client{Timeout: DefaultTimeout},
+		maxBytes:       DefaultMaxBytes,
+		maxInlineBytes: DefaultMaxInlineBytes,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *HTTPCapturer) Capture(rawURL string) ([]byte, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return nil, ErrUnsupportedURL
+	}
+
+	page, err := c.get(parsed, c.maxBytes)
+	if err != nil {
+		if errors.Is(err, errTooLarge) {
+			return nil, fmt.Errorf("page is larger than %d bytes", c.maxBytes)
+		}
+		return nil, fmt.Errorf("failed to fetch page: %w", err)
+	}
+	if page.mediaType != "text/html" && page.mediaType != "application/xhtml+xml" {
+		return nil, fmt.Errorf("%w: %s", ErrNotHTML, page.mediaType)
+	}
+
+	in := &inliner{
+		base:    page.url,
+		charset: page.charset,
+		budget:  c.maxInlineBytes,
+		fetch: func(u *url.URL, limit int64) (resource, error) {
+			return c.get(u, limit)
+		},
+	}
+	return []byte(in.run(string(page.body))), nil
+}
+
+// resource is a downloaded page, stylesheet or image.
+type resource struct {
+	// url is the location after redirects.
+	url       *url.URL
+	body      []byte
+	mediaType string
+	charset   string
+}
+
+func (c *HTTPCapturer) get(u *url.URL, limit int64) (res resource, retErr error) {
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return resource{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "bkm")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return resource{}, err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil && retErr == nil {
+			retErr = fmt.Errorf("failed to close response body: %w", err)
+		}
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resource{}, errors.New(resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return resource{}, fmt.Errorf("failed to read response: %w", err)
+	}
+	if int64(len(body)) > limit {
+		return resource{}, errTooLarge
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(body)
+	}
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = "application/octet-stream"
+	}
+
+	return resource{url: resp.Request.URL, body: body, mediaType: mediaType, charset: params["charset"]}, nil
+}
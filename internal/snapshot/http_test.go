@@ -0,0 +1,126 @@
+package snapshot_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/snapshot"
+)
+
+var pixel = []byte("\x89PNG\r\n\x1a\nfake")
+
+func newSite(t *testing.T, page string) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/docs/page", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=iso-8859-1")
+		fmt.Fprint(w, page)
+	})
+	mux.HandleFunc("/assets/site.css", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/css")
+		fmt.Fprint(w, `body { background: url(img/bg.png) } </style>`)
+	})
+	mux.HandleFunc("/assets/img/bg.png", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(pixel)
+	})
+	mux.HandleFunc("/docs/logo.png", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(pixel)
+	})
+	mux.HandleFunc("/docs/large.png", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte(strings.Repeat("x", 4096)))
+	})
+	mux.HandleFunc("/data.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{}`)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestHTTPCapturer_InlinesResources(t *testing.T) {
+	server := newSite(t, `<!DOCTYPE html><html><head><title>Docs</title>`+
+		`<link rel="stylesheet" href="/assets/site.css" media="screen">`+
+		`<script>alert("hi")</script></head>`+
+		`<body><img src="logo.png" srcset="logo-2x.png 2x" alt="Logo"><a href="other">Other</a></body></html>`)
+
+	content, err := snapshot.NewHTTPCapturer().Capture(server.URL + "/docs/page")
+	if err != nil {
+		t.Fatalf("Capture should succeed: %v", err)
+	}
+	page := string(content)
+
+	dataURL := "data:image/png;base64," + base64.StdEncoding.EncodeToString(pixel)
+	for _, expected := range []string{
+		`<head><meta charset="iso-8859-1"><base href="` + server.URL + `/docs/page">`,
+		`<style media="screen">body { background: url("` + dataURL + `") } <\/style></style>`,
+		`<img src="` + dataURL + `" alt="Logo">`,
+		`<a href="other">Other</a>`,
+	} {
+		if !strings.Contains(page, expected) {
+			t.Errorf("expected the snapshot to contain %q, got:\n%s", expected, page)
+		}
+	}
+	if strings.Contains(page, "alert") {
+		t.Errorf("expected scripts to be removed, got:\n%s", page)
+	}
+}
+
+func TestHTTPCapturer_LinksResourcesBeyondTheBudget(t *testing.T) {
+	server := newSite(t, `<img src="large.png"><img src="logo.png">`)
+
+	content, err := snapshot.NewHTTPCapturer(snapshot.WithMaxInlineBytes(1024)).Capture(server.URL + "/docs/page")
+	if err != nil {
+		t.Fatalf("Capture should succeed: %v", err)
+	}
+	page := string(content)
+
+	if !strings.Contains(page, `<img src="large.png">`) {
+		t.Errorf("expected the large image to stay linked, got:\n%s", page)
+	}
+	if !strings.Contains(page, `<img src="data:image/png;base64,`) {
+		t.Errorf("expected the small image to be inlined, got:\n%s", page)
+	}
+	if !strings.HasPrefix(page, `<meta charset="iso-8859-1"><base href="`+server.URL+`/docs/page">`) {
+		t.Errorf("expected a base element before the first tag, got:\n%s", page)
+	}
+}
+
+func TestHTTPCapturer_UsesTheBaseElement(t *testing.T) {
+	server := newSite(t, `<head><base href="/assets/"></head><body><img src="img/bg.png"></body>`)
+
+	content, err := snapshot.NewHTTPCapturer().Capture(server.URL + "/docs/page")
+	if err != nil {
+		t.Fatalf("Capture should succeed: %v", err)
+	}
+	page := string(content)
+
+	if strings.Count(page, "<base") != 1 || !strings.Contains(page, `<base href="`+server.URL+`/assets/">`) {
+		t.Errorf("expected a single base element pointing at the original base, got:\n%s", page)
+	}
+	if !strings.Contains(page, `<img src="data:image/png;base64,`) {
+		t.Errorf("expected the image to be resolved against the base, got:\n%s", page)
+	}
+}
+
+func TestHTTPCapturer_Errors(t *testing.T) {
+	server := newSite(t, "")
+
+	if _, err := snapshot.NewHTTPCapturer().Capture(server.URL + "/data.json"); !errors.Is(err, snapshot.ErrNotHTML) {
+		t.Errorf("expected ErrNotHTML, got %v", err)
+	}
+	if _, err := snapshot.NewHTTPCapturer().Capture("mailto:someone@example.com"); !errors.Is(err, snapshot.ErrUnsupportedURL) {
+		t.Errorf("expected ErrUnsupportedURL, got %v", err)
+	}
+	if _, err := snapshot.NewHTTPCapturer().Capture(server.URL + "/missing"); err == nil {
+		t.Error("expected an error for a missing page")
+	}
+}
@@ -0,0 +1,273 @@
+package snapshot
+
+import (
+	"encoding/base64"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// cssURLPattern matches url(...) references in CSS with the reference in one
+// of the three groups depending on its quoting.
+var cssURLPattern = regexp.MustCompile(`(?i)url\(\s*(?:"([^"]*)"|'([^']*)'|([^)'"\s]*))\s*\)`)
+
+// token is a token together with its source, which is written unchanged
+// unless the token has to be rewritten.
+type token struct {
+	html.Token
+	Raw string
+}
+
+// inliner rewrites a page so that it renders without the network: resources
+// are embedded as data URLs within the budget, and a <base> element makes the
+// remaining relative links point at the original site.
+type inliner struct {
+	base *url.URL
+	// charset is the charset from the Content-Type header, which is lost
+	// when the page is opened from a file.
+	charset string
+	budget  int64
+	fetch   func(u *url.URL, limit int64) (resource, error)
+
+	out         strings.Builder
+	baseWritten bool
+	// skipUntil is the end tag that closes the element being dropped.
+	skipUntil string
+	inStyle   bool
+	// downloads holds every resource fetched so far, nil for failures.
+	downloads map[string]*resource
+}
+
+func (in *inliner) run(src string) string {
+	in.downloads = make(map[string]*resource)
+	in.base = findBase(src, in.base)
+
+	z := html.NewTokenizer(strings.NewReader(src))
+	for z.Next() != html.ErrorToken {
+		// Raw is read first: building the token lowercases the tag in place.
+		raw := string(z.Raw())
+		in.write(token{Token: z.Token(), Raw: raw})
+	}
+	return in.out.String()
+}
+
+// findBase returns the URL that relative references of the page resolve
+// against: the first <base href> or the page URL.
+func findBase(src string, pageURL *url.URL) *url.URL {
+	z := html.NewTokenizer(strings.NewReader(src))
+	for z.Next() != html.ErrorToken {
+		tok := z.Token()
+		if (tok.Type == html.StartTagToken || tok.Type == html.SelfClosingTagToken) && tok.Data == "base" {
+			if href, ok := attrValue(tok.Attr, "href"); ok {
+				if resolved, err := pageURL.Parse(strings.TrimSpace(href)); err == nil {
+					return resolved
+				}
+			}
+		}
+	}
+	return pageURL
+}
+
+func (in *inliner) write(tok token) {
+	if in.skipUntil != "" {
+		if tok.Type == html.EndTagToken && tok.Data == in.skipUntil {
+			in.skipUntil = ""
+		}
+		return
+	}
+
+	switch tok.Type {
+	case html.StartTagToken, html.SelfClosingTagToken:
+		in.writeTag(tok)
+	case html.TextToken:
+		if in.inStyle {
+			in.out.WriteString(in.inlineCSS(tok.Raw, in.base))
+			return
+		}
+		in.out.WriteString(tok.Raw)
+	case html.EndTagToken:
+		if tok.Data == "style" {
+			in.inStyle = false
+		}
+		in.out.WriteString(tok.Raw)
+	default:
+		in.out.WriteString(tok.Raw)
+	}
+}
+
+func (in *inliner) writeTag(tok token) {
+	if !in.baseWritten && tok.Data != "html" && tok.Data != "head" {
+		in.writeBase()
+	}
+
+	switch tok.Data {
+	case "head":
+		in.out.WriteString(tok.Raw)
+		if !in.baseWritten {
+			in.writeBase()
+		}
+	case "script":
+		if tok.Type == html.StartTagToken {
+			in.skipUntil = "script"
+		}
+	case "base":
+		// Replaced by the <base> written at the start of the head.
+	case "meta":
+		// A content security policy could forbid the inlined data URLs.
+		if equiv, _ := attrValue(tok.Attr, "http-equiv"); strings.EqualFold(equiv, "content-security-policy") {
+			return
+		}
+		in.out.WriteString(tok.Raw)
+	case "link":
+		in.writeLink(tok)
+	case "img":
+		in.writeImage(tok)
+	case "style":
+		in.inStyle = tok.Type == html.StartTagToken
+		in.out.WriteString(tok.Raw)
+	default:
+		in.out.WriteString(tok.Raw)
+	}
+}
+
+func (in *inliner) writeBase() {
+	in.baseWritten = true
+	if in.charset != "" {
+		in.out.WriteString(`<meta charset="` + html.EscapeString(in.charset) + `">`)
+	}
+	in.out.WriteString(`<base href="` + html.EscapeString(in.base.String()) + `">`)
+}
+
+func (in *inliner) writeLink(tok token) {
+	rel, _ := attrValue(tok.Attr, "rel")
+	href, ok := attrValue(tok.Attr, "href")
+	if !ok || !slices.Contains(strings.Fields(strings.ToLower(rel)), "stylesheet") {
+		in.out.WriteString(tok.Raw)
+		return
+	}
+
+	u, err := in.base.Parse(strings.TrimSpace(href))
+	if err != nil {
+		in.out.WriteString(tok.Raw)
+		return
+	}
+	css, ok := in.download(u)
+	if !ok {
+		in.out.WriteString(tok.Raw)
+		return
+	}
+
+	in.out.WriteString("<style")
+	if media, ok := attrValue(tok.Attr, "media"); ok {
+		in.out.WriteString(` media="` + html.EscapeString(media) + `"`)
+	}
+	in.out.WriteString(">")
+	// Relative references in a stylesheet resolve against the stylesheet.
+	// "</" is escaped so that the CSS cannot end the <style> element early.
+	in.out.WriteString(strings.ReplaceAll(in.inlineCSS(string(css.body), css.url), "</", `<\/`))
+	in.out.WriteString("</style>")
+}
+
+func (in *inliner) writeImage(tok token) {
+	src, ok := attrValue(tok.Attr, "src")
+	if !ok {
+		in.out.WriteString(tok.Raw)
+		return
+	}
+	inlined := in.inlineURL(src, in.base)
+	if !strings.HasPrefix(inlined, "data:") {
+		in.out.WriteString(tok.Raw)
+		return
+	}
+
+	// Drop srcset so that the browser uses the inlined image.
+	attrs := slices.DeleteFunc(slices.Clone(tok.Attr), func(attr html.Attribute) bool {
+		return attr.Key == "srcset" || attr.Key == "sizes"
+	})
+	for i := range attrs {
+		if attrs[i].Key == "src" {
+			attrs[i].Val = inlined
+		}
+	}
+	tok.Attr = attrs
+	in.out.WriteString(renderTag(tok.Token))
+}
+
+// inlineCSS replaces url(...) references in css with data URLs, or with
+// absolute URLs once the budget is spent.
+func (in *inliner) inlineCSS(css string, base *url.URL) string {
+	return cssURLPattern.ReplaceAllStringFunc(css, func(match string) string {
+		groups := cssURLPattern.FindStringSubmatch(match)
+		ref := groups[1] + groups[2] + groups[3]
+		if ref == "" || strings.HasPrefix(ref, "data:") || strings.HasPrefix(ref, "#") {
+			return match
+		}
+		return `url("` + strings.ReplaceAll(in.inlineURL(ref, base), `"`, `%22`) + `")`
+	})
+}
+
+// inlineURL returns ref as a data URL, or as an absolute URL when it cannot
+// be downloaded within the budget.
+func (in *inliner) inlineURL(ref string, base *url.URL) string {
+	u, err := base.Parse(strings.TrimSpace(ref))
+	if err != nil {
+		return ref
+	}
+	res, ok := in.download(u)
+	if !ok {
+		return u.String()
+	}
+	return "data:" + res.mediaType + ";base64," + base64.StdEncoding.EncodeToString(res.body)
+}
+
+// download fetches u and charges it to the budget. Each URL is downloaded at
+// most once; later references reuse the result.
+func (in *inliner) download(u *url.URL) (resource, bool) {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return resource{}, false
+	}
+	key := u.String()
+	if res, seen := in.downloads[key]; seen {
+		if res == nil {
+			return resource{}, false
+		}
+		return *res, true
+	}
+	in.downloads[key] = nil
+
+	if in.budget <= 0 {
+		return resource{}, false
+	}
+	res, err := in.fetch(u, in.budget)
+	if err != nil {
+		return resource{}, false
+	}
+	in.budget -= int64(len(res.body))
+	in.downloads[key] = &res
+	return res, true
+}
+
+func renderTag(tok html.Token) string {
+	var b strings.Builder
+	b.WriteString("<" + tok.Data)
+	for _, attr := range tok.Attr {
+		b.WriteString(" " + attr.Key + `="` + html.EscapeString(attr.Val) + `"`)
+	}
+	if tok.Type == html.SelfClosingTagToken {
+		b.WriteString(" /")
+	}
+	b.WriteString(">")
+	return b.String()
+}
+
+func attrValue(attrs []html.Attribute, key string) (string, bool) {
+	for _, attr := range attrs {
+		if attr.Key == key {
+			return attr.Val, true
+		}
+	}
+	return "", false
+}
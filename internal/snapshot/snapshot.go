@@ -0,0 +1,38 @@
+// Package snapshot saves offline copies of bookmarked pages.
+package snapshot
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+)
+
+var (
+	ErrNotFound = errors.New("snapshot not found")
+	// ErrUnsupportedURL is returned for URLs that do not point at a single
+	// web page, such as URL templates and non-HTTP URLs.
+	ErrUnsupportedURL = errors.New("URL cannot be archived")
+	ErrNotHTML        = errors.New("page is not HTML")
+)
+
+// Capturer downloads a page into a single self-contained HTML document.
+type Capturer interface {
+	Capture(url string) ([]byte, error)
+}
+
+// Store keeps snapshots addressed by the digest of their content, so storing
+// the same page twice keeps a single copy.
+type Store interface {
+	// Put stores content and returns its digest.
+	Put(content []byte) (string, error)
+	// Get returns the content of the snapshot with the given digest.
+	Get(digest string) ([]byte, error)
+	// Path returns the file holding the snapshot with the given digest.
+	Path(digest string) (string, error)
+}
+
+// Digest returns the hex-encoded SHA-256 of content.
+func Digest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
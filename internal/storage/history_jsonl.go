@@ -0,0 +1,154 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/adrg/xdg"
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/history"
+)
+
+type historyEntryJSON struct {
+	BookmarkID string    `json:"bookmark_id"`
+	URL        string    `json:"url"`
+	OpenedAt   time.Time `json:"opened_at"`
+}
+
+// HistoryJSONLStorage keeps the history as one JSON object per line so that
+// recording an open only appends to the file.
+type HistoryJSONLStorage struct {
+	filePath string
+}
+
+var _ history.Repository = (*HistoryJSONLStorage)(nil)
+
+func NewDefaultHistoryJSONLStorage() (*HistoryJSONLStorage, error) {
+	dataDir := filepath.Join(xdg.DataHome, "bkm")
+	filePath := filepath.Join(dataDir, "history.jsonl")
+	return NewHistoryJSONLStorage(filePath)
+}
+
+func NewHistoryJSONLStorage(filePath string) (*HistoryJSONLStorage, error) {
+	dir := filepath.Dir(filePath)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+	return &HistoryJSONLStorage{filePath: filePath}, nil
+}
+
+func (s *HistoryJSONLStorage) Append(entry history.Entry) (retErr error) {
+	line, err := json.Marshal(historyEntryToDTO(entry))
+	if err != nil {
+		return fmt.Errorf("failed to marshal history entry: %w", err)
+	}
+
+	f, err := os.OpenFile(s.filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer func() {
+		if err := f.Close(); err != nil && retErr == nil {
+			retErr = fmt.Errorf("failed to close file: %w", err)
+		}
+	}()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
+
+func (s *HistoryJSONLStorage) List() ([]history.Entry, error) {
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []history.Entry{}, nil
+		}
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	entries := []history.Entry{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var dto historyEntryJSON
+		if err := json.Unmarshal(line, &dto); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal JSON at line %d: %w", lineNo, err)
+		}
+		entry, err := historyEntryFromDTO(dto)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert DTO at line %d: %w", lineNo, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (s *HistoryJSONLStorage) Prune(before time.Time) error {
+	entries, err := s.List()
+	if err != nil {
+		return fmt.Errorf("failed to read existing history: %w", err)
+	}
+
+	var buf bytes.Buffer
+	pruned := false
+	for _, entry := range entries {
+		if entry.OpenedAt.Before(before) {
+			pruned = true
+			continue
+		}
+		line, err := json.Marshal(historyEntryToDTO(entry))
+		if err != nil {
+			return fmt.Errorf("failed to marshal history entry: %w", err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	if !pruned {
+		return nil
+	}
+
+	if err := os.WriteFile(s.filePath, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
+
+func historyEntryToDTO(entry history.Entry) historyEntryJSON {
+	return historyEntryJSON{
+		BookmarkID: entry.BookmarkID.Value(),
+		URL:        entry.URL.Value(),
+		OpenedAt:   entry.OpenedAt,
+	}
+}
+
+func historyEntryFromDTO(dto historyEntryJSON) (history.Entry, error) {
+	id, err := bookmark.NewBookmarkID(dto.BookmarkID)
+	if err != nil {
+		return history.Entry{}, fmt.Errorf("invalid bookmark ID: %w", err)
+	}
+
+	url, err := bookmark.NewBookmarkURL(dto.URL)
+	if err != nil {
+		return history.Entry{}, fmt.Errorf("invalid URL: %w", err)
+	}
+
+	return history.NewEntry(id, url, dto.OpenedAt), nil
+}
@@ -0,0 +1,104 @@
+package storage_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/history"
+	"github.com/airRnot1106/bkm/internal/storage"
+)
+
+func newHistoryEntry(t *testing.T, rawURL string, openedAt time.Time) history.Entry {
+	t.Helper()
+	url, err := bookmark.NewBookmarkURL(rawURL)
+	if err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+	return history.NewEntry(bookmark.GenerateBookmarkID(), url, openedAt)
+}
+
+func TestHistoryJSONLStorage_AppendAndList(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "history.jsonl")
+	st, err := storage.NewHistoryJSONLStorage(filePath)
+	if err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	first := newHistoryEntry(t, "https://example.com/1", now.Add(-time.Hour))
+	second := newHistoryEntry(t, "https://example.com/2", now)
+	for _, entry := range []history.Entry{first, second} {
+		if err := st.Append(entry); err != nil {
+			t.Fatalf("Append should succeed: %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("failed to read history file: %v", err)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 2 {
+		t.Errorf("expected one line per entry, got %d lines", lines)
+	}
+
+	entries, err := st.List()
+	if err != nil {
+		t.Fatalf("List should succeed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].URL != first.URL || entries[0].BookmarkID != first.BookmarkID || !entries[0].OpenedAt.Equal(first.OpenedAt) {
+		t.Errorf("first entry mismatch: expected %+v, got %+v", first, entries[0])
+	}
+	if entries[1].URL != second.URL {
+		t.Errorf("second entry mismatch: expected %q, got %q", second.URL.Value(), entries[1].URL.Value())
+	}
+}
+
+func TestHistoryJSONLStorage_ListEmpty(t *testing.T) {
+	st, err := storage.NewHistoryJSONLStorage(filepath.Join(t.TempDir(), "history.jsonl"))
+	if err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+
+	entries, err := st.List()
+	if err != nil {
+		t.Fatalf("List should succeed: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected 0 entries, got %d", len(entries))
+	}
+}
+
+func TestHistoryJSONLStorage_Prune(t *testing.T) {
+	st, err := storage.NewHistoryJSONLStorage(filepath.Join(t.TempDir(), "history.jsonl"))
+	if err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+
+	now := time.Now()
+	old := newHistoryEntry(t, "https://example.com/old", now.Add(-48*time.Hour))
+	recent := newHistoryEntry(t, "https://example.com/recent", now)
+	for _, entry := range []history.Entry{old, recent} {
+		if err := st.Append(entry); err != nil {
+			t.Fatalf("Append should succeed: %v", err)
+		}
+	}
+
+	if err := st.Prune(now.Add(-24 * time.Hour)); err != nil {
+		t.Fatalf("Prune should succeed: %v", err)
+	}
+
+	entries, err := st.List()
+	if err != nil {
+		t.Fatalf("List should succeed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].URL != recent.URL {
+		t.Errorf("expected only the recent entry to remain, got %v", entries)
+	}
+}
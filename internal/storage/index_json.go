@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/adrg/xdg"
+	"github.com/airRnot1106/bkm/internal/fulltext"
+)
+
+type indexJSON struct {
+	Documents []documentJSON `json:"documents"`
+}
+
+type documentJSON struct {
+	ID     string         `json:"id"`
+	Digest string         `json:"digest"`
+	Terms  map[string]int `json:"terms"`
+}
+
+// IndexJSONStorage keeps the full-text index as JSON. Only the term counts of
+// each document are stored; the inverted index is rebuilt when loading.
+type IndexJSONStorage struct {
+	filePath string
+}
+
+var _ fulltext.Repository = (*IndexJSONStorage)(nil)
+
+func NewDefaultIndexJSONStorage() (*IndexJSONStorage, error) {
+	dataDir := filepath.Join(xdg.DataHome, "bkm")
+	filePath := filepath.Join(dataDir, "index.json")
+	return NewIndexJSONStorage(filePath)
+}
+
+func NewIndexJSONStorage(filePath string) (*IndexJSONStorage, error) {
+	dir := filepath.Dir(filePath)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+	return &IndexJSONStorage{filePath: filePath}, nil
+}
+
+func (s *IndexJSONStorage) Load() (*fulltext.Index, error) {
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fulltext.NewIndex(), nil
+		}
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var dto indexJSON
+	if err := json.Unmarshal(data, &dto); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+
+	docs := make([]fulltext.Document, len(dto.Documents))
+	for i, d := range dto.Documents {
+		docs[i] = fulltext.Document{ID: d.ID, Digest: d.Digest, Terms: d.Terms}
+	}
+	return fulltext.NewIndex(docs...), nil
+}
+
+func (s *IndexJSONStorage) Save(index *fulltext.Index) error {
+	docs := index.Documents()
+	dto := indexJSON{Documents: make([]documentJSON, len(docs))}
+	for i, d := range docs {
+		dto.Documents[i] = documentJSON{ID: d.ID, Digest: d.Digest, Terms: d.Terms}
+	}
+
+	// The index is rebuilt from the pages at any time, so it is not indented.
+	data, err := json.Marshal(dto)
+	if err != nil {
+		return fmt.Errorf("failed to marshal index: %w", err)
+	}
+
+	if err := os.WriteFile(s.filePath, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package storage_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/fulltext"
+	"github.com/airRnot1106/bkm/internal/storage"
+)
+
+func TestIndexJSONStorage_LoadWithoutFile(t *testing.T) {
+	st, err := storage.NewIndexJSONStorage(filepath.Join(t.TempDir(), "index.json"))
+	if err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+
+	idx, err := st.Load()
+	if err != nil {
+		t.Fatalf("Load should succeed: %v", err)
+	}
+	if len(idx.Documents()) != 0 {
+		t.Errorf("expected an empty index, got %v", idx.Documents())
+	}
+}
+
+func TestIndexJSONStorage_SaveAndLoad(t *testing.T) {
+	st, err := storage.NewIndexJSONStorage(filepath.Join(t.TempDir(), "index.json"))
+	if err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+
+	saved := fulltext.NewIndex(
+		fulltext.NewDocument("a", "digest-a", "tomatoes in the garden"),
+		fulltext.NewDocument("b", "digest-b", "pasta with tomatoes"),
+	)
+	if err := st.Save(saved); err != nil {
+		t.Fatalf("Save should succeed: %v", err)
+	}
+
+	loaded, err := st.Load()
+	if err != nil {
+		t.Fatalf("Load should succeed: %v", err)
+	}
+
+	doc, ok := loaded.Document("a")
+	if !ok || doc.Digest != "digest-a" || doc.Terms["tomatoes"] != 1 {
+		t.Errorf("expected the saved document, got %+v", doc)
+	}
+	expected, got := saved.Search("tomatoes garden"), loaded.Search("tomatoes garden")
+	if len(got) != len(expected) || got[0] != expected[0] || got[1] != expected[1] {
+		t.Errorf("expected the same ranking %v, got %v", expected, got)
+	}
+}
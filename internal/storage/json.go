@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"time"
 
 	"github.com/adrg/xdg"
@@ -13,20 +14,28 @@ import (
 )
 
 type bookmarkJSON struct {
-	ID          string    `json:"id"`
-	URL         string    `json:"url"`
-	Title       string    `json:"title"`
-	Description string    `json:"description,omitempty"`
-	Tags        []string  `json:"tags,omitempty"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          string            `json:"id"`
+	URL         string            `json:"url"`
+	Title       string            `json:"title"`
+	Description string            `json:"description,omitempty"`
+	Notes       string            `json:"notes,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	Keyword     string            `json:"keyword,omitempty"`
+	Variants    map[string]string `json:"variants,omitempty"`
+	Status      string            `json:"status,omitempty"`
+	LinkStatus  int               `json:"link_status,omitempty"`
+	CheckedAt   time.Time         `json:"checked_at,omitzero"`
+	Snapshot    string            `json:"snapshot,omitempty"`
+	ArchivedAt  time.Time         `json:"archived_at,omitzero"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
 }
 
 type JSONStorage struct {
 	filePath string
 }
 
-var _ bookmark.Repository = (*JSONStorage)(nil)
+var _ bookmark.BulkAdder = (*JSONStorage)(nil)
 
 func NewDefaultJSONStorage() (*JSONStorage, error) {
 	dataDir := filepath.Join(xdg.DataHome, "bkm")
@@ -54,21 +63,18 @@ func (s *JSONStorage) Add(bm bookmark.Bookmark) error {
 
 	bookmarks = append(bookmarks, bm)
 
-	dtos := make([]bookmarkJSON, len(bookmarks))
-	for i, b := range bookmarks {
-		dtos[i] = toDTO(b)
-	}
+	return s.save(bookmarks)
+}
 
-	data, err := json.MarshalIndent(dtos, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal bookmarks: %w", err)
+func (s *JSONStorage) AddAll(added []bookmark.Bookmark) error {
+	bookmarks, err := s.List()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read existing bookmarks: %w", err)
 	}
 
-	if err := os.WriteFile(s.filePath, data, 0o600); err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
-	}
+	bookmarks = append(bookmarks, added...)
 
-	return nil
+	return s.save(bookmarks)
 }
 
 func (s *JSONStorage) List() ([]bookmark.Bookmark, error) {
@@ -97,6 +103,44 @@ func (s *JSONStorage) List() ([]bookmark.Bookmark, error) {
 	return bookmarks, nil
 }
 
+func (s *JSONStorage) Update(bm bookmark.Bookmark) error {
+	bookmarks, err := s.List()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read existing bookmarks: %w", err)
+	}
+
+	idx := slices.IndexFunc(bookmarks, func(b bookmark.Bookmark) bool {
+		return b.ID == bm.ID
+	})
+	if idx < 0 {
+		return fmt.Errorf("%w: %s", bookmark.ErrBookmarkNotFound, bm.ID.Value())
+	}
+	bookmarks[idx] = bm
+
+	return s.save(bookmarks)
+}
+
+func (s *JSONStorage) UpdateAll(updates []bookmark.Bookmark) error {
+	bookmarks, err := s.List()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read existing bookmarks: %w", err)
+	}
+
+	index := make(map[bookmark.BookmarkID]int, len(bookmarks))
+	for i, b := range bookmarks {
+		index[b.ID] = i
+	}
+	for _, bm := range updates {
+		idx, ok := index[bm.ID]
+		if !ok {
+			return fmt.Errorf("%w: %s", bookmark.ErrBookmarkNotFound, bm.ID.Value())
+		}
+		bookmarks[idx] = bm
+	}
+
+	return s.save(bookmarks)
+}
+
 func (s *JSONStorage) Delete(id bookmark.BookmarkID) error {
 	bookmarks, err := s.List()
 	if err != nil && !errors.Is(err, os.ErrNotExist) {
@@ -110,8 +154,12 @@ func (s *JSONStorage) Delete(id bookmark.BookmarkID) error {
 		}
 	}
 
-	dtos := make([]bookmarkJSON, len(updatedBookmarks))
-	for i, b := range updatedBookmarks {
+	return s.save(updatedBookmarks)
+}
+
+func (s *JSONStorage) save(bookmarks []bookmark.Bookmark) error {
+	dtos := make([]bookmarkJSON, len(bookmarks))
+	for i, b := range bookmarks {
 		dtos[i] = toDTO(b)
 	}
 
@@ -138,7 +186,15 @@ func toDTO(bm bookmark.Bookmark) bookmarkJSON {
 		URL:         bm.URL.Value(),
 		Title:       bm.Title.Value(),
 		Description: bm.Description.Value(),
+		Notes:       bm.Notes.Value(),
 		Tags:        tags,
+		Keyword:     bm.Keyword.Value(),
+		Variants:    variantsToDTO(bm.Variants),
+		Status:      bm.Status.Value(),
+		LinkStatus:  bm.LastCheck.StatusCode,
+		CheckedAt:   bm.LastCheck.CheckedAt,
+		Snapshot:    bm.Snapshot.Digest,
+		ArchivedAt:  bm.Snapshot.ArchivedAt,
 		CreatedAt:   bm.CreatedAt,
 		UpdatedAt:   bm.UpdatedAt,
 	}
@@ -171,5 +227,63 @@ func fromDTO(dto bookmarkJSON) (bookmark.Bookmark, error) {
 		tags = append(tags, tag)
 	}
 
-	return bookmark.NewBookmark(id, url, title, description, tags, dto.CreatedAt, dto.UpdatedAt), nil
+	var keyword bookmark.BookmarkKeyword
+	if dto.Keyword != "" {
+		keyword, err = bookmark.NewBookmarkKeyword(dto.Keyword)
+		if err != nil {
+			return bookmark.Bookmark{}, fmt.Errorf("invalid keyword: %w", err)
+		}
+	}
+
+	variants, err := variantsFromDTO(dto.Variants)
+	if err != nil {
+		return bookmark.Bookmark{}, err
+	}
+
+	status, err := bookmark.NewBookmarkStatus(dto.Status)
+	if err != nil {
+		return bookmark.Bookmark{}, fmt.Errorf("invalid status: %w", err)
+	}
+
+	bm := bookmark.NewBookmark(id, url, title, description, tags, dto.CreatedAt, dto.UpdatedAt)
+	bm.Notes = bookmark.NewBookmarkNotes(dto.Notes)
+	bm.Keyword = keyword
+	bm.Variants = variants
+	bm.Status = status
+	bm.LastCheck = bookmark.LinkCheck{StatusCode: dto.LinkStatus, CheckedAt: dto.CheckedAt}
+	bm.Snapshot = bookmark.Snapshot{Digest: dto.Snapshot, ArchivedAt: dto.ArchivedAt}
+
+	return bm, nil
+}
+
+func variantsToDTO(variants map[bookmark.BookmarkVariantName]bookmark.BookmarkURL) map[string]string {
+	if len(variants) == 0 {
+		return nil
+	}
+
+	dto := make(map[string]string, len(variants))
+	for name, url := range variants {
+		dto[name.Value()] = url.Value()
+	}
+	return dto
+}
+
+func variantsFromDTO(dto map[string]string) (map[bookmark.BookmarkVariantName]bookmark.BookmarkURL, error) {
+	if len(dto) == 0 {
+		return nil, nil
+	}
+
+	variants := make(map[bookmark.BookmarkVariantName]bookmark.BookmarkURL, len(dto))
+	for rawName, rawURL := range dto {
+		name, err := bookmark.NewBookmarkVariantName(rawName)
+		if err != nil {
+			return nil, fmt.Errorf("invalid variant name %q: %w", rawName, err)
+		}
+		url, err := bookmark.NewBookmarkURL(rawURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid URL of variant %q: %w", rawName, err)
+		}
+		variants[name] = url
+	}
+	return variants, nil
 }
@@ -1,12 +1,15 @@
 package storage_test
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/bookmark/bookmarktest"
 	"github.com/airRnot1106/bkm/internal/storage"
 )
 
@@ -154,3 +157,217 @@ func TestJSONStorage_Delete(t *testing.T) {
 		t.Fatalf("expected 0 bookmarks after deletion, got %d", len(bookmarks))
 	}
 }
+
+func TestJSONStorage_Update(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "bookmarks.json")
+	st, err := storage.NewJSONStorage(filePath)
+	if err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+
+	bm := bookmarktest.New(t, "https://example.com")
+
+	if err := st.Add(bm); err != nil {
+		t.Fatalf("Add should succeed: %v", err)
+	}
+
+	bm.Status = bookmark.StatusReading
+	if err := st.Update(bm); err != nil {
+		t.Fatalf("Update should succeed: %v", err)
+	}
+
+	bookmarks, err := st.List()
+	if err != nil {
+		t.Fatalf("List should succeed: %v", err)
+	}
+
+	if len(bookmarks) != 1 {
+		t.Fatalf("expected 1 bookmark, got %d", len(bookmarks))
+	}
+	if bookmarks[0].Status != bookmark.StatusReading {
+		t.Errorf("Status mismatch: expected %q, got %q", bookmark.StatusReading.Value(), bookmarks[0].Status.Value())
+	}
+}
+
+func TestJSONStorage_UpdateMissingBookmarkFails(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "bookmarks.json")
+	st, err := storage.NewJSONStorage(filePath)
+	if err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+
+	err = st.Update(bookmarktest.New(t, "https://example.com"))
+	if !errors.Is(err, bookmark.ErrBookmarkNotFound) {
+		t.Fatalf("expected ErrBookmarkNotFound, got %v", err)
+	}
+}
+
+func TestJSONStorage_NotesAreStoredSeparatelyFromDescription(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "bookmarks.json")
+	st, err := storage.NewJSONStorage(filePath)
+	if err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+
+	bm := bookmarktest.New(t, "https://example.com")
+	bm.Description = bookmark.NewBookmarkDescription("One line")
+	bm.Notes = bookmark.NewBookmarkNotes("# Why\n\nMultiple\nlines")
+
+	if err := st.Add(bm); err != nil {
+		t.Fatalf("Add should succeed: %v", err)
+	}
+
+	bookmarks, err := st.List()
+	if err != nil {
+		t.Fatalf("List should succeed: %v", err)
+	}
+
+	if bookmarks[0].Notes != bm.Notes {
+		t.Errorf("Notes mismatch: expected %q, got %q", bm.Notes.Value(), bookmarks[0].Notes.Value())
+	}
+	if bookmarks[0].Description != bm.Description {
+		t.Errorf("Description mismatch: expected %q, got %q", bm.Description.Value(), bookmarks[0].Description.Value())
+	}
+}
+
+func TestJSONStorage_VariantsRoundTrip(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "bookmarks.json")
+	st, err := storage.NewJSONStorage(filePath)
+	if err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+
+	prodURL, _ := bookmark.NewBookmarkURL("https://grafana.example.com")
+	prod, _ := bookmark.NewBookmarkVariantName("prod")
+	bm := bookmarktest.New(t, "https://grafana.dev.example.com")
+	bm.Variants = map[bookmark.BookmarkVariantName]bookmark.BookmarkURL{prod: prodURL}
+
+	if err := st.Add(bm); err != nil {
+		t.Fatalf("Add should succeed: %v", err)
+	}
+
+	bookmarks, err := st.List()
+	if err != nil {
+		t.Fatalf("List should succeed: %v", err)
+	}
+
+	if got := bookmarks[0].Variants[prod]; got != prodURL {
+		t.Errorf("Variant mismatch: expected %q, got %q", prodURL.Value(), got.Value())
+	}
+}
+
+func TestJSONStorage_ListReadsSchemesOutsideTheAllowlist(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "bookmarks.json")
+	data := `[{"id":"6f1c2d3e-4b5a-4c6d-8e7f-8091a2b3c4d5","url":"obsidian://open?vault=work","title":"Todo"}]`
+	if err := os.WriteFile(filePath, []byte(data), 0o600); err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+	st, err := storage.NewJSONStorage(filePath)
+	if err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+
+	bookmarks, err := st.List()
+	if err != nil {
+		t.Fatalf("List should succeed: %v", err)
+	}
+
+	if len(bookmarks) != 1 || bookmarks[0].URL.Value() != "obsidian://open?vault=work" {
+		t.Errorf("expected the stored bookmark, got %+v", bookmarks)
+	}
+}
+
+func TestJSONStorage_AddAll(t *testing.T) {
+	st, err := storage.NewJSONStorage(filepath.Join(t.TempDir(), "bookmarks.json"))
+	if err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+
+	if err := st.Add(bookmarktest.New(t, "https://example.com")); err != nil {
+		t.Fatalf("Add should succeed: %v", err)
+	}
+
+	createdAt := time.Date(2015, 3, 1, 12, 0, 0, 0, time.UTC)
+	var added []bookmark.Bookmark
+	for i := 1; i <= 2; i++ {
+		bm := bookmarktest.New(t, fmt.Sprintf("https://example%d.com", i))
+		bm.CreatedAt = createdAt
+		added = append(added, bm)
+	}
+	if err := st.AddAll(added); err != nil {
+		t.Fatalf("AddAll should succeed: %v", err)
+	}
+
+	bookmarks, err := st.List()
+	if err != nil {
+		t.Fatalf("List should succeed: %v", err)
+	}
+	if len(bookmarks) != 3 {
+		t.Fatalf("expected 3 bookmarks, got %d", len(bookmarks))
+	}
+	if bookmarks[1].ID != added[0].ID || bookmarks[2].ID != added[1].ID {
+		t.Errorf("expected the added bookmarks after the existing one")
+	}
+	if !bookmarks[1].CreatedAt.Equal(createdAt) {
+		t.Errorf("expected created time %v, got %v", createdAt, bookmarks[1].CreatedAt)
+	}
+}
+
+func TestJSONStorage_UpdateAll(t *testing.T) {
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "bookmarks.json")
+	st, err := storage.NewJSONStorage(filePath)
+	if err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+
+	var bms []bookmark.Bookmark
+	for i := 1; i <= 3; i++ {
+		bm := bookmarktest.New(t, fmt.Sprintf("https://example%d.com", i))
+		if err := st.Add(bm); err != nil {
+			t.Fatalf("Add should succeed: %v", err)
+		}
+		bms = append(bms, bm)
+	}
+
+	checkedAt := time.Now().UTC().Truncate(time.Second)
+	bms[0].LastCheck = bookmark.LinkCheck{StatusCode: 200, CheckedAt: checkedAt}
+	bms[2].LastCheck = bookmark.LinkCheck{StatusCode: 404, CheckedAt: checkedAt}
+	if err := st.UpdateAll([]bookmark.Bookmark{bms[0], bms[2]}); err != nil {
+		t.Fatalf("UpdateAll should succeed: %v", err)
+	}
+
+	bookmarks, err := st.List()
+	if err != nil {
+		t.Fatalf("List should succeed: %v", err)
+	}
+
+	expected := []int{200, 0, 404}
+	for i, bm := range bookmarks {
+		if bm.LastCheck.StatusCode != expected[i] {
+			t.Errorf("bookmark %d: expected status %d, got %d", i, expected[i], bm.LastCheck.StatusCode)
+		}
+	}
+	if !bookmarks[0].LastCheck.CheckedAt.Equal(checkedAt) {
+		t.Errorf("expected checked time %v, got %v", checkedAt, bookmarks[0].LastCheck.CheckedAt)
+	}
+	if !bookmarks[1].LastCheck.CheckedAt.IsZero() {
+		t.Errorf("expected unchecked bookmark to stay unchecked, got %v", bookmarks[1].LastCheck.CheckedAt)
+	}
+}
+
+func TestJSONStorage_UpdateAllMissingBookmarkFails(t *testing.T) {
+	st, err := storage.NewJSONStorage(filepath.Join(t.TempDir(), "bookmarks.json"))
+	if err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+
+	err = st.UpdateAll([]bookmark.Bookmark{bookmarktest.New(t, "https://example.com")})
+	if !errors.Is(err, bookmark.ErrBookmarkNotFound) {
+		t.Fatalf("expected ErrBookmarkNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,166 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/adrg/xdg"
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/session"
+)
+
+type sessionJSON struct {
+	Name        string    `json:"name"`
+	BookmarkIDs []string  `json:"bookmark_ids"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type SessionJSONStorage struct {
+	filePath string
+}
+
+var _ session.Repository = (*SessionJSONStorage)(nil)
+
+func NewDefaultSessionJSONStorage() (*SessionJSONStorage, error) {
+	dataDir := filepath.Join(xdg.DataHome, "bkm")
+	filePath := filepath.Join(dataDir, "sessions.json")
+	return NewSessionJSONStorage(filePath)
+}
+
+func NewSessionJSONStorage(filePath string) (*SessionJSONStorage, error) {
+	dir := filepath.Dir(filePath)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+	return &SessionJSONStorage{filePath: filePath}, nil
+}
+
+func (s *SessionJSONStorage) Add(sess session.Session) error {
+	sessions, err := s.List()
+	if err != nil {
+		return fmt.Errorf("failed to read existing sessions: %w", err)
+	}
+
+	if s.indexOf(sessions, sess.Name) >= 0 {
+		return fmt.Errorf("%w: %s", session.ErrDuplicateSession, sess.Name.Value())
+	}
+	sessions = append(sessions, sess)
+
+	return s.save(sessions)
+}
+
+func (s *SessionJSONStorage) List() ([]session.Session, error) {
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []session.Session{}, nil
+		}
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var dtos []sessionJSON
+	if err := json.Unmarshal(data, &dtos); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+
+	sessions := make([]session.Session, len(dtos))
+	for i, dto := range dtos {
+		sess, err := sessionFromDTO(dto)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert DTO at index %d: %w", i, err)
+		}
+		sessions[i] = sess
+	}
+
+	return sessions, nil
+}
+
+func (s *SessionJSONStorage) Update(sess session.Session) error {
+	sessions, err := s.List()
+	if err != nil {
+		return fmt.Errorf("failed to read existing sessions: %w", err)
+	}
+
+	idx := s.indexOf(sessions, sess.Name)
+	if idx < 0 {
+		return fmt.Errorf("%w: %s", session.ErrSessionNotFound, sess.Name.Value())
+	}
+	sessions[idx] = sess
+
+	return s.save(sessions)
+}
+
+func (s *SessionJSONStorage) Delete(name session.SessionName) error {
+	sessions, err := s.List()
+	if err != nil {
+		return fmt.Errorf("failed to read existing sessions: %w", err)
+	}
+
+	idx := s.indexOf(sessions, name)
+	if idx < 0 {
+		return fmt.Errorf("%w: %s", session.ErrSessionNotFound, name.Value())
+	}
+
+	return s.save(slices.Delete(sessions, idx, idx+1))
+}
+
+func (s *SessionJSONStorage) indexOf(sessions []session.Session, name session.SessionName) int {
+	return slices.IndexFunc(sessions, func(sess session.Session) bool {
+		return sess.Name == name
+	})
+}
+
+func (s *SessionJSONStorage) save(sessions []session.Session) error {
+	dtos := make([]sessionJSON, len(sessions))
+	for i, sess := range sessions {
+		dtos[i] = sessionToDTO(sess)
+	}
+
+	data, err := json.MarshalIndent(dtos, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal sessions: %w", err)
+	}
+
+	if err := os.WriteFile(s.filePath, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	return nil
+}
+
+func sessionToDTO(sess session.Session) sessionJSON {
+	ids := make([]string, len(sess.BookmarkIDs))
+	for i, id := range sess.BookmarkIDs {
+		ids[i] = id.Value()
+	}
+
+	return sessionJSON{
+		Name:        sess.Name.Value(),
+		BookmarkIDs: ids,
+		CreatedAt:   sess.CreatedAt,
+		UpdatedAt:   sess.UpdatedAt,
+	}
+}
+
+func sessionFromDTO(dto sessionJSON) (session.Session, error) {
+	name, err := session.NewSessionName(dto.Name)
+	if err != nil {
+		return session.Session{}, fmt.Errorf("invalid name: %w", err)
+	}
+
+	ids := make([]bookmark.BookmarkID, 0, len(dto.BookmarkIDs))
+	for _, rawID := range dto.BookmarkIDs {
+		id, err := bookmark.NewBookmarkID(rawID)
+		if err != nil {
+			return session.Session{}, fmt.Errorf("invalid bookmark ID %q: %w", rawID, err)
+		}
+		ids = append(ids, id)
+	}
+
+	return session.NewSession(name, ids, dto.CreatedAt, dto.UpdatedAt), nil
+}
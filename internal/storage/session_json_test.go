@@ -0,0 +1,105 @@
+package storage_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/session"
+	"github.com/airRnot1106/bkm/internal/storage"
+)
+
+func newSessionStorage(t *testing.T) *storage.SessionJSONStorage {
+	t.Helper()
+	st, err := storage.NewSessionJSONStorage(filepath.Join(t.TempDir(), "sessions.json"))
+	if err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+	return st
+}
+
+func TestSessionJSONStorage_AddAndList(t *testing.T) {
+	st := newSessionStorage(t)
+
+	name, _ := session.NewSessionName("incident-response")
+	sess := session.CreateSession(name)
+	ids := []bookmark.BookmarkID{bookmark.GenerateBookmarkID(), bookmark.GenerateBookmarkID()}
+	sess.BookmarkIDs = ids
+
+	if err := st.Add(sess); err != nil {
+		t.Fatalf("Add should succeed: %v", err)
+	}
+
+	sessions, err := st.List()
+	if err != nil {
+		t.Fatalf("List should succeed: %v", err)
+	}
+
+	if len(sessions) != 1 {
+		t.Fatalf("expected 1 session, got %d", len(sessions))
+	}
+	if sessions[0].Name != name {
+		t.Errorf("Name mismatch: expected %q, got %q", name.Value(), sessions[0].Name.Value())
+	}
+	if len(sessions[0].BookmarkIDs) != 2 || sessions[0].BookmarkIDs[0] != ids[0] || sessions[0].BookmarkIDs[1] != ids[1] {
+		t.Errorf("BookmarkIDs mismatch: expected %v, got %v", ids, sessions[0].BookmarkIDs)
+	}
+}
+
+func TestSessionJSONStorage_ListEmpty(t *testing.T) {
+	st := newSessionStorage(t)
+
+	sessions, err := st.List()
+	if err != nil {
+		t.Fatalf("List should succeed: %v", err)
+	}
+	if len(sessions) != 0 {
+		t.Fatalf("expected 0 sessions, got %d", len(sessions))
+	}
+}
+
+func TestSessionJSONStorage_AddDuplicateFails(t *testing.T) {
+	st := newSessionStorage(t)
+
+	name, _ := session.NewSessionName("daily")
+	if err := st.Add(session.CreateSession(name)); err != nil {
+		t.Fatalf("Add should succeed: %v", err)
+	}
+
+	err := st.Add(session.CreateSession(name))
+	if !errors.Is(err, session.ErrDuplicateSession) {
+		t.Fatalf("expected ErrDuplicateSession, got %v", err)
+	}
+}
+
+func TestSessionJSONStorage_UpdateAndDelete(t *testing.T) {
+	st := newSessionStorage(t)
+
+	name, _ := session.NewSessionName("daily")
+	sess := session.CreateSession(name)
+	if err := st.Add(sess); err != nil {
+		t.Fatalf("Add should succeed: %v", err)
+	}
+
+	id := bookmark.GenerateBookmarkID()
+	sess.BookmarkIDs = []bookmark.BookmarkID{id}
+	if err := st.Update(sess); err != nil {
+		t.Fatalf("Update should succeed: %v", err)
+	}
+
+	sessions, err := st.List()
+	if err != nil {
+		t.Fatalf("List should succeed: %v", err)
+	}
+	if !sessions[0].Contains(id) {
+		t.Errorf("expected updated session to contain %s", id.Value())
+	}
+
+	if err := st.Delete(name); err != nil {
+		t.Fatalf("Delete should succeed: %v", err)
+	}
+	if err := st.Delete(name); !errors.Is(err, session.ErrSessionNotFound) {
+		t.Fatalf("expected ErrSessionNotFound, got %v", err)
+	}
+}
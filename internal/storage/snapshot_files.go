@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/adrg/xdg"
+	"github.com/airRnot1106/bkm/internal/snapshot"
+)
+
+var digestPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// SnapshotFileStorage keeps each snapshot in a file named after its digest,
+// in a subdirectory named after the first two characters of the digest.
+type SnapshotFileStorage struct {
+	dir string
+}
+
+var _ snapshot.Store = (*SnapshotFileStorage)(nil)
+
+func NewDefaultSnapshotFileStorage() (*SnapshotFileStorage, error) {
+	return NewSnapshotFileStorage(filepath.Join(xdg.DataHome, "bkm", "snapshots"))
+}
+
+func NewSnapshotFileStorage(dir string) (*SnapshotFileStorage, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	return &SnapshotFileStorage{dir: dir}, nil
+}
+
+func (s *SnapshotFileStorage) Put(content []byte) (string, error) {
+	digest := snapshot.Digest(content)
+	path := s.pathFor(digest)
+	if _, err := os.Stat(path); err == nil {
+		return digest, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return "", fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		return "", fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	return digest, nil
+}
+
+func (s *SnapshotFileStorage) Get(digest string) ([]byte, error) {
+	path, err := s.Path(digest)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	return content, nil
+}
+
+func (s *SnapshotFileStorage) Path(digest string) (string, error) {
+	// The digest comes from the bookmark file, so make sure it cannot point
+	// outside of the store.
+	if !digestPattern.MatchString(digest) {
+		return "", fmt.Errorf("%w: invalid digest %q", snapshot.ErrNotFound, digest)
+	}
+
+	path := s.pathFor(digest)
+	if _, err := os.Stat(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("%w: %s", snapshot.ErrNotFound, digest)
+		}
+		return "", fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	return path, nil
+}
+
+func (s *SnapshotFileStorage) pathFor(digest string) string {
+	return filepath.Join(s.dir, digest[:2], digest+".html")
+}
@@ -0,0 +1,84 @@
+package storage_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/snapshot"
+	"github.com/airRnot1106/bkm/internal/storage"
+)
+
+func newSnapshotStorage(t *testing.T) (*storage.SnapshotFileStorage, string) {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "snapshots")
+	st, err := storage.NewSnapshotFileStorage(dir)
+	if err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+	return st, dir
+}
+
+func TestSnapshotFileStorage_PutAndPath(t *testing.T) {
+	st, dir := newSnapshotStorage(t)
+	content := []byte("<title>Example</title>")
+
+	digest, err := st.Put(content)
+	if err != nil {
+		t.Fatalf("Put should succeed: %v", err)
+	}
+	if digest != snapshot.Digest(content) {
+		t.Errorf("expected the digest of the content, got %q", digest)
+	}
+
+	path, err := st.Path(digest)
+	if err != nil {
+		t.Fatalf("Path should succeed: %v", err)
+	}
+	if filepath.Dir(filepath.Dir(path)) != dir {
+		t.Errorf("expected the snapshot inside %s, got %s", dir, path)
+	}
+	stored, err := os.ReadFile(path)
+	if err != nil || string(stored) != string(content) {
+		t.Errorf("expected the stored content in the file, got %q (%v)", stored, err)
+	}
+	got, err := st.Get(digest)
+	if err != nil || string(got) != string(content) {
+		t.Errorf("expected Get to return the stored content, got %q (%v)", got, err)
+	}
+}
+
+func TestSnapshotFileStorage_SameContentIsStoredOnce(t *testing.T) {
+	st, dir := newSnapshotStorage(t)
+
+	first, err := st.Put([]byte("page"))
+	if err != nil {
+		t.Fatalf("Put should succeed: %v", err)
+	}
+	second, err := st.Put([]byte("page"))
+	if err != nil {
+		t.Fatalf("Put should succeed: %v", err)
+	}
+
+	if first != second {
+		t.Errorf("expected the same digest, got %q and %q", first, second)
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "*", "*.html"))
+	if len(files) != 1 {
+		t.Errorf("expected a single file, got %v", files)
+	}
+}
+
+func TestSnapshotFileStorage_PathNotFound(t *testing.T) {
+	st, _ := newSnapshotStorage(t)
+
+	for _, digest := range []string{snapshot.Digest([]byte("missing")), "../../etc/passwd", ""} {
+		if _, err := st.Path(digest); !errors.Is(err, snapshot.ErrNotFound) {
+			t.Errorf("Path(%q): expected ErrNotFound, got %v", digest, err)
+		}
+		if _, err := st.Get(digest); !errors.Is(err, snapshot.ErrNotFound) {
+			t.Errorf("Get(%q): expected ErrNotFound, got %v", digest, err)
+		}
+	}
+}
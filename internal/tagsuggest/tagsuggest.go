@@ -0,0 +1,176 @@
+// Package tagsuggest suggests tags for a new bookmark from configured rules,
+// the tags of bookmarks on the same site and the words of the page.
+package tagsuggest
+
+import (
+	"cmp"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/fulltext"
+)
+
+// DefaultLimit is the number of tags suggested when no limit is set.
+const DefaultLimit = 5
+
+// Weights of the sources of a suggestion. A rule is an explicit decision, so
+// it outranks tags inferred from other bookmarks or from the page text.
+const (
+	ruleWeight       = 3.0
+	sameDomainWeight = 2.0
+	keywordWeight    = 1.0
+)
+
+// Rule suggests Tags for URLs matching all of its non-empty conditions.
+type Rule struct {
+	// Domain is a glob matched against the host name, e.g. "*.go.dev".
+	Domain string
+	// URL is matched against the whole URL.
+	URL  *regexp.Regexp
+	Tags []string
+}
+
+func (r Rule) Matches(rawURL string) bool {
+	if r.Domain != "" {
+		parsed, err := url.Parse(rawURL)
+		if err != nil {
+			return false
+		}
+		if ok, err := path.Match(strings.ToLower(r.Domain), strings.ToLower(parsed.Hostname())); err != nil || !ok {
+			return false
+		}
+	}
+	if r.URL != nil && !r.URL.MatchString(rawURL) {
+		return false
+	}
+	return true
+}
+
+// Page is the bookmark being added.
+type Page struct {
+	URL         string
+	Title       string
+	Description string
+}
+
+type Suggester struct {
+	rules []Rule
+	limit int
+}
+
+type Option func(*Suggester)
+
+func WithLimit(limit int) Option {
+	return func(s *Suggester) {
+		s.limit = limit
+	}
+}
+
+func NewSuggester(rules []Rule, opts ...Option) *Suggester {
+	s := &Suggester{rules: rules, limit: DefaultLimit}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Suggest returns the best tags for page, best first. Besides the tags of
+// matching rules, it suggests the tags of existing bookmarks on the same site
+// and existing tags whose words appear in the title or description. Tags in
+// exclude are never suggested and do not take a place within the limit.
+func (s *Suggester) Suggest(page Page, existing []bookmark.Bookmark, exclude ...string) []string {
+	scores := make(map[string]float64)
+
+	for _, rule := range s.rules {
+		if rule.Matches(page.URL) {
+			for _, tag := range rule.Tags {
+				scores[tag] += ruleWeight
+			}
+		}
+	}
+
+	if site := siteOf(page.URL); site != "" {
+		counts, total := make(map[string]int), 0
+		for _, bm := range existing {
+			if siteOf(bm.URL.Value()) != site {
+				continue
+			}
+			total++
+			for _, tag := range bm.Tags {
+				counts[tag.Value()]++
+			}
+		}
+		for tag, count := range counts {
+			scores[tag] += sameDomainWeight * float64(count) / float64(total)
+		}
+	}
+
+	words := fulltext.Tokenize(page.Title + " " + page.Description)
+	for _, tag := range vocabulary(existing) {
+		if containsPhrase(words, fulltext.Tokenize(tag)) {
+			scores[tag] += keywordWeight
+		}
+	}
+
+	tags := make([]string, 0, len(scores))
+	for tag := range scores {
+		if !slices.Contains(exclude, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	slices.SortFunc(tags, func(a, b string) int {
+		if c := cmp.Compare(scores[b], scores[a]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a, b)
+	})
+	if s.limit > 0 && len(tags) > s.limit {
+		tags = tags[:s.limit]
+	}
+	return tags
+}
+
+// siteOf returns the lower-cased host name without a leading "www.".
+func siteOf(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+}
+
+func vocabulary(bookmarks []bookmark.Bookmark) []string {
+	var tags []string
+	for _, bm := range bookmarks {
+		for _, tag := range bm.Tags {
+			tags = append(tags, tag.Value())
+		}
+	}
+	slices.Sort(tags)
+	return slices.Compact(tags)
+}
+
+// containsPhrase reports whether phrase occurs in words as consecutive words.
+// Simple plurals match too, so the tag "tool" matches "tools".
+func containsPhrase(words, phrase []string) bool {
+	if len(phrase) == 0 {
+		return false
+	}
+	for i := 0; i+len(phrase) <= len(words); i++ {
+		matched := true
+		for j, p := range phrase {
+			if w := words[i+j]; w != p && w != p+"s" {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package tagsuggest_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/bookmark/bookmarktest"
+	"github.com/airRnot1106/bkm/internal/tagsuggest"
+)
+
+func TestRule_Matches(t *testing.T) {
+	tests := []struct {
+		rule     tagsuggest.Rule
+		url      string
+		expected bool
+	}{
+		{tagsuggest.Rule{Domain: "*.go.dev"}, "https://pkg.go.dev/net/http", true},
+		{tagsuggest.Rule{Domain: "*.go.dev"}, "https://go.dev/", false},
+		{tagsuggest.Rule{Domain: "GitHub.com"}, "https://github.com/x", true},
+		{tagsuggest.Rule{URL: regexp.MustCompile(`/issues/`)}, "https://github.com/x/issues/1", true},
+		{tagsuggest.Rule{Domain: "github.com", URL: regexp.MustCompile(`/issues/`)}, "https://github.com/x/pulls", false},
+	}
+	for _, tt := range tests {
+		if got := tt.rule.Matches(tt.url); got != tt.expected {
+			t.Errorf("%+v.Matches(%q): expected %v, got %v", tt.rule, tt.url, tt.expected, got)
+		}
+	}
+}
+
+func TestSuggester_Suggest(t *testing.T) {
+	existing := []bookmark.Bookmark{
+		bookmarktest.New(t, "https://www.github.com/a", "code", "go"),
+		bookmarktest.New(t, "https://github.com/b", "code"),
+		bookmarktest.New(t, "https://example.com/c", "machine-learning", "tool"),
+		bookmarktest.New(t, "https://example.com/d", "cooking"),
+	}
+	suggester := tagsuggest.NewSuggester([]tagsuggest.Rule{
+		{Domain: "github.com", Tags: []string{"oss"}},
+	})
+
+	got := suggester.Suggest(tagsuggest.Page{
+		URL:         "https://github.com/new",
+		Title:       "Machine Learning tools",
+		Description: "Nothing about food",
+	}, existing)
+
+	// oss: rule (3), code: both GitHub bookmarks (2), go: half of them (1),
+	// and machine-learning and tool from the title (1 each).
+	expected := []string{"oss", "code", "go", "machine-learning", "tool"}
+	if fmt.Sprint(got) != fmt.Sprint(expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestSuggester_Limit(t *testing.T) {
+	existing := []bookmark.Bookmark{bookmarktest.New(t, "https://example.com/a", "a", "b", "c")}
+
+	got := tagsuggest.NewSuggester(nil, tagsuggest.WithLimit(2)).Suggest(tagsuggest.Page{URL: "https://example.com/new"}, existing)
+	if fmt.Sprint(got) != "[a b]" {
+		t.Errorf("expected [a b], got %v", got)
+	}
+}
+
+func TestSuggester_ExcludedTagsDoNotCountAgainstTheLimit(t *testing.T) {
+	existing := []bookmark.Bookmark{bookmarktest.New(t, "https://example.com/a", "a", "b", "c")}
+
+	got := tagsuggest.NewSuggester(nil, tagsuggest.WithLimit(2)).Suggest(tagsuggest.Page{URL: "https://example.com/new"}, existing, "a")
+	if fmt.Sprint(got) != "[b c]" {
+		t.Errorf("expected [b c], got %v", got)
+	}
+}
+
+func TestSuggester_NothingToSuggest(t *testing.T) {
+	got := tagsuggest.NewSuggester(nil).Suggest(tagsuggest.Page{URL: "https://example.com/new", Title: "Hello"}, nil)
+	if len(got) != 0 {
+		t.Errorf("expected no suggestions, got %v", got)
+	}
+}
@@ -0,0 +1,138 @@
+// Package urlclean removes tracking parameters from URLs.
+package urlclean
+
+import (
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+)
+
+// Rule removes the query parameters matching Remove from URLs whose host
+// matches Domain, except those matching Keep. All of them are globs, and an
+// empty Domain matches every host.
+type Rule struct {
+	Domain string
+	Remove []string
+	Keep   []string
+}
+
+func (r Rule) matchesHost(host string) bool {
+	if r.Domain == "" {
+		return true
+	}
+	ok, err := path.Match(strings.ToLower(r.Domain), host)
+	return err == nil && ok
+}
+
+// BuiltinRules returns the rules for common tracking parameters of analytics,
+// ad and newsletter tools and of a few large sites.
+func BuiltinRules() []Rule {
+	return []Rule{
+		{Remove: []string{
+			"utm_*", "fbclid", "gclid", "gclsrc", "dclid", "gbraid", "wbraid", "msclkid", "yclid", "twclid",
+			"mc_cid", "mc_eid", "_hsenc", "_hsmi", "__hssc", "__hstc", "__hsfp", "hsctatracking",
+			"mkt_tok", "igshid", "oly_anon_id", "oly_enc_id", "vero_conv", "vero_id", "ck_subscriber_id", "_ga", "_gl",
+		}},
+		{Domain: "youtube.com", Remove: []string{"si", "pp"}},
+		{Domain: "*.youtube.com", Remove: []string{"si", "pp"}},
+		{Domain: "youtu.be", Remove: []string{"si"}},
+		{Domain: "open.spotify.com", Remove: []string{"si"}},
+		{Domain: "x.com", Remove: []string{"s", "t", "ref_src", "ref_url"}},
+		{Domain: "twitter.com", Remove: []string{"s", "t", "ref_src", "ref_url"}},
+		{Domain: "*.instagram.com", Remove: []string{"igsh"}},
+		{Domain: "*.linkedin.com", Remove: []string{"trk", "trackingId", "refId", "lipi"}},
+		{Domain: "*.reddit.com", Remove: []string{"share_id", "rdt"}},
+		{Domain: "amazon.*", Remove: []string{"ref", "ref_", "pf_rd_*", "pd_rd_*", "content-id", "crid", "sprefix", "qid", "sr"}},
+		{Domain: "*.amazon.*", Remove: []string{"ref", "ref_", "pf_rd_*", "pd_rd_*", "content-id", "crid", "sprefix", "qid", "sr"}},
+	}
+}
+
+type Cleaner struct {
+	rules []Rule
+}
+
+func NewCleaner(rules []Rule) *Cleaner {
+	return &Cleaner{rules: rules}
+}
+
+// Clean returns rawURL without the parameters removed by the rules. The rest
+// of the URL is kept byte for byte, so URL templates and variables survive.
+// URLs other than http and https are returned unchanged.
+func (c *Cleaner) Clean(rawURL string) string {
+	base, fragment, hasFragment := strings.Cut(rawURL, "#")
+	base, query, hasQuery := strings.Cut(base, "?")
+	if !hasQuery || query == "" {
+		return rawURL
+	}
+
+	rules := c.rulesFor(base)
+	if len(rules) == 0 {
+		return rawURL
+	}
+
+	pairs := strings.Split(query, "&")
+	kept := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		if pair == "" {
+			continue
+		}
+		if !removes(rules, paramName(pair)) {
+			kept = append(kept, pair)
+		}
+	}
+	if len(kept) == len(pairs) {
+		return rawURL
+	}
+
+	cleaned := base
+	if len(kept) > 0 {
+		cleaned += "?" + strings.Join(kept, "&")
+	}
+	if hasFragment {
+		cleaned += "#" + fragment
+	}
+	return cleaned
+}
+
+func (c *Cleaner) rulesFor(base string) []Rule {
+	parsed, err := url.Parse(base)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return nil
+	}
+	host := strings.ToLower(parsed.Hostname())
+
+	var rules []Rule
+	for _, rule := range c.rules {
+		if rule.matchesHost(host) {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+func paramName(pair string) string {
+	key, _, _ := strings.Cut(pair, "=")
+	if unescaped, err := url.QueryUnescape(key); err == nil {
+		key = unescaped
+	}
+	return strings.ToLower(key)
+}
+
+func removes(rules []Rule, name string) bool {
+	matches := func(patterns []string) bool {
+		return slices.ContainsFunc(patterns, func(pattern string) bool {
+			ok, err := path.Match(strings.ToLower(pattern), name)
+			return err == nil && ok
+		})
+	}
+
+	removed := false
+	for _, rule := range rules {
+		if matches(rule.Keep) {
+			return false
+		}
+		removed = removed || matches(rule.Remove)
+	}
+	return removed
+}
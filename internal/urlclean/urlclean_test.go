@@ -0,0 +1,65 @@
+package urlclean_test
+
+import (
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/urlclean"
+)
+
+func TestCleaner_CleanBuiltinRules(t *testing.T) {
+	cleaner := urlclean.NewCleaner(urlclean.BuiltinRules())
+
+	tests := []struct {
+		name     string
+		rawURL   string
+		expected string
+	}{
+		{"utm parameters", "https://example.com/post?id=1&utm_source=news&utm_medium=email", "https://example.com/post?id=1"},
+		{"only tracking parameters", "https://example.com/post?fbclid=abc&gclid=def", "https://example.com/post"},
+		{"keeps fragment", "https://example.com/post?utm_campaign=x#section", "https://example.com/post#section"},
+		{"case insensitive", "https://example.com/?UTM_Source=x&q=go", "https://example.com/?q=go"},
+		{"domain rule", "https://www.youtube.com/watch?v=abc&si=xyz", "https://www.youtube.com/watch?v=abc"},
+		{"domain rule on other host", "https://example.com/watch?v=abc&si=xyz", "https://example.com/watch?v=abc&si=xyz"},
+		{"nothing to remove", "https://example.com/?b=2&a=1", "https://example.com/?b=2&a=1"},
+		{"no query", "https://example.com/post", "https://example.com/post"},
+		{"keeps encoding of other parameters", "https://example.com/?q=a%20b+c&utm_source=x", "https://example.com/?q=a%20b+c"},
+		{"template", "https://example.com/search?q=%s&utm_source=x", "https://example.com/search?q=%s"},
+		{"variables", "https://example.com/${ORG}?utm_source=x", "https://example.com/${ORG}"},
+		{"other scheme", "ftp://example.com/file?utm_source=x", "ftp://example.com/file?utm_source=x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cleaner.Clean(tt.rawURL); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCleaner_CleanUserRules(t *testing.T) {
+	rules := append(urlclean.BuiltinRules(),
+		urlclean.Rule{Domain: "*.example.com", Remove: []string{"ref", "src_*"}},
+		urlclean.Rule{Domain: "shop.example.com", Keep: []string{"utm_source"}},
+	)
+	cleaner := urlclean.NewCleaner(rules)
+
+	got := cleaner.Clean("https://blog.example.com/?ref=feed&src_a=1&page=2")
+	if expected := "https://blog.example.com/?page=2"; got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+
+	got = cleaner.Clean("https://shop.example.com/?utm_source=partner&utm_medium=x")
+	if expected := "https://shop.example.com/?utm_source=partner"; got != expected {
+		t.Errorf("expected kept parameter in %q, got %q", expected, got)
+	}
+}
+
+func TestCleaner_CleanWithoutRules(t *testing.T) {
+	cleaner := urlclean.NewCleaner(nil)
+
+	rawURL := "https://example.com/?utm_source=x"
+	if got := cleaner.Clean(rawURL); got != rawURL {
+		t.Errorf("expected unchanged URL, got %q", got)
+	}
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/airRnot1106/bkm/internal/bookmark"
 )
@@ -11,20 +12,28 @@ type AddBookmarkInput struct {
 	Title       string
 	Description string
 	Tags        []string
+	Keyword     string
+	Variants    map[string]string
 }
 
 type AddBookmark struct {
-	repo bookmark.Repository
+	repo     bookmark.Repository
+	policy   *URLPolicy
+	reserved []string
 }
 
-func NewAddBookmark(repo bookmark.Repository) *AddBookmark {
-	return &AddBookmark{repo: repo}
+// NewAddBookmark refuses the keywords in reserved, such as the names of
+// commands that would always be run instead of opening the bookmark.
+func NewAddBookmark(repo bookmark.Repository, policy *URLPolicy, reserved ...string) *AddBookmark {
+	return &AddBookmark{repo: repo, policy: policy, reserved: reserved}
 }
 
+// Execute stores a new bookmark. The URL and the variant URLs go through the
+// URL policy.
 func (uc *AddBookmark) Execute(input AddBookmarkInput) (bookmark.Bookmark, error) {
-	url, err := bookmark.NewBookmarkURL(input.URL)
+	url, err := uc.policy.Apply(input.URL)
 	if err != nil {
-		return bookmark.Bookmark{}, fmt.Errorf("invalid URL: %w", err)
+		return bookmark.Bookmark{}, err
 	}
 
 	title, err := bookmark.NewBookmarkTitle(input.Title)
@@ -43,7 +52,25 @@ func (uc *AddBookmark) Execute(input AddBookmarkInput) (bookmark.Bookmark, error
 		tags = append(tags, tag)
 	}
 
+	var keyword bookmark.BookmarkKeyword
+	if input.Keyword != "" {
+		keyword, err = bookmark.NewBookmarkKeyword(input.Keyword)
+		if err != nil {
+			return bookmark.Bookmark{}, fmt.Errorf("invalid keyword: %w", err)
+		}
+		if err := checkKeywordAvailable(uc.repo, keyword, bookmark.BookmarkID{}, uc.reserved); err != nil {
+			return bookmark.Bookmark{}, err
+		}
+	}
+
+	variants, err := uc.policy.applyVariants(input.Variants, nil)
+	if err != nil {
+		return bookmark.Bookmark{}, err
+	}
+
 	bm := bookmark.CreateBookmark(url, title, desc, tags)
+	bm.Keyword = keyword
+	bm.Variants = variants
 
 	if err := uc.repo.Add(bm); err != nil {
 		return bookmark.Bookmark{}, fmt.Errorf("failed to add bookmark: %w", err)
@@ -51,3 +78,24 @@ func (uc *AddBookmark) Execute(input AddBookmarkInput) (bookmark.Bookmark, error
 
 	return bm, nil
 }
+
+// checkKeywordAvailable fails when keyword is reserved or used by a bookmark
+// other than self.
+func checkKeywordAvailable(repo bookmark.Repository, keyword bookmark.BookmarkKeyword, self bookmark.BookmarkID, reserved []string) error {
+	if slices.Contains(reserved, keyword.Value()) {
+		return fmt.Errorf("%w: %s is the name of a command", bookmark.ErrReservedKeyword, keyword.Value())
+	}
+
+	bookmarks, err := repo.List()
+	if err != nil {
+		return fmt.Errorf("failed to list bookmarks: %w", err)
+	}
+
+	for _, bm := range bookmarks {
+		if bm.Keyword == keyword && bm.ID != self {
+			return fmt.Errorf("%w: %s", bookmark.ErrDuplicateKeyword, keyword.Value())
+		}
+	}
+
+	return nil
+}
@@ -1,10 +1,13 @@
 package usecase_test
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
 	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/secretscan"
+	"github.com/airRnot1106/bkm/internal/urlclean"
 	"github.com/airRnot1106/bkm/internal/usecase"
 )
 
@@ -29,13 +32,25 @@ func (m *mockRepositoryForAdd) List() ([]bookmark.Bookmark, error) {
 	return m.bookmarks, nil
 }
 
+func (m *mockRepositoryForAdd) Update(bm bookmark.Bookmark) error {
+	return fmt.Errorf("not implemented")
+}
+
 func (m *mockRepositoryForAdd) Delete(id bookmark.BookmarkID) error {
 	return fmt.Errorf("not implemented")
 }
 
+func (m *mockRepositoryForAdd) UpdateAll(bms []bookmark.Bookmark) error {
+	return fmt.Errorf("not implemented")
+}
+
+func newAddBookmark(repo bookmark.Repository) *usecase.AddBookmark {
+	return usecase.NewAddBookmark(repo, newURLPolicy(nil, secretscan.PolicyRefuse))
+}
+
 func TestAddBookmark_ValidParamsAlwaysSucceed(t *testing.T) {
 	repo := &mockRepositoryForAdd{}
-	uc := usecase.NewAddBookmark(repo)
+	uc := newAddBookmark(repo)
 
 	input := usecase.AddBookmarkInput{
 		URL:         "https://example.com",
@@ -74,7 +89,7 @@ func TestAddBookmark_ValidParamsAlwaysSucceed(t *testing.T) {
 
 func TestAddBookmark_InvalidURLAlwaysFails(t *testing.T) {
 	repo := &mockRepositoryForAdd{}
-	uc := usecase.NewAddBookmark(repo)
+	uc := newAddBookmark(repo)
 
 	input := usecase.AddBookmarkInput{
 		URL:         "invalid-url",
@@ -91,7 +106,7 @@ func TestAddBookmark_InvalidURLAlwaysFails(t *testing.T) {
 
 func TestAddBookmark_InvalidTitleAlwaysFails(t *testing.T) {
 	repo := &mockRepositoryForAdd{}
-	uc := usecase.NewAddBookmark(repo)
+	uc := newAddBookmark(repo)
 
 	input := usecase.AddBookmarkInput{
 		URL:         "https://example.com",
@@ -108,7 +123,7 @@ func TestAddBookmark_InvalidTitleAlwaysFails(t *testing.T) {
 
 func TestAddBookmark_InvalidTagAlwaysFails(t *testing.T) {
 	repo := &mockRepositoryForAdd{}
-	uc := usecase.NewAddBookmark(repo)
+	uc := newAddBookmark(repo)
 
 	input := usecase.AddBookmarkInput{
 		URL:         "https://example.com",
@@ -125,7 +140,7 @@ func TestAddBookmark_InvalidTagAlwaysFails(t *testing.T) {
 
 func TestAddBookmark_EmptyTagsSucceed(t *testing.T) {
 	repo := &mockRepositoryForAdd{}
-	uc := usecase.NewAddBookmark(repo)
+	uc := newAddBookmark(repo)
 
 	input := usecase.AddBookmarkInput{
 		URL:         "https://example.com",
@@ -168,7 +183,7 @@ func TestAddBookmark_RepositoryAddFails(t *testing.T) {
 			return fmt.Errorf("repository add error")
 		},
 	}
-	uc := usecase.NewAddBookmark(repo)
+	uc := newAddBookmark(repo)
 
 	input := usecase.AddBookmarkInput{
 		URL:         "https://example.com",
@@ -182,3 +197,200 @@ func TestAddBookmark_RepositoryAddFails(t *testing.T) {
 		t.Fatalf("expected error from repository add, got success")
 	}
 }
+
+func TestAddBookmark_KeywordIsStored(t *testing.T) {
+	repo := &mockRepositoryForAdd{}
+	uc := newAddBookmark(repo)
+
+	bm, err := uc.Execute(usecase.AddBookmarkInput{
+		URL:     "https://github.com",
+		Title:   "GitHub",
+		Keyword: "gh",
+	})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if bm.Keyword.Value() != "gh" {
+		t.Errorf("expected keyword %q, got %q", "gh", bm.Keyword.Value())
+	}
+}
+
+func TestAddBookmark_DuplicateKeywordFails(t *testing.T) {
+	repo := &mockRepositoryForAdd{}
+	uc := newAddBookmark(repo)
+
+	input := usecase.AddBookmarkInput{
+		URL:     "https://github.com",
+		Title:   "GitHub",
+		Keyword: "gh",
+	}
+	if _, err := uc.Execute(input); err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+
+	input.URL = "https://gitlab.com"
+	_, err := uc.Execute(input)
+	if !errors.Is(err, bookmark.ErrDuplicateKeyword) {
+		t.Fatalf("expected ErrDuplicateKeyword, got %v", err)
+	}
+	if len(repo.bookmarks) != 1 {
+		t.Errorf("expected 1 bookmark, got %d", len(repo.bookmarks))
+	}
+}
+
+func TestAddBookmark_ReservedKeywordFails(t *testing.T) {
+	repo := &mockRepositoryForAdd{}
+	uc := usecase.NewAddBookmark(repo, newURLPolicy(nil, secretscan.PolicyRefuse), "add", "ls")
+
+	_, err := uc.Execute(usecase.AddBookmarkInput{
+		URL:     "https://github.com",
+		Title:   "GitHub",
+		Keyword: "ls",
+	})
+	if !errors.Is(err, bookmark.ErrReservedKeyword) {
+		t.Fatalf("expected ErrReservedKeyword, got %v", err)
+	}
+	if len(repo.bookmarks) != 0 {
+		t.Errorf("expected no bookmark, got %d", len(repo.bookmarks))
+	}
+}
+
+func TestAddBookmark_InvalidKeywordFails(t *testing.T) {
+	repo := &mockRepositoryForAdd{}
+	uc := newAddBookmark(repo)
+
+	_, err := uc.Execute(usecase.AddBookmarkInput{
+		URL:     "https://github.com",
+		Title:   "GitHub",
+		Keyword: "git hub",
+	})
+	if err == nil {
+		t.Fatalf("expected error for invalid keyword, got success")
+	}
+}
+
+func TestAddBookmark_VariantsAreStored(t *testing.T) {
+	repo := &mockRepositoryForAdd{}
+	uc := newAddBookmark(repo)
+
+	bm, err := uc.Execute(usecase.AddBookmarkInput{
+		URL:   "https://grafana.dev.example.com",
+		Title: "Grafana",
+		Variants: map[string]string{
+			"staging": "https://grafana.staging.example.com",
+			"prod":    "https://grafana.example.com",
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	names := bm.VariantNames()
+	if len(names) != 2 || names[0].Value() != "prod" || names[1].Value() != "staging" {
+		t.Errorf("unexpected variant names: %v", names)
+	}
+}
+
+func TestAddBookmark_InvalidVariantURLFails(t *testing.T) {
+	repo := &mockRepositoryForAdd{}
+	uc := newAddBookmark(repo)
+
+	_, err := uc.Execute(usecase.AddBookmarkInput{
+		URL:      "https://grafana.dev.example.com",
+		Title:    "Grafana",
+		Variants: map[string]string{"prod": "not a url"},
+	})
+	if err == nil {
+		t.Fatalf("expected error for invalid variant URL, got success")
+	}
+}
+
+func TestAddBookmark_RemovesTrackingParameters(t *testing.T) {
+	repo := &mockRepositoryForAdd{}
+	uc := usecase.NewAddBookmark(repo, newURLPolicy(urlclean.BuiltinRules(), secretscan.PolicyRefuse))
+
+	bm, err := uc.Execute(usecase.AddBookmarkInput{
+		URL:   "https://example.com/post?id=1&utm_source=newsletter&fbclid=abc",
+		Title: "Post",
+	})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if expected := "https://example.com/post?id=1"; bm.URL.Value() != expected {
+		t.Errorf("expected URL %s, got %s", expected, bm.URL.Value())
+	}
+}
+
+func TestAddBookmark_AppliesURLPolicyToVariants(t *testing.T) {
+	repo := &mockRepositoryForAdd{}
+	uc := usecase.NewAddBookmark(repo, newURLPolicy(urlclean.BuiltinRules(), secretscan.PolicyRedact))
+
+	bm, err := uc.Execute(usecase.AddBookmarkInput{
+		URL:      "https://example.com/feed",
+		Title:    "Feed",
+		Variants: map[string]string{"private": "https://example.com/feed?utm_source=mail&token=abc"},
+	})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	name, _ := bookmark.NewBookmarkVariantName("private")
+	if url, expected := bm.Variants[name], "https://example.com/feed?token=REDACTED"; url.Value() != expected {
+		t.Errorf("expected variant URL %s, got %s", expected, url.Value())
+	}
+}
+
+// mockCleanerForAdd replaces URLs with the configured cleaned URLs.
+type mockCleanerForAdd struct {
+	cleaned map[string]string
+}
+
+func (m mockCleanerForAdd) Clean(rawURL string) string {
+	if cleaned, ok := m.cleaned[rawURL]; ok {
+		return cleaned
+	}
+	return rawURL
+}
+
+func TestAddBookmark_UsesInjectedCleaner(t *testing.T) {
+	repo := &mockRepositoryForAdd{}
+	cleaner := mockCleanerForAdd{cleaned: map[string]string{"https://example.com/?ref=a": "https://example.com/"}}
+	uc := usecase.NewAddBookmark(repo, usecase.NewURLPolicy(cleaner, secretscan.NewGuard(secretscan.NewScanner(), secretscan.PolicyRefuse), bookmark.NewSchemeAllowlist()))
+
+	bm, err := uc.Execute(usecase.AddBookmarkInput{URL: "https://example.com/?ref=a", Title: "Example"})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if bm.URL.Value() != "https://example.com/" {
+		t.Errorf("expected the cleaned URL, got %s", bm.URL.Value())
+	}
+}
+
+func TestAddBookmark_SecretsPolicy(t *testing.T) {
+	rawURL := "https://example.com/feed?token=abc"
+
+	refuse := usecase.NewAddBookmark(&mockRepositoryForAdd{}, newURLPolicy(nil, secretscan.PolicyRefuse))
+	if _, err := refuse.Execute(usecase.AddBookmarkInput{URL: rawURL, Title: "Feed"}); !errors.Is(err, secretscan.ErrSecretFound) {
+		t.Errorf("expected ErrSecretFound, got %v", err)
+	}
+	_, err := refuse.Execute(usecase.AddBookmarkInput{
+		URL:      "https://example.com/feed",
+		Title:    "Feed",
+		Variants: map[string]string{"private": rawURL},
+	})
+	if !errors.Is(err, secretscan.ErrSecretFound) {
+		t.Errorf("expected ErrSecretFound for a variant, got %v", err)
+	}
+
+	redact := usecase.NewAddBookmark(&mockRepositoryForAdd{}, newURLPolicy(nil, secretscan.PolicyRedact))
+	bm, err := redact.Execute(usecase.AddBookmarkInput{URL: rawURL, Title: "Feed"})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	if expected := "https://example.com/feed?token=REDACTED"; bm.URL.Value() != expected {
+		t.Errorf("expected URL %s, got %s", expected, bm.URL.Value())
+	}
+}
@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/session"
+)
+
+type AddToSessionInput struct {
+	Name string
+	// Bookmarks are appended in order. Bookmarks already in the session are skipped.
+	Bookmarks []bookmark.Bookmark
+}
+
+type AddToSession struct {
+	sessions session.Repository
+}
+
+func NewAddToSession(sessions session.Repository) *AddToSession {
+	return &AddToSession{sessions: sessions}
+}
+
+func (uc *AddToSession) Execute(input AddToSessionInput) (session.Session, error) {
+	sess, err := findSession(uc.sessions, input.Name)
+	if err != nil {
+		return session.Session{}, err
+	}
+
+	for _, bm := range input.Bookmarks {
+		if sess.Contains(bm.ID) {
+			continue
+		}
+		if err := sess.Append(bm.ID); err != nil {
+			return session.Session{}, err
+		}
+	}
+
+	sess.UpdatedAt = time.Now()
+	if err := uc.sessions.Update(sess); err != nil {
+		return session.Session{}, fmt.Errorf("failed to update session: %w", err)
+	}
+
+	return sess, nil
+}
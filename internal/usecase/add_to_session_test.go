@@ -0,0 +1,49 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/bookmark/bookmarktest"
+	"github.com/airRnot1106/bkm/internal/session"
+	"github.com/airRnot1106/bkm/internal/session/sessiontest"
+	"github.com/airRnot1106/bkm/internal/usecase"
+)
+
+func TestAddToSession_AppendsInOrderAndSkipsDuplicates(t *testing.T) {
+	repo := &mockSessionRepository{}
+	first, second, third := bookmarktest.New(t, "https://status.example.com/1"), bookmarktest.New(t, "https://status.example.com/2"), bookmarktest.New(t, "https://status.example.com/3")
+	repo.Add(sessiontest.New(t, "incident-response", first))
+	uc := usecase.NewAddToSession(repo)
+
+	sess, err := uc.Execute(usecase.AddToSessionInput{
+		Name:      "incident-response",
+		Bookmarks: []bookmark.Bookmark{third, first, second},
+	})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	expected := []bookmark.BookmarkID{first.ID, third.ID, second.ID}
+	if len(sess.BookmarkIDs) != len(expected) {
+		t.Fatalf("expected %d bookmarks, got %d", len(expected), len(sess.BookmarkIDs))
+	}
+	for i, id := range expected {
+		if sess.BookmarkIDs[i] != id {
+			t.Errorf("expected %s at position %d, got %s", id.Value(), i, sess.BookmarkIDs[i].Value())
+		}
+	}
+	if len(repo.sessions[0].BookmarkIDs) != len(expected) {
+		t.Error("expected session to be saved")
+	}
+}
+
+func TestAddToSession_UnknownSessionFails(t *testing.T) {
+	uc := usecase.NewAddToSession(&mockSessionRepository{})
+
+	_, err := uc.Execute(usecase.AddToSessionInput{Name: "missing"})
+	if !errors.Is(err, session.ErrSessionNotFound) {
+		t.Fatalf("expected ErrSessionNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/snapshot"
+)
+
+type ArchiveBookmarkInput struct {
+	Bookmark bookmark.Bookmark
+}
+
+type ArchiveBookmark struct {
+	repo     bookmark.Repository
+	capturer snapshot.Capturer
+	store    snapshot.Store
+}
+
+func NewArchiveBookmark(repo bookmark.Repository, capturer snapshot.Capturer, store snapshot.Store) *ArchiveBookmark {
+	return &ArchiveBookmark{repo: repo, capturer: capturer, store: store}
+}
+
+// Execute saves an offline copy of the bookmarked page and links it to the
+// bookmark, replacing any earlier copy.
+func (uc *ArchiveBookmark) Execute(input ArchiveBookmarkInput) (bookmark.Bookmark, error) {
+	bm := input.Bookmark
+	if !isCheckable(bm.URL) {
+		return bookmark.Bookmark{}, snapshot.ErrUnsupportedURL
+	}
+
+	content, err := uc.capturer.Capture(bm.URL.Value())
+	if err != nil {
+		return bookmark.Bookmark{}, fmt.Errorf("failed to capture page: %w", err)
+	}
+
+	digest, err := uc.store.Put(content)
+	if err != nil {
+		return bookmark.Bookmark{}, fmt.Errorf("failed to store snapshot: %w", err)
+	}
+
+	bm.Snapshot = bookmark.Snapshot{Digest: digest, ArchivedAt: time.Now()}
+	if err := uc.repo.Update(bm); err != nil {
+		return bookmark.Bookmark{}, fmt.Errorf("failed to update bookmark: %w", err)
+	}
+
+	return bm, nil
+}
@@ -0,0 +1,125 @@
+package usecase_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/bookmark/bookmarktest"
+	"github.com/airRnot1106/bkm/internal/snapshot"
+	"github.com/airRnot1106/bkm/internal/usecase"
+)
+
+type mockRepositoryForArchive struct {
+	updated []bookmark.Bookmark
+}
+
+func (m *mockRepositoryForArchive) Add(bm bookmark.Bookmark) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (m *mockRepositoryForArchive) List() ([]bookmark.Bookmark, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *mockRepositoryForArchive) Update(bm bookmark.Bookmark) error {
+	m.updated = append(m.updated, bm)
+	return nil
+}
+
+func (m *mockRepositoryForArchive) UpdateAll(bms []bookmark.Bookmark) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (m *mockRepositoryForArchive) Delete(id bookmark.BookmarkID) error {
+	return fmt.Errorf("not implemented")
+}
+
+type mockCapturerForArchive struct {
+	content []byte
+	err     error
+}
+
+func (m *mockCapturerForArchive) Capture(url string) ([]byte, error) {
+	return m.content, m.err
+}
+
+// mockSnapshotStore keeps snapshots in memory and reports paths under /snapshots.
+type mockSnapshotStore struct {
+	contents map[string][]byte
+}
+
+func (m *mockSnapshotStore) Put(content []byte) (string, error) {
+	if m.contents == nil {
+		m.contents = make(map[string][]byte)
+	}
+	digest := snapshot.Digest(content)
+	m.contents[digest] = content
+	return digest, nil
+}
+
+func (m *mockSnapshotStore) Get(digest string) ([]byte, error) {
+	content, ok := m.contents[digest]
+	if !ok {
+		return nil, snapshot.ErrNotFound
+	}
+	return content, nil
+}
+
+func (m *mockSnapshotStore) Path(digest string) (string, error) {
+	if _, ok := m.contents[digest]; !ok {
+		return "", snapshot.ErrNotFound
+	}
+	return "/snapshots/" + digest + ".html", nil
+}
+
+func TestArchiveBookmark_StoresSnapshot(t *testing.T) {
+	repo := &mockRepositoryForArchive{}
+	store := &mockSnapshotStore{}
+	capturer := &mockCapturerForArchive{content: []byte("<title>Example</title>")}
+	uc := usecase.NewArchiveBookmark(repo, capturer, store)
+	bm := bookmarktest.New(t, "https://example.com/")
+
+	archived, err := uc.Execute(usecase.ArchiveBookmarkInput{Bookmark: bm})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	digest := snapshot.Digest(capturer.content)
+	if archived.Snapshot.Digest != digest || archived.Snapshot.ArchivedAt.IsZero() {
+		t.Errorf("expected the snapshot to be linked, got %+v", archived.Snapshot)
+	}
+	if string(store.contents[digest]) != string(capturer.content) {
+		t.Error("expected the captured page to be stored")
+	}
+	if len(repo.updated) != 1 || repo.updated[0].Snapshot.Digest != digest {
+		t.Errorf("expected the bookmark to be saved with its snapshot, got %+v", repo.updated)
+	}
+}
+
+func TestArchiveBookmark_Failures(t *testing.T) {
+	t.Run("unsupported URL", func(t *testing.T) {
+		repo := &mockRepositoryForArchive{}
+		uc := usecase.NewArchiveBookmark(repo, &mockCapturerForArchive{}, &mockSnapshotStore{})
+
+		_, err := uc.Execute(usecase.ArchiveBookmarkInput{Bookmark: bookmarktest.New(t, "https://example.com/search?q=%s")})
+		if !errors.Is(err, snapshot.ErrUnsupportedURL) {
+			t.Errorf("expected ErrUnsupportedURL, got %v", err)
+		}
+	})
+
+	t.Run("capture fails", func(t *testing.T) {
+		repo := &mockRepositoryForArchive{}
+		capturer := &mockCapturerForArchive{err: snapshot.ErrNotHTML}
+		uc := usecase.NewArchiveBookmark(repo, capturer, &mockSnapshotStore{})
+
+		_, err := uc.Execute(usecase.ArchiveBookmarkInput{Bookmark: bookmarktest.New(t, "https://example.com/file.pdf")})
+		if !errors.Is(err, snapshot.ErrNotHTML) {
+			t.Errorf("expected ErrNotHTML, got %v", err)
+		}
+		if len(repo.updated) != 0 {
+			t.Error("expected the bookmark not to be updated")
+		}
+	})
+}
@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/linkcheck"
+)
+
+// DeadTag is the tag given to dead links when CheckBookmarksInput.TagDead is set.
+const DeadTag = "dead"
+
+type CheckBookmarksInput struct {
+	// Tags limit the check to bookmarks that have all of them.
+	Tags []string
+	// TagDead tags dead links with DeadTag and removes the tag from links
+	// that respond again. Unreachable links keep their tags, so that a flaky
+	// network does not tag the whole library.
+	TagDead bool
+}
+
+type BookmarkCheck struct {
+	Bookmark bookmark.Bookmark
+	Result   linkcheck.Result
+}
+
+type CheckBookmarksOutput struct {
+	Checks []BookmarkCheck
+	// Skipped is the number of bookmarks that cannot be checked, such as URL
+	// templates and non-HTTP URLs.
+	Skipped int
+}
+
+type CheckBookmarks struct {
+	repo    bookmark.Repository
+	checker linkcheck.Checker
+}
+
+func NewCheckBookmarks(repo bookmark.Repository, checker linkcheck.Checker) *CheckBookmarks {
+	return &CheckBookmarks{repo: repo, checker: checker}
+}
+
+// Execute checks the URL of every bookmark and records the outcome on it.
+func (uc *CheckBookmarks) Execute(input CheckBookmarksInput) (CheckBookmarksOutput, error) {
+	deadTag, err := bookmark.NewBookmarkTag(DeadTag)
+	if err != nil {
+		return CheckBookmarksOutput{}, fmt.Errorf("invalid dead tag: %w", err)
+	}
+
+	listUc := NewListBookmarks(uc.repo)
+	bookmarks, err := listUc.Execute(ListBookmarksInput{Tags: input.Tags})
+	if err != nil {
+		return CheckBookmarksOutput{}, err
+	}
+
+	var output CheckBookmarksOutput
+	var targets []bookmark.Bookmark
+	var urls []string
+	for _, bm := range bookmarks {
+		if !isCheckable(bm.URL) {
+			output.Skipped++
+			continue
+		}
+		targets = append(targets, bm)
+		urls = append(urls, bm.URL.Value())
+	}
+	if len(targets) == 0 {
+		return output, nil
+	}
+
+	results := uc.checker.Check(urls)
+
+	// Checking takes a while, so the results are merged into the bookmarks as
+	// they are now to keep changes made in the meantime. Bookmarks that were
+	// deleted or moved to another URL are left alone.
+	byID, err := bookmarksByID(uc.repo)
+	if err != nil {
+		return CheckBookmarksOutput{}, err
+	}
+
+	checkedAt := time.Now()
+	var updated []bookmark.Bookmark
+	for i, target := range targets {
+		bm, ok := byID[target.ID]
+		if !ok || bm.URL != target.URL {
+			continue
+		}
+		result := results[i]
+		bm.LastCheck = bookmark.LinkCheck{StatusCode: result.StatusCode, CheckedAt: checkedAt}
+		if input.TagDead && !result.IsUnreachable() && setTag(&bm, deadTag, result.IsDead()) {
+			bm.UpdatedAt = checkedAt
+		}
+		updated = append(updated, bm)
+		output.Checks = append(output.Checks, BookmarkCheck{Bookmark: bm, Result: result})
+	}
+
+	if err := uc.repo.UpdateAll(updated); err != nil {
+		return CheckBookmarksOutput{}, fmt.Errorf("failed to record check results: %w", err)
+	}
+
+	return output, nil
+}
+
+func bookmarksByID(repo bookmark.Repository) (map[bookmark.BookmarkID]bookmark.Bookmark, error) {
+	bookmarks, err := repo.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bookmarks: %w", err)
+	}
+	byID := make(map[bookmark.BookmarkID]bookmark.Bookmark, len(bookmarks))
+	for _, bm := range bookmarks {
+		byID[bm.ID] = bm
+	}
+	return byID, nil
+}
+
+// isCheckable reports whether the URL points at a single web page.
+func isCheckable(url bookmark.BookmarkURL) bool {
+	scheme := url.Scheme()
+	return (scheme == "http" || scheme == "https") && !url.IsTemplate() && len(url.Variables()) == 0
+}
+
+// setTag adds or removes tag and reports whether the tags changed.
+func setTag(bm *bookmark.Bookmark, tag bookmark.BookmarkTag, present bool) bool {
+	idx := slices.Index(bm.Tags, tag)
+	switch {
+	case present && idx < 0:
+		bm.Tags = append(slices.Clip(bm.Tags), tag)
+		return true
+	case !present && idx >= 0:
+		bm.Tags = slices.Delete(slices.Clone(bm.Tags), idx, idx+1)
+		return true
+	}
+	return false
+}
@@ -0,0 +1,197 @@
+package usecase_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/bookmark/bookmarktest"
+	"github.com/airRnot1106/bkm/internal/linkcheck"
+	"github.com/airRnot1106/bkm/internal/usecase"
+)
+
+type mockRepositoryForCheck struct {
+	bookmarks []bookmark.Bookmark
+	updated   []bookmark.Bookmark
+}
+
+func (m *mockRepositoryForCheck) Add(bm bookmark.Bookmark) error {
+	m.bookmarks = append(m.bookmarks, bm)
+	return nil
+}
+
+func (m *mockRepositoryForCheck) AddAll(bms []bookmark.Bookmark) error {
+	m.bookmarks = append(m.bookmarks, bms...)
+	return nil
+}
+
+func (m *mockRepositoryForCheck) List() ([]bookmark.Bookmark, error) {
+	return m.bookmarks, nil
+}
+
+func (m *mockRepositoryForCheck) Update(bm bookmark.Bookmark) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (m *mockRepositoryForCheck) UpdateAll(bms []bookmark.Bookmark) error {
+	m.updated = bms
+	return nil
+}
+
+func (m *mockRepositoryForCheck) Delete(id bookmark.BookmarkID) error {
+	return fmt.Errorf("not implemented")
+}
+
+// mockCheckerForCheck answers with the status configured for each URL.
+type mockCheckerForCheck struct {
+	statuses map[string]int
+	checked  []string
+	// during runs while the URLs are being checked.
+	during func()
+}
+
+func (m *mockCheckerForCheck) Check(urls []string) []linkcheck.Result {
+	m.checked = urls
+	if m.during != nil {
+		m.during()
+	}
+	results := make([]linkcheck.Result, len(urls))
+	for i, url := range urls {
+		results[i] = linkcheck.Result{URL: url, StatusCode: m.statuses[url]}
+	}
+	return results
+}
+
+func hasTag(bm bookmark.Bookmark, name string) bool {
+	return slices.ContainsFunc(bm.Tags, func(tag bookmark.BookmarkTag) bool { return tag.Value() == name })
+}
+
+func TestCheckBookmarks_RecordsResults(t *testing.T) {
+	repo := &mockRepositoryForCheck{}
+	repo.Add(bookmarktest.New(t, "https://example.com/alive"))
+	repo.Add(bookmarktest.New(t, "https://example.com/gone"))
+	checker := &mockCheckerForCheck{statuses: map[string]int{"https://example.com/alive": 200, "https://example.com/gone": 404}}
+	uc := usecase.NewCheckBookmarks(repo, checker)
+
+	output, err := uc.Execute(usecase.CheckBookmarksInput{})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if len(output.Checks) != 2 || output.Skipped != 0 {
+		t.Fatalf("expected 2 checks and none skipped, got %d and %d", len(output.Checks), output.Skipped)
+	}
+	if len(repo.updated) != 2 {
+		t.Fatalf("expected 2 updated bookmarks, got %d", len(repo.updated))
+	}
+	for i, expected := range []int{200, 404} {
+		check := repo.updated[i].LastCheck
+		if check.StatusCode != expected || check.CheckedAt.IsZero() {
+			t.Errorf("bookmark %d: expected status %d with a check time, got %+v", i, expected, check)
+		}
+		if hasTag(repo.updated[i], usecase.DeadTag) {
+			t.Errorf("bookmark %d: expected no dead tag without TagDead", i)
+		}
+	}
+}
+
+func TestCheckBookmarks_SkipsUncheckableURLs(t *testing.T) {
+	repo := &mockRepositoryForCheck{}
+	repo.Add(bookmarktest.New(t, "https://example.com/search?q=%s"))
+	repo.Add(bookmarktest.New(t, "https://${HOST}/status"))
+	repo.Add(bookmarktest.New(t, "mailto:someone@example.com"))
+	repo.Add(bookmarktest.New(t, "https://example.com/"))
+	checker := &mockCheckerForCheck{statuses: map[string]int{"https://example.com/": 200}}
+	uc := usecase.NewCheckBookmarks(repo, checker)
+
+	output, err := uc.Execute(usecase.CheckBookmarksInput{})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if output.Skipped != 3 {
+		t.Errorf("expected 3 skipped bookmarks, got %d", output.Skipped)
+	}
+	if len(checker.checked) != 1 || checker.checked[0] != "https://example.com/" {
+		t.Errorf("expected only the plain URL to be checked, got %v", checker.checked)
+	}
+}
+
+func TestCheckBookmarks_TagsDeadLinks(t *testing.T) {
+	repo := &mockRepositoryForCheck{}
+	repo.Add(bookmarktest.New(t, "https://example.com/gone", "docs"))
+	repo.Add(bookmarktest.New(t, "https://example.com/back", "docs", usecase.DeadTag))
+	repo.Add(bookmarktest.New(t, "https://example.com/down"))
+	repo.Add(bookmarktest.New(t, "https://example.com/still-down", usecase.DeadTag))
+	checker := &mockCheckerForCheck{statuses: map[string]int{"https://example.com/gone": 410, "https://example.com/back": 200}}
+	uc := usecase.NewCheckBookmarks(repo, checker)
+
+	if _, err := uc.Execute(usecase.CheckBookmarksInput{TagDead: true}); err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	gone, back, down, stillDown := repo.updated[0], repo.updated[1], repo.updated[2], repo.updated[3]
+	if !hasTag(gone, usecase.DeadTag) || !hasTag(gone, "docs") {
+		t.Errorf("expected the gone link to keep its tags and be tagged dead, got %v", gone.Tags)
+	}
+	if hasTag(back, usecase.DeadTag) || !hasTag(back, "docs") {
+		t.Errorf("expected the dead tag to be removed from a link that responds again, got %v", back.Tags)
+	}
+	if hasTag(down, usecase.DeadTag) || !hasTag(stillDown, usecase.DeadTag) {
+		t.Errorf("expected unreachable links to keep their tags, got %v and %v", down.Tags, stillDown.Tags)
+	}
+	if !hasTag(repo.bookmarks[1], usecase.DeadTag) {
+		t.Error("expected the listed bookmark not to be modified in place")
+	}
+}
+
+func TestCheckBookmarks_FiltersByTags(t *testing.T) {
+	repo := &mockRepositoryForCheck{}
+	repo.Add(bookmarktest.New(t, "https://example.com/docs", "docs"))
+	repo.Add(bookmarktest.New(t, "https://example.com/blog", "blog"))
+	checker := &mockCheckerForCheck{statuses: map[string]int{}}
+	uc := usecase.NewCheckBookmarks(repo, checker)
+
+	if _, err := uc.Execute(usecase.CheckBookmarksInput{Tags: []string{"docs"}}); err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if len(checker.checked) != 1 || checker.checked[0] != "https://example.com/docs" {
+		t.Errorf("expected only the docs bookmark to be checked, got %v", checker.checked)
+	}
+}
+
+func TestCheckBookmarks_KeepsChangesMadeDuringTheCheck(t *testing.T) {
+	edited := bookmarktest.New(t, "https://example.com/edited")
+	deleted := bookmarktest.New(t, "https://example.com/deleted")
+	repo := &mockRepositoryForCheck{bookmarks: []bookmark.Bookmark{edited, deleted}}
+	title, err := bookmark.NewBookmarkTitle("Edited while checking")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checker := &mockCheckerForCheck{
+		statuses: map[string]int{"https://example.com/edited": 404, "https://example.com/deleted": 404},
+		during: func() {
+			bm := edited
+			bm.Title = title
+			repo.bookmarks = []bookmark.Bookmark{bm}
+		},
+	}
+	uc := usecase.NewCheckBookmarks(repo, checker)
+
+	if _, err := uc.Execute(usecase.CheckBookmarksInput{TagDead: true}); err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if len(repo.updated) != 1 {
+		t.Fatalf("expected only the remaining bookmark to be updated, got %d", len(repo.updated))
+	}
+	got := repo.updated[0]
+	if got.Title != title {
+		t.Errorf("expected the title edited during the check to be kept, got %q", got.Title.Value())
+	}
+	if got.LastCheck.StatusCode != 404 || !hasTag(got, usecase.DeadTag) {
+		t.Errorf("expected the check result to be recorded, got %+v", got)
+	}
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/airRnot1106/bkm/internal/session"
+)
+
+type CreateSessionInput struct {
+	Name string
+}
+
+type CreateSession struct {
+	sessions session.Repository
+}
+
+func NewCreateSession(sessions session.Repository) *CreateSession {
+	return &CreateSession{sessions: sessions}
+}
+
+func (uc *CreateSession) Execute(input CreateSessionInput) (session.Session, error) {
+	name, err := session.NewSessionName(input.Name)
+	if err != nil {
+		return session.Session{}, fmt.Errorf("invalid name: %w", err)
+	}
+
+	sess := session.CreateSession(name)
+	if err := uc.sessions.Add(sess); err != nil {
+		return session.Session{}, fmt.Errorf("failed to add session: %w", err)
+	}
+
+	return sess, nil
+}
+
+// findSession returns the session with the given name.
+func findSession(sessions session.Repository, rawName string) (session.Session, error) {
+	name, err := session.NewSessionName(rawName)
+	if err != nil {
+		return session.Session{}, fmt.Errorf("invalid name: %w", err)
+	}
+
+	all, err := sessions.List()
+	if err != nil {
+		return session.Session{}, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	idx := slices.IndexFunc(all, func(s session.Session) bool {
+		return s.Name == name
+	})
+	if idx < 0 {
+		return session.Session{}, fmt.Errorf("%w: %s", session.ErrSessionNotFound, name.Value())
+	}
+
+	return all[idx], nil
+}
@@ -0,0 +1,87 @@
+package usecase_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/session"
+	"github.com/airRnot1106/bkm/internal/session/sessiontest"
+	"github.com/airRnot1106/bkm/internal/usecase"
+)
+
+type mockSessionRepository struct {
+	sessions []session.Session
+}
+
+func (m *mockSessionRepository) Add(sess session.Session) error {
+	if m.indexOf(sess.Name) >= 0 {
+		return session.ErrDuplicateSession
+	}
+	m.sessions = append(m.sessions, sess)
+	return nil
+}
+
+func (m *mockSessionRepository) List() ([]session.Session, error) {
+	return slices.Clone(m.sessions), nil
+}
+
+func (m *mockSessionRepository) Update(sess session.Session) error {
+	idx := m.indexOf(sess.Name)
+	if idx < 0 {
+		return session.ErrSessionNotFound
+	}
+	m.sessions[idx] = sess
+	return nil
+}
+
+func (m *mockSessionRepository) Delete(name session.SessionName) error {
+	idx := m.indexOf(name)
+	if idx < 0 {
+		return session.ErrSessionNotFound
+	}
+	m.sessions = slices.Delete(m.sessions, idx, idx+1)
+	return nil
+}
+
+func (m *mockSessionRepository) indexOf(name session.SessionName) int {
+	return slices.IndexFunc(m.sessions, func(s session.Session) bool {
+		return s.Name == name
+	})
+}
+
+func TestCreateSession_Success(t *testing.T) {
+	repo := &mockSessionRepository{}
+	uc := usecase.NewCreateSession(repo)
+
+	sess, err := uc.Execute(usecase.CreateSessionInput{Name: " incident-response "})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if sess.Name.Value() != "incident-response" {
+		t.Errorf("expected name incident-response, got %q", sess.Name.Value())
+	}
+	if len(repo.sessions) != 1 {
+		t.Fatalf("expected 1 stored session, got %d", len(repo.sessions))
+	}
+}
+
+func TestCreateSession_DuplicateFails(t *testing.T) {
+	repo := &mockSessionRepository{}
+	repo.Add(sessiontest.New(t, "daily"))
+	uc := usecase.NewCreateSession(repo)
+
+	_, err := uc.Execute(usecase.CreateSessionInput{Name: "daily"})
+	if !errors.Is(err, session.ErrDuplicateSession) {
+		t.Fatalf("expected ErrDuplicateSession, got %v", err)
+	}
+}
+
+func TestCreateSession_InvalidNameFails(t *testing.T) {
+	uc := usecase.NewCreateSession(&mockSessionRepository{})
+
+	if _, err := uc.Execute(usecase.CreateSessionInput{Name: "two words"}); err == nil {
+		t.Fatal("expected error for invalid name")
+	}
+}
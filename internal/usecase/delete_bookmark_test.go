@@ -32,6 +32,10 @@ func (m *mockRepositoryForDelete) List() ([]bookmark.Bookmark, error) {
 	return m.bookmarks, nil
 }
 
+func (m *mockRepositoryForDelete) Update(bm bookmark.Bookmark) error {
+	return fmt.Errorf("not implemented")
+}
+
 func (m *mockRepositoryForDelete) Delete(id bookmark.BookmarkID) error {
 	if m.deleteFunc != nil {
 		return m.deleteFunc(id)
@@ -39,6 +43,10 @@ func (m *mockRepositoryForDelete) Delete(id bookmark.BookmarkID) error {
 	return fmt.Errorf("not implemented")
 }
 
+func (m *mockRepositoryForDelete) UpdateAll(bms []bookmark.Bookmark) error {
+	return fmt.Errorf("not implemented")
+}
+
 type mockSelectorForDelete struct {
 	selectFunc func([]bookmark.Bookmark) (bookmark.Bookmark, error)
 }
@@ -53,6 +61,10 @@ func (m *mockSelectorForDelete) Select(bms []bookmark.Bookmark) (bookmark.Bookma
 	return bms[0], nil
 }
 
+func (m *mockSelectorForDelete) SelectMulti(bms []bookmark.Bookmark) ([]bookmark.Bookmark, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
 func TestDeleteBookmark_Success(t *testing.T) {
 	repo := &mockRepositoryForDelete{}
 	sel := &mockSelectorForDelete{}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/airRnot1106/bkm/internal/session"
+)
+
+type DeleteSessionInput struct {
+	Name string
+}
+
+type DeleteSession struct {
+	sessions session.Repository
+}
+
+func NewDeleteSession(sessions session.Repository) *DeleteSession {
+	return &DeleteSession{sessions: sessions}
+}
+
+// Execute deletes the session. The bookmarks in it are kept.
+func (uc *DeleteSession) Execute(input DeleteSessionInput) error {
+	name, err := session.NewSessionName(input.Name)
+	if err != nil {
+		return fmt.Errorf("invalid name: %w", err)
+	}
+
+	if err := uc.sessions.Delete(name); err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/bookmark/bookmarktest"
+	"github.com/airRnot1106/bkm/internal/session"
+	"github.com/airRnot1106/bkm/internal/session/sessiontest"
+	"github.com/airRnot1106/bkm/internal/usecase"
+)
+
+func TestDeleteSession_Success(t *testing.T) {
+	repo := &mockSessionRepository{}
+	repo.Add(sessiontest.New(t, "daily", bookmarktest.New(t, "https://status.example.com/1")))
+	uc := usecase.NewDeleteSession(repo)
+
+	if err := uc.Execute(usecase.DeleteSessionInput{Name: "daily"}); err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	if len(repo.sessions) != 0 {
+		t.Errorf("expected no sessions, got %d", len(repo.sessions))
+	}
+}
+
+func TestDeleteSession_UnknownSessionFails(t *testing.T) {
+	uc := usecase.NewDeleteSession(&mockSessionRepository{})
+
+	err := uc.Execute(usecase.DeleteSessionInput{Name: "missing"})
+	if !errors.Is(err, session.ErrSessionNotFound) {
+		t.Fatalf("expected ErrSessionNotFound, got %v", err)
+	}
+}
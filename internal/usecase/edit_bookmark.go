@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+)
+
+type EditBookmarkInput struct {
+	Bookmark    bookmark.Bookmark
+	URL         string
+	Title       string
+	Description string
+	Tags        []string
+	Keyword     string
+	Variants    map[string]string
+}
+
+type EditBookmark struct {
+	repo     bookmark.Repository
+	policy   *URLPolicy
+	reserved []string
+}
+
+// NewEditBookmark refuses the keywords in reserved like NewAddBookmark.
+func NewEditBookmark(repo bookmark.Repository, policy *URLPolicy, reserved ...string) *EditBookmark {
+	return &EditBookmark{repo: repo, policy: policy, reserved: reserved}
+}
+
+// Execute replaces the fields of the bookmark. URLs that changed go through
+// the URL policy; unchanged ones are kept as they are.
+func (uc *EditBookmark) Execute(input EditBookmarkInput) (bookmark.Bookmark, error) {
+	url := input.Bookmark.URL
+	if input.URL != url.Value() {
+		var err error
+		url, err = uc.policy.Apply(input.URL)
+		if err != nil {
+			return bookmark.Bookmark{}, err
+		}
+	}
+
+	title, err := bookmark.NewBookmarkTitle(input.Title)
+	if err != nil {
+		return bookmark.Bookmark{}, fmt.Errorf("invalid title: %w", err)
+	}
+
+	desc := bookmark.NewBookmarkDescription(input.Description)
+
+	tags := make([]bookmark.BookmarkTag, 0, len(input.Tags))
+	for i, t := range input.Tags {
+		tag, err := bookmark.NewBookmarkTag(t)
+		if err != nil {
+			return bookmark.Bookmark{}, fmt.Errorf("invalid tag at index %d: %w", i, err)
+		}
+		tags = append(tags, tag)
+	}
+
+	var keyword bookmark.BookmarkKeyword
+	if input.Keyword != "" {
+		keyword, err = bookmark.NewBookmarkKeyword(input.Keyword)
+		if err != nil {
+			return bookmark.Bookmark{}, fmt.Errorf("invalid keyword: %w", err)
+		}
+		if err := checkKeywordAvailable(uc.repo, keyword, input.Bookmark.ID, uc.reserved); err != nil {
+			return bookmark.Bookmark{}, err
+		}
+	}
+
+	variants, err := uc.policy.applyVariants(input.Variants, input.Bookmark.Variants)
+	if err != nil {
+		return bookmark.Bookmark{}, err
+	}
+
+	bm := input.Bookmark
+	bm.URL = url
+	bm.Title = title
+	bm.Description = desc
+	bm.Tags = tags
+	bm.Keyword = keyword
+	bm.Variants = variants
+	bm.UpdatedAt = time.Now()
+
+	if err := uc.repo.Update(bm); err != nil {
+		return bookmark.Bookmark{}, fmt.Errorf("failed to update bookmark: %w", err)
+	}
+
+	return bm, nil
+}
@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/editor"
+)
+
+type EditBookmarkNotesInput struct {
+	Bookmark bookmark.Bookmark
+}
+
+type EditBookmarkNotes struct {
+	repo   bookmark.Repository
+	editor editor.Editor
+}
+
+func NewEditBookmarkNotes(repo bookmark.Repository, editor editor.Editor) *EditBookmarkNotes {
+	return &EditBookmarkNotes{repo: repo, editor: editor}
+}
+
+func (uc *EditBookmarkNotes) Execute(input EditBookmarkNotesInput) (bookmark.Bookmark, error) {
+	bm := input.Bookmark
+
+	edited, err := uc.editor.Edit(bm.Notes.Value())
+	if err != nil {
+		return bookmark.Bookmark{}, fmt.Errorf("failed to edit notes: %w", err)
+	}
+
+	notes := bookmark.NewBookmarkNotes(edited)
+	if notes == bm.Notes {
+		return bm, nil
+	}
+
+	bm.Notes = notes
+	bm.UpdatedAt = time.Now()
+	if err := uc.repo.Update(bm); err != nil {
+		return bookmark.Bookmark{}, fmt.Errorf("failed to update bookmark: %w", err)
+	}
+
+	return bm, nil
+}
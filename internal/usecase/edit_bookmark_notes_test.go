@@ -0,0 +1,136 @@
+package usecase_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/bookmark/bookmarktest"
+	"github.com/airRnot1106/bkm/internal/usecase"
+)
+
+type mockRepositoryForNotes struct {
+	updateFunc func(bookmark.Bookmark) error
+	updated    []bookmark.Bookmark
+}
+
+func (m *mockRepositoryForNotes) Add(bm bookmark.Bookmark) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (m *mockRepositoryForNotes) List() ([]bookmark.Bookmark, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *mockRepositoryForNotes) Update(bm bookmark.Bookmark) error {
+	if m.updateFunc != nil {
+		return m.updateFunc(bm)
+	}
+	m.updated = append(m.updated, bm)
+	return nil
+}
+
+func (m *mockRepositoryForNotes) Delete(id bookmark.BookmarkID) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (m *mockRepositoryForNotes) UpdateAll(bms []bookmark.Bookmark) error {
+	return fmt.Errorf("not implemented")
+}
+
+type mockEditorForNotes struct {
+	editFunc func(string) (string, error)
+	received string
+}
+
+func (m *mockEditorForNotes) Edit(content string) (string, error) {
+	m.received = content
+	if m.editFunc != nil {
+		return m.editFunc(content)
+	}
+	return content, nil
+}
+
+func TestEditBookmarkNotes_SavesEditedNotes(t *testing.T) {
+	repo := &mockRepositoryForNotes{}
+	ed := &mockEditorForNotes{
+		editFunc: func(content string) (string, error) {
+			return content + "\n\n- key snippet\n", nil
+		},
+	}
+	uc := usecase.NewEditBookmarkNotes(repo, ed)
+	original := bookmarktest.New(t, "https://example.com")
+	original.Description = bookmark.NewBookmarkDescription("short")
+	original.Notes = bookmark.NewBookmarkNotes("# Why")
+
+	bm, err := uc.Execute(usecase.EditBookmarkNotesInput{Bookmark: original})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if ed.received != "# Why" {
+		t.Errorf("expected editor to receive current notes, got %q", ed.received)
+	}
+	if len(repo.updated) != 1 {
+		t.Fatalf("expected 1 update, got %d", len(repo.updated))
+	}
+	if bm.Notes.Value() != "# Why\n\n- key snippet" {
+		t.Errorf("unexpected notes: %q", bm.Notes.Value())
+	}
+	if bm.Description.Value() != "short" {
+		t.Errorf("description should be left untouched, got %q", bm.Description.Value())
+	}
+}
+
+func TestEditBookmarkNotes_UnchangedNotesAreNotSaved(t *testing.T) {
+	repo := &mockRepositoryForNotes{}
+	uc := usecase.NewEditBookmarkNotes(repo, &mockEditorForNotes{})
+	bm := bookmarktest.New(t, "https://example.com")
+	bm.Notes = bookmark.NewBookmarkNotes("same")
+
+	_, err := uc.Execute(usecase.EditBookmarkNotesInput{Bookmark: bm})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if len(repo.updated) != 0 {
+		t.Errorf("expected no update, got %d", len(repo.updated))
+	}
+}
+
+func TestEditBookmarkNotes_EditorError(t *testing.T) {
+	repo := &mockRepositoryForNotes{}
+	ed := &mockEditorForNotes{
+		editFunc: func(string) (string, error) {
+			return "", fmt.Errorf("editor crashed")
+		},
+	}
+	uc := usecase.NewEditBookmarkNotes(repo, ed)
+
+	_, err := uc.Execute(usecase.EditBookmarkNotesInput{Bookmark: bookmarktest.New(t, "https://example.com")})
+	if err == nil {
+		t.Fatalf("expected error, got success")
+	}
+	if len(repo.updated) != 0 {
+		t.Errorf("expected no update, got %d", len(repo.updated))
+	}
+}
+
+func TestEditBookmarkNotes_RepositoryUpdateError(t *testing.T) {
+	repo := &mockRepositoryForNotes{
+		updateFunc: func(bookmark.Bookmark) error {
+			return fmt.Errorf("disk full")
+		},
+	}
+	ed := &mockEditorForNotes{
+		editFunc: func(string) (string, error) {
+			return "new notes", nil
+		},
+	}
+	uc := usecase.NewEditBookmarkNotes(repo, ed)
+
+	_, err := uc.Execute(usecase.EditBookmarkNotesInput{Bookmark: bookmarktest.New(t, "https://example.com")})
+	if err == nil {
+		t.Fatalf("expected error, got success")
+	}
+}
@@ -0,0 +1,219 @@
+package usecase_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/bookmark/bookmarktest"
+	"github.com/airRnot1106/bkm/internal/secretscan"
+	"github.com/airRnot1106/bkm/internal/urlclean"
+	"github.com/airRnot1106/bkm/internal/usecase"
+)
+
+type mockRepositoryForEdit struct {
+	updateFunc func(bookmark.Bookmark) error
+	bookmarks  []bookmark.Bookmark
+	updated    []bookmark.Bookmark
+}
+
+func (m *mockRepositoryForEdit) Add(bm bookmark.Bookmark) error {
+	m.bookmarks = append(m.bookmarks, bm)
+	return nil
+}
+
+func (m *mockRepositoryForEdit) List() ([]bookmark.Bookmark, error) {
+	return m.bookmarks, nil
+}
+
+func (m *mockRepositoryForEdit) Update(bm bookmark.Bookmark) error {
+	if m.updateFunc != nil {
+		return m.updateFunc(bm)
+	}
+	m.updated = append(m.updated, bm)
+	return nil
+}
+
+func (m *mockRepositoryForEdit) Delete(id bookmark.BookmarkID) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (m *mockRepositoryForEdit) UpdateAll(bms []bookmark.Bookmark) error {
+	return fmt.Errorf("not implemented")
+}
+
+func TestEditBookmark_ValidParamsAlwaysSucceed(t *testing.T) {
+	repo := &mockRepositoryForEdit{}
+	original := bookmarktest.New(t, "https://example.com")
+	repo.Add(original)
+
+	uc := usecase.NewEditBookmark(repo, newURLPolicy(nil, secretscan.PolicyRefuse))
+
+	bm, err := uc.Execute(usecase.EditBookmarkInput{
+		Bookmark:    original,
+		URL:         "https://example.org",
+		Title:       "Example",
+		Description: "Edited",
+		Tags:        []string{"web"},
+		Keyword:     "ex",
+	})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if len(repo.updated) != 1 {
+		t.Fatalf("expected 1 update, got %d", len(repo.updated))
+	}
+	if bm.ID != original.ID {
+		t.Errorf("ID should be preserved")
+	}
+	if bm.CreatedAt != original.CreatedAt {
+		t.Errorf("CreatedAt should be preserved")
+	}
+	if bm.URL.Value() != "https://example.org" {
+		t.Errorf("expected URL %q, got %q", "https://example.org", bm.URL.Value())
+	}
+	if bm.Keyword.Value() != "ex" {
+		t.Errorf("expected keyword %q, got %q", "ex", bm.Keyword.Value())
+	}
+}
+
+func TestEditBookmark_KeepingOwnKeywordSucceeds(t *testing.T) {
+	repo := &mockRepositoryForEdit{}
+	original := bookmarktest.New(t, "https://github.com")
+	original.Keyword, _ = bookmark.NewBookmarkKeyword("gh")
+	repo.Add(original)
+
+	uc := usecase.NewEditBookmark(repo, newURLPolicy(nil, secretscan.PolicyRefuse))
+
+	_, err := uc.Execute(usecase.EditBookmarkInput{
+		Bookmark: original,
+		URL:      "https://github.com",
+		Title:    "GitHub",
+		Keyword:  "gh",
+	})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+}
+
+func TestEditBookmark_DuplicateKeywordFails(t *testing.T) {
+	repo := &mockRepositoryForEdit{}
+	github := bookmarktest.New(t, "https://github.com")
+	github.Keyword, _ = bookmark.NewBookmarkKeyword("gh")
+	repo.Add(github)
+	target := bookmarktest.New(t, "https://gitlab.com")
+	repo.Add(target)
+
+	uc := usecase.NewEditBookmark(repo, newURLPolicy(nil, secretscan.PolicyRefuse))
+
+	_, err := uc.Execute(usecase.EditBookmarkInput{
+		Bookmark: target,
+		URL:      "https://gitlab.com",
+		Title:    "GitLab",
+		Keyword:  "gh",
+	})
+	if !errors.Is(err, bookmark.ErrDuplicateKeyword) {
+		t.Fatalf("expected ErrDuplicateKeyword, got %v", err)
+	}
+	if len(repo.updated) != 0 {
+		t.Errorf("expected no update, got %d", len(repo.updated))
+	}
+}
+
+func TestEditBookmark_ReservedKeywordFails(t *testing.T) {
+	repo := &mockRepositoryForEdit{}
+	target := bookmarktest.New(t, "https://github.com")
+	repo.Add(target)
+
+	uc := usecase.NewEditBookmark(repo, newURLPolicy(nil, secretscan.PolicyRefuse), "add", "ls")
+
+	_, err := uc.Execute(usecase.EditBookmarkInput{
+		Bookmark: target,
+		URL:      "https://github.com",
+		Title:    "GitHub",
+		Keyword:  "add",
+	})
+	if !errors.Is(err, bookmark.ErrReservedKeyword) {
+		t.Fatalf("expected ErrReservedKeyword, got %v", err)
+	}
+	if len(repo.updated) != 0 {
+		t.Errorf("expected no update, got %d", len(repo.updated))
+	}
+}
+
+func TestEditBookmark_InvalidTitleFails(t *testing.T) {
+	repo := &mockRepositoryForEdit{}
+	original := bookmarktest.New(t, "https://example.com")
+	repo.Add(original)
+
+	uc := usecase.NewEditBookmark(repo, newURLPolicy(nil, secretscan.PolicyRefuse))
+
+	_, err := uc.Execute(usecase.EditBookmarkInput{
+		Bookmark: original,
+		URL:      "https://example.com",
+		Title:    "  ",
+	})
+	if err == nil {
+		t.Fatalf("expected error for invalid title, got success")
+	}
+}
+
+func TestEditBookmark_RepositoryUpdateError(t *testing.T) {
+	repo := &mockRepositoryForEdit{
+		updateFunc: func(bookmark.Bookmark) error {
+			return bookmark.ErrBookmarkNotFound
+		},
+	}
+	original := bookmarktest.New(t, "https://example.com")
+
+	uc := usecase.NewEditBookmark(repo, newURLPolicy(nil, secretscan.PolicyRefuse))
+
+	_, err := uc.Execute(usecase.EditBookmarkInput{
+		Bookmark: original,
+		URL:      "https://example.com",
+		Title:    "Example",
+	})
+	if !errors.Is(err, bookmark.ErrBookmarkNotFound) {
+		t.Fatalf("expected ErrBookmarkNotFound, got %v", err)
+	}
+}
+
+func TestEditBookmark_AppliesURLPolicyToChangedURLs(t *testing.T) {
+	repo := &mockRepositoryForEdit{}
+	original := bookmarktest.New(t, "https://example.com/feed?token=old")
+	repo.Add(original)
+	uc := usecase.NewEditBookmark(repo, newURLPolicy(urlclean.BuiltinRules(), secretscan.PolicyRefuse))
+
+	_, err := uc.Execute(usecase.EditBookmarkInput{Bookmark: original, URL: "https://example.com/feed?token=new", Title: "Feed"})
+	if !errors.Is(err, secretscan.ErrSecretFound) {
+		t.Errorf("expected ErrSecretFound for a changed URL, got %v", err)
+	}
+	_, err = uc.Execute(usecase.EditBookmarkInput{
+		Bookmark: original,
+		URL:      original.URL.Value(),
+		Title:    "Feed",
+		Variants: map[string]string{"private": "https://example.com/feed?api_key=abc"},
+	})
+	if !errors.Is(err, secretscan.ErrSecretFound) {
+		t.Errorf("expected ErrSecretFound for a variant, got %v", err)
+	}
+
+	bm, err := uc.Execute(usecase.EditBookmarkInput{
+		Bookmark: original,
+		URL:      original.URL.Value(),
+		Title:    "Feed",
+		Variants: map[string]string{"web": "https://example.com/?utm_source=mail"},
+	})
+	if err != nil {
+		t.Fatalf("expected an unchanged URL to be kept, got error: %v", err)
+	}
+	if bm.URL != original.URL {
+		t.Errorf("expected URL %s, got %s", original.URL.Value(), bm.URL.Value())
+	}
+	name, _ := bookmark.NewBookmarkVariantName("web")
+	if url := bm.Variants[name]; url.Value() != "https://example.com/" {
+		t.Errorf("expected a cleaned variant URL, got %s", url.Value())
+	}
+}
@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/metadata"
+)
+
+type FetchBookmarkMetadataInput struct {
+	URL string
+}
+
+type FetchBookmarkMetadata struct {
+	fetcher metadata.Fetcher
+}
+
+func NewFetchBookmarkMetadata(fetcher metadata.Fetcher) *FetchBookmarkMetadata {
+	return &FetchBookmarkMetadata{fetcher: fetcher}
+}
+
+// Execute fetches the title and description of the page at the URL. URL
+// templates and URLs with variables cannot be fetched because they do not
+// point at a single page.
+func (uc *FetchBookmarkMetadata) Execute(input FetchBookmarkMetadataInput) (metadata.Metadata, error) {
+	url, err := bookmark.NewBookmarkURL(input.URL)
+	if err != nil {
+		return metadata.Metadata{}, fmt.Errorf("invalid URL: %w", err)
+	}
+	if url.IsTemplate() || len(url.Variables()) > 0 {
+		return metadata.Metadata{}, metadata.ErrUnsupportedURL
+	}
+
+	meta, err := uc.fetcher.Fetch(url.Value())
+	if err != nil {
+		return metadata.Metadata{}, fmt.Errorf("failed to fetch metadata: %w", err)
+	}
+
+	return meta, nil
+}
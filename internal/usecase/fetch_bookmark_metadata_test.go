@@ -0,0 +1,67 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/metadata"
+	"github.com/airRnot1106/bkm/internal/usecase"
+)
+
+type mockFetcherForMetadata struct {
+	fetched []string
+	meta    metadata.Metadata
+	err     error
+}
+
+func (m *mockFetcherForMetadata) Fetch(url string) (metadata.Metadata, error) {
+	m.fetched = append(m.fetched, url)
+	return m.meta, m.err
+}
+
+func TestFetchBookmarkMetadata_Success(t *testing.T) {
+	fetcher := &mockFetcherForMetadata{meta: metadata.Metadata{Title: "Example", Description: "An example"}}
+	uc := usecase.NewFetchBookmarkMetadata(fetcher)
+
+	meta, err := uc.Execute(usecase.FetchBookmarkMetadataInput{URL: "https://example.com"})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if meta != fetcher.meta {
+		t.Errorf("expected %+v, got %+v", fetcher.meta, meta)
+	}
+}
+
+func TestFetchBookmarkMetadata_FetcherError(t *testing.T) {
+	expectedErr := errors.New("timeout")
+	uc := usecase.NewFetchBookmarkMetadata(&mockFetcherForMetadata{err: expectedErr})
+
+	_, err := uc.Execute(usecase.FetchBookmarkMetadataInput{URL: "https://example.com"})
+	if !errors.Is(err, expectedErr) {
+		t.Fatalf("expected %v, got %v", expectedErr, err)
+	}
+}
+
+func TestFetchBookmarkMetadata_TemplatesAndVariablesAreNotFetched(t *testing.T) {
+	for _, url := range []string{"https://pkg.go.dev/search?q=%s", "https://${ENV}.grafana.example.com"} {
+		fetcher := &mockFetcherForMetadata{}
+		uc := usecase.NewFetchBookmarkMetadata(fetcher)
+
+		_, err := uc.Execute(usecase.FetchBookmarkMetadataInput{URL: url})
+		if !errors.Is(err, metadata.ErrUnsupportedURL) {
+			t.Errorf("%s: expected ErrUnsupportedURL, got %v", url, err)
+		}
+		if len(fetcher.fetched) != 0 {
+			t.Errorf("%s: expected no fetch, got %v", url, fetcher.fetched)
+		}
+	}
+}
+
+func TestFetchBookmarkMetadata_InvalidURLFails(t *testing.T) {
+	uc := usecase.NewFetchBookmarkMetadata(&mockFetcherForMetadata{})
+
+	if _, err := uc.Execute(usecase.FetchBookmarkMetadataInput{URL: "not a url"}); err == nil {
+		t.Fatal("expected error for invalid URL")
+	}
+}
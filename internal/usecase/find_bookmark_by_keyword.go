@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+)
+
+type FindBookmarkByKeywordInput struct {
+	Keyword string
+}
+
+type FindBookmarkByKeyword struct {
+	repo bookmark.Repository
+}
+
+func NewFindBookmarkByKeyword(repo bookmark.Repository) *FindBookmarkByKeyword {
+	return &FindBookmarkByKeyword{repo: repo}
+}
+
+func (uc *FindBookmarkByKeyword) Execute(input FindBookmarkByKeywordInput) (bookmark.Bookmark, error) {
+	keyword, err := bookmark.NewBookmarkKeyword(input.Keyword)
+	if err != nil {
+		return bookmark.Bookmark{}, fmt.Errorf("invalid keyword: %w", err)
+	}
+
+	bookmarks, err := uc.repo.List()
+	if err != nil {
+		return bookmark.Bookmark{}, fmt.Errorf("failed to list bookmarks: %w", err)
+	}
+
+	for _, bm := range bookmarks {
+		if bm.Keyword == keyword {
+			return bm, nil
+		}
+	}
+
+	return bookmark.Bookmark{}, fmt.Errorf("%w with keyword %q", bookmark.ErrBookmarkNotFound, keyword.Value())
+}
@@ -0,0 +1,97 @@
+package usecase_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/bookmark/bookmarktest"
+	"github.com/airRnot1106/bkm/internal/usecase"
+)
+
+type mockRepositoryForKeyword struct {
+	listFunc  func() ([]bookmark.Bookmark, error)
+	bookmarks []bookmark.Bookmark
+}
+
+func (m *mockRepositoryForKeyword) Add(bm bookmark.Bookmark) error {
+	m.bookmarks = append(m.bookmarks, bm)
+	return nil
+}
+
+func (m *mockRepositoryForKeyword) List() ([]bookmark.Bookmark, error) {
+	if m.listFunc != nil {
+		return m.listFunc()
+	}
+	return m.bookmarks, nil
+}
+
+func (m *mockRepositoryForKeyword) Update(bm bookmark.Bookmark) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (m *mockRepositoryForKeyword) Delete(id bookmark.BookmarkID) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (m *mockRepositoryForKeyword) UpdateAll(bms []bookmark.Bookmark) error {
+	return fmt.Errorf("not implemented")
+}
+
+func TestFindBookmarkByKeyword_Found(t *testing.T) {
+	repo := &mockRepositoryForKeyword{}
+	for rawURL, keyword := range map[string]string{"https://github.com": "gh", "https://ci.example.com": "ci"} {
+		bm := bookmarktest.New(t, rawURL)
+		bm.Keyword, _ = bookmark.NewBookmarkKeyword(keyword)
+		repo.Add(bm)
+	}
+
+	uc := usecase.NewFindBookmarkByKeyword(repo)
+
+	bm, err := uc.Execute(usecase.FindBookmarkByKeywordInput{Keyword: "ci"})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if bm.URL.Value() != "https://ci.example.com" {
+		t.Errorf("expected URL %q, got %q", "https://ci.example.com", bm.URL.Value())
+	}
+}
+
+func TestFindBookmarkByKeyword_NotFound(t *testing.T) {
+	repo := &mockRepositoryForKeyword{}
+	github := bookmarktest.New(t, "https://github.com")
+	github.Keyword, _ = bookmark.NewBookmarkKeyword("gh")
+	repo.Add(github)
+
+	uc := usecase.NewFindBookmarkByKeyword(repo)
+
+	_, err := uc.Execute(usecase.FindBookmarkByKeywordInput{Keyword: "gl"})
+	if !errors.Is(err, bookmark.ErrBookmarkNotFound) {
+		t.Fatalf("expected ErrBookmarkNotFound, got %v", err)
+	}
+}
+
+func TestFindBookmarkByKeyword_InvalidKeyword(t *testing.T) {
+	uc := usecase.NewFindBookmarkByKeyword(&mockRepositoryForKeyword{})
+
+	_, err := uc.Execute(usecase.FindBookmarkByKeywordInput{Keyword: ""})
+	if err == nil {
+		t.Fatalf("expected error for empty keyword, got success")
+	}
+}
+
+func TestFindBookmarkByKeyword_RepositoryListError(t *testing.T) {
+	repo := &mockRepositoryForKeyword{
+		listFunc: func() ([]bookmark.Bookmark, error) {
+			return nil, fmt.Errorf("database connection failed")
+		},
+	}
+	uc := usecase.NewFindBookmarkByKeyword(repo)
+
+	_, err := uc.Execute(usecase.FindBookmarkByKeywordInput{Keyword: "gh"})
+	if err == nil {
+		t.Fatalf("expected error, got success")
+	}
+}
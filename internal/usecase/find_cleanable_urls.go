@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"github.com/airRnot1106/bkm/internal/bookmark"
+)
+
+type FindCleanableURLsInput struct {
+	Bookmarks []bookmark.Bookmark
+}
+
+type FindCleanableURLs struct {
+	cleaner URLCleaner
+}
+
+func NewFindCleanableURLs(cleaner URLCleaner) *FindCleanableURLs {
+	return &FindCleanableURLs{cleaner: cleaner}
+}
+
+// Execute proposes the URL without tracking parameters for the primary URL
+// and the variants of every bookmark that have any.
+func (uc *FindCleanableURLs) Execute(input FindCleanableURLsInput) []URLUpgrade {
+	var upgrades []URLUpgrade
+	for _, bm := range input.Bookmarks {
+		names := append([]bookmark.BookmarkVariantName{{}}, bm.VariantNames()...)
+		for _, name := range names {
+			url, err := bm.URLFor(name)
+			if err != nil {
+				continue
+			}
+			cleaned := uc.cleaner.Clean(url.Value())
+			if cleaned == url.Value() {
+				continue
+			}
+			newURL, err := bookmark.NewBookmarkURL(cleaned)
+			if err != nil {
+				continue
+			}
+			upgrades = append(upgrades, URLUpgrade{Bookmark: bm, Variant: name, URL: newURL})
+		}
+	}
+	return upgrades
+}
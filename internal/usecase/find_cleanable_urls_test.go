@@ -0,0 +1,54 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/bookmark/bookmarktest"
+	"github.com/airRnot1106/bkm/internal/urlclean"
+	"github.com/airRnot1106/bkm/internal/usecase"
+)
+
+func TestFindCleanableURLs_ProposesCleanedURLs(t *testing.T) {
+	tracked := bookmarktest.New(t, "https://example.com/post?utm_source=news#intro")
+	clean := bookmarktest.New(t, "https://example.com/about")
+	uc := usecase.NewFindCleanableURLs(urlclean.NewCleaner(urlclean.BuiltinRules()))
+
+	upgrades := uc.Execute(usecase.FindCleanableURLsInput{Bookmarks: []bookmark.Bookmark{tracked, clean}})
+
+	if len(upgrades) != 1 {
+		t.Fatalf("expected 1 upgrade, got %d", len(upgrades))
+	}
+	if upgrades[0].Bookmark.ID != tracked.ID {
+		t.Errorf("expected the tracked bookmark, got %s", upgrades[0].Bookmark.URL.Value())
+	}
+	if expected := "https://example.com/post#intro"; upgrades[0].URL.Value() != expected {
+		t.Errorf("expected URL %s, got %s", expected, upgrades[0].URL.Value())
+	}
+}
+
+func TestFindCleanableURLs_CleansVariants(t *testing.T) {
+	bm := bookmarktest.New(t, "https://example.com/")
+	dirty, err := bookmark.NewBookmarkURL("https://staging.example.com/?id=1&fbclid=abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	name, err := bookmark.NewBookmarkVariantName("staging")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bm.Variants = map[bookmark.BookmarkVariantName]bookmark.BookmarkURL{name: dirty}
+	uc := usecase.NewFindCleanableURLs(urlclean.NewCleaner(urlclean.BuiltinRules()))
+
+	upgrades := uc.Execute(usecase.FindCleanableURLsInput{Bookmarks: []bookmark.Bookmark{bm}})
+
+	if len(upgrades) != 1 {
+		t.Fatalf("expected 1 upgrade, got %d", len(upgrades))
+	}
+	if upgrades[0].Variant != name {
+		t.Errorf("expected the staging variant, got %q", upgrades[0].Variant.Value())
+	}
+	if expected := "https://staging.example.com/?id=1"; upgrades[0].URL.Value() != expected {
+		t.Errorf("expected URL %s, got %s", expected, upgrades[0].URL.Value())
+	}
+}
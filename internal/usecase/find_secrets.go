@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/secretscan"
+)
+
+type FindSecretsInput struct {
+	Bookmarks []bookmark.Bookmark
+}
+
+// SecretAudit lists the secrets in one URL of Bookmark. Variant is the zero
+// value for the primary URL.
+type SecretAudit struct {
+	Bookmark bookmark.Bookmark
+	Variant  bookmark.BookmarkVariantName
+	Findings []secretscan.Finding
+}
+
+type FindSecrets struct {
+	scanner *secretscan.Scanner
+}
+
+func NewFindSecrets(scanner *secretscan.Scanner) *FindSecrets {
+	return &FindSecrets{scanner: scanner}
+}
+
+// Execute scans the primary URL and the variants of every bookmark.
+func (uc *FindSecrets) Execute(input FindSecretsInput) []SecretAudit {
+	var audits []SecretAudit
+	for _, bm := range input.Bookmarks {
+		names := append([]bookmark.BookmarkVariantName{{}}, bm.VariantNames()...)
+		for _, name := range names {
+			url, err := bm.URLFor(name)
+			if err != nil {
+				continue
+			}
+			if findings := uc.scanner.Scan(url.Value()); len(findings) > 0 {
+				audits = append(audits, SecretAudit{Bookmark: bm, Variant: name, Findings: findings})
+			}
+		}
+	}
+	return audits
+}
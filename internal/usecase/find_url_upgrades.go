@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/linkcheck"
+)
+
+type FindURLUpgradesInput struct {
+	Bookmarks []bookmark.Bookmark
+}
+
+// URLUpgrade proposes replacing one URL of Bookmark with URL. Variant is the
+// zero value for the primary URL. Redirects are the hops that lead to URL
+// when the old one permanently redirects there.
+type URLUpgrade struct {
+	Bookmark  bookmark.Bookmark
+	Variant   bookmark.BookmarkVariantName
+	URL       bookmark.BookmarkURL
+	Redirects []linkcheck.Redirect
+}
+
+type FindURLUpgradesOutput struct {
+	Upgrades []URLUpgrade
+	// Refused are the bookmarks whose new location is refused by the URL
+	// policy, such as a redirect to a URL with secrets. They keep their URL.
+	Refused []error
+}
+
+type FindURLUpgrades struct {
+	checker linkcheck.Checker
+	policy  *URLPolicy
+}
+
+func NewFindURLUpgrades(checker linkcheck.Checker, policy *URLPolicy) *FindURLUpgrades {
+	return &FindURLUpgrades{checker: checker, policy: policy}
+}
+
+// Execute follows the redirects of every bookmark URL and proposes the new
+// location for permanent redirects and http to https upgrades. The new
+// location goes through the URL policy like any other stored URL. Bookmarks
+// that cannot be checked are ignored.
+func (uc *FindURLUpgrades) Execute(input FindURLUpgradesInput) (FindURLUpgradesOutput, error) {
+	var targets []bookmark.Bookmark
+	var urls []string
+	for _, bm := range input.Bookmarks {
+		if isCheckable(bm.URL) {
+			targets = append(targets, bm)
+			urls = append(urls, bm.URL.Value())
+		}
+	}
+	if len(targets) == 0 {
+		return FindURLUpgradesOutput{}, nil
+	}
+
+	var output FindURLUpgradesOutput
+	for i, result := range uc.checker.Check(urls) {
+		moved, ok := result.MovedURL()
+		if !ok {
+			continue
+		}
+		newURL, err := uc.policy.Apply(keepFragment(targets[i].URL.Value(), moved))
+		if err != nil {
+			output.Refused = append(output.Refused, fmt.Errorf("%s: %w", targets[i].Title.Value(), err))
+			continue
+		}
+		if newURL == targets[i].URL {
+			continue
+		}
+		output.Upgrades = append(output.Upgrades, URLUpgrade{Bookmark: targets[i], URL: newURL, Redirects: result.Redirects})
+	}
+
+	return output, nil
+}
+
+// keepFragment carries the fragment of the old URL over to the new one, since
+// fragments are never sent to the server and so are lost in redirects.
+func keepFragment(oldURL, newURL string) string {
+	oldParsed, err := url.Parse(oldURL)
+	if err != nil || oldParsed.Fragment == "" {
+		return newURL
+	}
+	newParsed, err := url.Parse(newURL)
+	if err != nil || newParsed.Fragment != "" {
+		return newURL
+	}
+	newParsed.Fragment = oldParsed.Fragment
+	newParsed.RawFragment = oldParsed.RawFragment
+	return newParsed.String()
+}
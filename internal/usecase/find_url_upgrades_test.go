@@ -0,0 +1,113 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/bookmark/bookmarktest"
+	"github.com/airRnot1106/bkm/internal/linkcheck"
+	"github.com/airRnot1106/bkm/internal/secretscan"
+	"github.com/airRnot1106/bkm/internal/urlclean"
+	"github.com/airRnot1106/bkm/internal/usecase"
+)
+
+// mockCheckerForUpgrades answers with the result configured for each URL.
+type mockCheckerForUpgrades struct {
+	results map[string]linkcheck.Result
+	checked []string
+}
+
+func (m *mockCheckerForUpgrades) Check(urls []string) []linkcheck.Result {
+	m.checked = urls
+	results := make([]linkcheck.Result, len(urls))
+	for i, url := range urls {
+		result, ok := m.results[url]
+		if !ok {
+			result = linkcheck.Result{URL: url, StatusCode: 200}
+		}
+		results[i] = result
+	}
+	return results
+}
+
+func TestFindURLUpgrades_ProposesMovedURLs(t *testing.T) {
+	moved := bookmarktest.New(t, "https://example.com/old")
+	insecure := bookmarktest.New(t, "http://example.com/docs#install")
+	temporary := bookmarktest.New(t, "https://example.com/app")
+	unchanged := bookmarktest.New(t, "https://example.com/")
+	checker := &mockCheckerForUpgrades{results: map[string]linkcheck.Result{
+		"https://example.com/old": {URL: "https://example.com/old", StatusCode: 200, Redirects: []linkcheck.Redirect{
+			{StatusCode: 301, URL: "https://example.com/new"},
+		}},
+		"http://example.com/docs#install": {URL: "http://example.com/docs#install", StatusCode: 200, Redirects: []linkcheck.Redirect{
+			{StatusCode: 302, URL: "https://example.com/docs"},
+		}},
+		"https://example.com/app": {URL: "https://example.com/app", StatusCode: 200, Redirects: []linkcheck.Redirect{
+			{StatusCode: 302, URL: "https://example.com/login"},
+		}},
+	}}
+	uc := usecase.NewFindURLUpgrades(checker, newURLPolicy(nil, secretscan.PolicyRefuse))
+
+	output, err := uc.Execute(usecase.FindURLUpgradesInput{Bookmarks: []bookmark.Bookmark{moved, insecure, temporary, unchanged}})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	upgrades := output.Upgrades
+
+	if len(upgrades) != 2 {
+		t.Fatalf("expected 2 upgrades, got %d", len(upgrades))
+	}
+	if upgrades[0].Bookmark.ID != moved.ID || upgrades[0].URL.Value() != "https://example.com/new" {
+		t.Errorf("expected the moved bookmark to be upgraded, got %+v", upgrades[0])
+	}
+	if upgrades[1].Bookmark.ID != insecure.ID || upgrades[1].URL.Value() != "https://example.com/docs#install" {
+		t.Errorf("expected the https upgrade to keep the fragment, got %q", upgrades[1].URL.Value())
+	}
+}
+
+func TestFindURLUpgrades_SkipsUncheckableURLs(t *testing.T) {
+	checker := &mockCheckerForUpgrades{}
+	uc := usecase.NewFindURLUpgrades(checker, newURLPolicy(nil, secretscan.PolicyRefuse))
+
+	output, err := uc.Execute(usecase.FindURLUpgradesInput{Bookmarks: []bookmark.Bookmark{
+		bookmarktest.New(t, "https://example.com/search?q=%s"),
+		bookmarktest.New(t, "ssh://example.com"),
+	}})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if len(output.Upgrades) != 0 || len(checker.checked) != 0 {
+		t.Errorf("expected nothing to be checked, got %v", checker.checked)
+	}
+}
+
+func TestFindURLUpgrades_AppliesURLPolicyToNewURLs(t *testing.T) {
+	tracked := bookmarktest.New(t, "https://example.com/old")
+	secret := bookmarktest.New(t, "https://example.com/feed")
+	checker := &mockCheckerForUpgrades{results: map[string]linkcheck.Result{
+		"https://example.com/old": {URL: "https://example.com/old", StatusCode: 200, Redirects: []linkcheck.Redirect{
+			{StatusCode: 301, URL: "https://example.com/new?id=1&utm_source=news"},
+		}},
+		"https://example.com/feed": {URL: "https://example.com/feed", StatusCode: 200, Redirects: []linkcheck.Redirect{
+			{StatusCode: 308, URL: "https://example.com/feed.xml?utm_medium=email&token=abc"},
+		}},
+	}}
+	uc := usecase.NewFindURLUpgrades(checker, newURLPolicy(urlclean.BuiltinRules(), secretscan.PolicyRefuse))
+
+	output, err := uc.Execute(usecase.FindURLUpgradesInput{Bookmarks: []bookmark.Bookmark{tracked, secret}})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if len(output.Upgrades) != 1 {
+		t.Fatalf("expected 1 upgrade, got %d", len(output.Upgrades))
+	}
+	if output.Upgrades[0].Bookmark.ID != tracked.ID || output.Upgrades[0].URL.Value() != "https://example.com/new?id=1" {
+		t.Errorf("expected the tracking parameters to be removed, got %q", output.Upgrades[0].URL.Value())
+	}
+	if len(output.Refused) != 1 || !errors.Is(output.Refused[0], secretscan.ErrSecretFound) {
+		t.Errorf("expected the redirect to a token to be refused, got %v", output.Refused)
+	}
+}
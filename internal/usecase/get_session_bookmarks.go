@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/session"
+)
+
+type GetSessionBookmarksInput struct {
+	Name string
+}
+
+type GetSessionBookmarksOutput struct {
+	Session session.Session
+	// Bookmarks are in session order.
+	Bookmarks []bookmark.Bookmark
+	// Missing are the IDs of bookmarks that were deleted after being added.
+	Missing []bookmark.BookmarkID
+}
+
+type GetSessionBookmarks struct {
+	sessions session.Repository
+	repo     bookmark.Repository
+}
+
+func NewGetSessionBookmarks(sessions session.Repository, repo bookmark.Repository) *GetSessionBookmarks {
+	return &GetSessionBookmarks{sessions: sessions, repo: repo}
+}
+
+func (uc *GetSessionBookmarks) Execute(input GetSessionBookmarksInput) (GetSessionBookmarksOutput, error) {
+	sess, err := findSession(uc.sessions, input.Name)
+	if err != nil {
+		return GetSessionBookmarksOutput{}, err
+	}
+
+	bookmarks, err := uc.repo.List()
+	if err != nil {
+		return GetSessionBookmarksOutput{}, fmt.Errorf("failed to list bookmarks: %w", err)
+	}
+
+	byID := make(map[bookmark.BookmarkID]bookmark.Bookmark, len(bookmarks))
+	for _, bm := range bookmarks {
+		byID[bm.ID] = bm
+	}
+
+	output := GetSessionBookmarksOutput{Session: sess}
+	for _, id := range sess.BookmarkIDs {
+		if bm, ok := byID[id]; ok {
+			output.Bookmarks = append(output.Bookmarks, bm)
+		} else {
+			output.Missing = append(output.Missing, id)
+		}
+	}
+
+	return output, nil
+}
@@ -0,0 +1,39 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/bookmark/bookmarktest"
+	"github.com/airRnot1106/bkm/internal/session/sessiontest"
+	"github.com/airRnot1106/bkm/internal/usecase"
+)
+
+func TestGetSessionBookmarks_FollowsEditsAndReportsDeleted(t *testing.T) {
+	sessions := &mockSessionRepository{}
+	repo := &mockRepositoryForList{}
+	first, second, deleted := bookmarktest.New(t, "https://status.example.com/1"), bookmarktest.New(t, "https://status.example.com/2"), bookmarktest.New(t, "https://status.example.com/3")
+	sessions.Add(sessiontest.New(t, "incident-response", second, deleted, first))
+
+	// The bookmark was renamed after it was added to the session.
+	renamed := first
+	renamed.Title, _ = bookmark.NewBookmarkTitle("Pager")
+	repo.Add(renamed)
+	repo.Add(second)
+
+	uc := usecase.NewGetSessionBookmarks(sessions, repo)
+	output, err := uc.Execute(usecase.GetSessionBookmarksInput{Name: "incident-response"})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if len(output.Bookmarks) != 2 || output.Bookmarks[0].ID != second.ID || output.Bookmarks[1].ID != first.ID {
+		t.Errorf("expected bookmarks in session order, got %v", output.Bookmarks)
+	}
+	if len(output.Bookmarks) == 2 && output.Bookmarks[1].Title.Value() != "Pager" {
+		t.Errorf("expected the current title, got %q", output.Bookmarks[1].Title.Value())
+	}
+	if len(output.Missing) != 1 || output.Missing[0] != deleted.ID {
+		t.Errorf("expected the deleted bookmark to be missing, got %v", output.Missing)
+	}
+}
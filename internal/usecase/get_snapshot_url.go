@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/snapshot"
+)
+
+type GetSnapshotURLInput struct {
+	Bookmark bookmark.Bookmark
+}
+
+type GetSnapshotURL struct {
+	store snapshot.Store
+}
+
+func NewGetSnapshotURL(store snapshot.Store) *GetSnapshotURL {
+	return &GetSnapshotURL{store: store}
+}
+
+// Execute returns the file URL of the offline copy of the bookmarked page.
+func (uc *GetSnapshotURL) Execute(input GetSnapshotURLInput) (bookmark.BookmarkURL, error) {
+	digest := input.Bookmark.Snapshot.Digest
+	if digest == "" {
+		return bookmark.BookmarkURL{}, fmt.Errorf("%w: %q has not been archived", snapshot.ErrNotFound, input.Bookmark.Title.Value())
+	}
+
+	path, err := uc.store.Path(digest)
+	if err != nil {
+		return bookmark.BookmarkURL{}, fmt.Errorf("failed to find snapshot: %w", err)
+	}
+
+	fileURL := url.URL{Scheme: "file", Path: filepath.ToSlash(path)}
+	return bookmark.NewBookmarkURL(fileURL.String())
+}
@@ -0,0 +1,49 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/bookmark/bookmarktest"
+	"github.com/airRnot1106/bkm/internal/snapshot"
+	"github.com/airRnot1106/bkm/internal/usecase"
+)
+
+func TestGetSnapshotURL_ReturnsFileURL(t *testing.T) {
+	store := &mockSnapshotStore{}
+	digest, _ := store.Put([]byte("page"))
+	bm := bookmarktest.New(t, "https://example.com/")
+	bm.Snapshot = bookmark.Snapshot{Digest: digest}
+	uc := usecase.NewGetSnapshotURL(store)
+
+	url, err := uc.Execute(usecase.GetSnapshotURLInput{Bookmark: bm})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	expected := "file:///snapshots/" + digest + ".html"
+	if url.Value() != expected {
+		t.Errorf("expected %q, got %q", expected, url.Value())
+	}
+}
+
+func TestGetSnapshotURL_NotArchived(t *testing.T) {
+	uc := usecase.NewGetSnapshotURL(&mockSnapshotStore{})
+
+	_, err := uc.Execute(usecase.GetSnapshotURLInput{Bookmark: bookmarktest.New(t, "https://example.com/")})
+	if !errors.Is(err, snapshot.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestGetSnapshotURL_MissingFile(t *testing.T) {
+	bm := bookmarktest.New(t, "https://example.com/")
+	bm.Snapshot = bookmark.Snapshot{Digest: snapshot.Digest([]byte("deleted"))}
+	uc := usecase.NewGetSnapshotURL(&mockSnapshotStore{})
+
+	_, err := uc.Execute(usecase.GetSnapshotURLInput{Bookmark: bm})
+	if !errors.Is(err, snapshot.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,269 @@
+package usecase
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/importer"
+)
+
+// MergeStrategy decides what happens to an imported entry whose URL is
+// already bookmarked.
+type MergeStrategy string
+
+const (
+	// MergeSkip leaves the existing bookmark as it is.
+	MergeSkip MergeStrategy = "skip"
+	// MergeTags adds the tags of the entry to the existing bookmark.
+	MergeTags MergeStrategy = "merge-tags"
+	// MergeOverwrite replaces the title, description and tags of the existing
+	// bookmark with those of the entry.
+	MergeOverwrite MergeStrategy = "overwrite"
+	// MergeKeepBoth adds the entry as a new bookmark next to the existing one.
+	MergeKeepBoth MergeStrategy = "keep-both"
+)
+
+func ParseMergeStrategy(s string) (MergeStrategy, error) {
+	switch strategy := MergeStrategy(s); strategy {
+	case MergeSkip, MergeTags, MergeOverwrite, MergeKeepBoth:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("merge strategy must be one of skip, merge-tags, overwrite or keep-both, got %q", s)
+	}
+}
+
+type ImportBookmarksInput struct {
+	Entries []importer.Entry
+	// Strategy defaults to MergeSkip.
+	Strategy MergeStrategy
+	// DryRun classifies the entries without storing anything.
+	DryRun bool
+}
+
+// SkippedEntry is an entry that could not be imported and why.
+type SkippedEntry struct {
+	Entry importer.Entry
+	Err   error
+}
+
+// ImportMatch is an imported bookmark with the same canonical URL as a
+// bookmark that is already stored or imported earlier in the same run.
+type ImportMatch struct {
+	Imported bookmark.Bookmark
+	Existing bookmark.Bookmark
+}
+
+type ImportBookmarksOutput struct {
+	New []bookmark.Bookmark
+	// Duplicates add nothing to the existing bookmark: the title is the same,
+	// the tags are already there and the description is empty or the same.
+	Duplicates []ImportMatch
+	// Conflicts differ from the existing bookmark in title, description or
+	// tags.
+	Conflicts []ImportMatch
+	Skipped   []SkippedEntry
+	// Added and Updated are the bookmarks stored after applying the merge
+	// strategy. They are filled in on a dry run too.
+	Added   []bookmark.Bookmark
+	Updated []bookmark.Bookmark
+}
+
+type ImportBookmarks struct {
+	repo   bookmark.BulkAdder
+	policy *URLPolicy
+}
+
+func NewImportBookmarks(repo bookmark.BulkAdder, policy *URLPolicy) *ImportBookmarks {
+	return &ImportBookmarks{repo: repo, policy: policy}
+}
+
+// Execute sorts the entries into new ones, duplicates and conflicts by
+// canonical URL and stores the result in at most two writes. URLs go through
+// the URL policy like in AddBookmark; entries that are rejected, such as
+// bookmarklets with a javascript: URL, are skipped. The merge strategy is
+// applied to conflicts only, since merging a duplicate changes nothing and
+// keeping both would store the same bookmark twice.
+func (uc *ImportBookmarks) Execute(input ImportBookmarksInput) (ImportBookmarksOutput, error) {
+	strategy := input.Strategy
+	if strategy == "" {
+		strategy = MergeSkip
+	}
+	existing, err := uc.repo.List()
+	if err != nil {
+		return ImportBookmarksOutput{}, fmt.Errorf("failed to list bookmarks: %w", err)
+	}
+
+	var output ImportBookmarksOutput
+	plan := newImportPlan(existing)
+	now := time.Now()
+	for _, entry := range input.Entries {
+		bm, err := uc.bookmarkFromEntry(entry, now)
+		if err != nil {
+			output.Skipped = append(output.Skipped, SkippedEntry{Entry: entry, Err: err})
+			continue
+		}
+
+		i, ok := plan.lookup(bm.URL)
+		if !ok {
+			output.New = append(output.New, bm)
+			plan.add(bm)
+			continue
+		}
+		match := ImportMatch{Imported: bm, Existing: plan.bookmarks[i]}
+		if isDuplicateImport(bm, match.Existing) {
+			output.Duplicates = append(output.Duplicates, match)
+			continue
+		}
+		output.Conflicts = append(output.Conflicts, match)
+		plan.merge(i, bm, strategy, now)
+	}
+	output.Added, output.Updated = plan.added(), plan.updated()
+	if input.DryRun {
+		return output, nil
+	}
+
+	if len(output.Added) > 0 {
+		if err := uc.repo.AddAll(output.Added); err != nil {
+			return ImportBookmarksOutput{}, fmt.Errorf("failed to add bookmarks: %w", err)
+		}
+	}
+	if len(output.Updated) > 0 {
+		if err := uc.repo.UpdateAll(output.Updated); err != nil {
+			return ImportBookmarksOutput{}, fmt.Errorf("failed to update bookmarks: %w", err)
+		}
+	}
+	return output, nil
+}
+
+// isDuplicateImport reports whether imported adds nothing to existing.
+func isDuplicateImport(imported, existing bookmark.Bookmark) bool {
+	if imported.Title != existing.Title {
+		return false
+	}
+	if desc := imported.Description.Value(); desc != "" && desc != existing.Description.Value() {
+		return false
+	}
+	for _, tag := range imported.Tags {
+		if !slices.Contains(existing.Tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// importPlan holds the stored bookmarks followed by the ones being imported,
+// indexed by canonical URL.
+type importPlan struct {
+	bookmarks []bookmark.Bookmark
+	stored    int
+	index     map[string]int
+	changed   map[int]bool
+}
+
+func newImportPlan(existing []bookmark.Bookmark) *importPlan {
+	plan := &importPlan{
+		bookmarks: slices.Clone(existing),
+		stored:    len(existing),
+		index:     make(map[string]int, len(existing)),
+		changed:   map[int]bool{},
+	}
+	for i, bm := range existing {
+		if _, ok := plan.index[bm.URL.Canonical()]; !ok {
+			plan.index[bm.URL.Canonical()] = i
+		}
+	}
+	return plan
+}
+
+func (p *importPlan) lookup(url bookmark.BookmarkURL) (int, bool) {
+	i, ok := p.index[url.Canonical()]
+	return i, ok
+}
+
+func (p *importPlan) add(bm bookmark.Bookmark) {
+	if _, ok := p.index[bm.URL.Canonical()]; !ok {
+		p.index[bm.URL.Canonical()] = len(p.bookmarks)
+	}
+	p.bookmarks = append(p.bookmarks, bm)
+}
+
+func (p *importPlan) merge(i int, imported bookmark.Bookmark, strategy MergeStrategy, now time.Time) {
+	bm := p.bookmarks[i]
+	switch strategy {
+	case MergeSkip:
+		return
+	case MergeKeepBoth:
+		p.add(imported)
+		return
+	case MergeTags:
+		added := false
+		for _, tag := range imported.Tags {
+			if !slices.Contains(bm.Tags, tag) {
+				bm.Tags = append(slices.Clone(bm.Tags), tag)
+				added = true
+			}
+		}
+		if !added {
+			return
+		}
+	case MergeOverwrite:
+		bm.Title = imported.Title
+		bm.Description = imported.Description
+		bm.Tags = imported.Tags
+	}
+	bm.UpdatedAt = now
+	p.bookmarks[i] = bm
+	p.changed[i] = true
+}
+
+func (p *importPlan) added() []bookmark.Bookmark {
+	return slices.Clone(p.bookmarks[p.stored:])
+}
+
+func (p *importPlan) updated() []bookmark.Bookmark {
+	var updated []bookmark.Bookmark
+	for i := range p.stored {
+		if p.changed[i] {
+			updated = append(updated, p.bookmarks[i])
+		}
+	}
+	return updated
+}
+
+func (uc *ImportBookmarks) bookmarkFromEntry(entry importer.Entry, now time.Time) (bookmark.Bookmark, error) {
+	url, err := uc.policy.Apply(entry.URL)
+	if err != nil {
+		return bookmark.Bookmark{}, err
+	}
+
+	rawTitle := entry.Title
+	if rawTitle == "" {
+		rawTitle = url.Value()
+	}
+	title, err := bookmark.NewBookmarkTitle(rawTitle)
+	if err != nil {
+		return bookmark.Bookmark{}, fmt.Errorf("invalid title: %w", err)
+	}
+
+	var tags []bookmark.BookmarkTag
+	for _, name := range append([]string{entry.FolderTag()}, entry.Tags...) {
+		tag, err := bookmark.NewBookmarkTag(name)
+		if err == nil && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+
+	createdAt := entry.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = now
+	}
+	updatedAt := entry.UpdatedAt
+	if updatedAt.Before(createdAt) {
+		updatedAt = createdAt
+	}
+
+	desc := bookmark.NewBookmarkDescription(entry.Description)
+	return bookmark.NewBookmark(bookmark.GenerateBookmarkID(), url, title, desc, tags, createdAt, updatedAt), nil
+}
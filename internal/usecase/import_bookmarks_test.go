@@ -0,0 +1,227 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/bkm/internal/bookmark/bookmarktest"
+	"github.com/airRnot1106/bkm/internal/importer"
+	"github.com/airRnot1106/bkm/internal/secretscan"
+	"github.com/airRnot1106/bkm/internal/urlclean"
+	"github.com/airRnot1106/bkm/internal/usecase"
+)
+
+func newImportBookmarks(repo *mockRepositoryForCheck) *usecase.ImportBookmarks {
+	return usecase.NewImportBookmarks(repo, newURLPolicy(urlclean.BuiltinRules(), secretscan.PolicyRefuse))
+}
+
+func TestImportBookmarks_ConvertsEntries(t *testing.T) {
+	repo := &mockRepositoryForCheck{}
+	uc := newImportBookmarks(repo)
+	createdAt := time.Date(2017, 7, 14, 2, 40, 0, 0, time.UTC)
+
+	output, err := uc.Execute(usecase.ImportBookmarksInput{Entries: []importer.Entry{
+		{
+			URL:         "https://www.rust-lang.org/?utm_source=bar",
+			Title:       "Rust",
+			Description: "A language",
+			Folder:      []string{"Dev", "Rust"},
+			Tags:        []string{"lang", "Dev/Rust"},
+			CreatedAt:   createdAt,
+		},
+		{URL: "https://example.com/"},
+	}})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if len(output.New) != 2 || len(repo.bookmarks) != 2 {
+		t.Fatalf("expected 2 imported bookmarks, got %d (stored %d)", len(output.New), len(repo.bookmarks))
+	}
+	rust := output.New[0]
+	if rust.URL.Value() != "https://www.rust-lang.org/" {
+		t.Errorf("expected a cleaned URL, got %s", rust.URL.Value())
+	}
+	if len(rust.Tags) != 2 || rust.Tags[0].Value() != "Dev/Rust" || rust.Tags[1].Value() != "lang" {
+		t.Errorf("expected tags Dev/Rust and lang, got %v", rust.Tags)
+	}
+	if !rust.CreatedAt.Equal(createdAt) || !rust.UpdatedAt.Equal(createdAt) {
+		t.Errorf("expected times %v, got %v and %v", createdAt, rust.CreatedAt, rust.UpdatedAt)
+	}
+	if example := output.New[1]; example.Title.Value() != "https://example.com/" || len(example.Tags) != 0 {
+		t.Errorf("expected the URL as title and no tags, got %q %v", example.Title.Value(), example.Tags)
+	}
+}
+
+func TestImportBookmarks_SkipsInvalidEntries(t *testing.T) {
+	repo := &mockRepositoryForCheck{}
+	uc := newImportBookmarks(repo)
+
+	output, err := uc.Execute(usecase.ImportBookmarksInput{Entries: []importer.Entry{
+		{URL: "javascript:alert(1)", Title: "Bookmarklet"},
+		{URL: "https://example.com/feed?token=abc", Title: "Feed"},
+	}})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if len(output.New) != 0 || len(repo.bookmarks) != 0 {
+		t.Errorf("expected nothing imported, got %d", len(output.New))
+	}
+	if len(output.Skipped) != 2 {
+		t.Fatalf("expected 2 skipped entries, got %d", len(output.Skipped))
+	}
+	if !errors.Is(output.Skipped[1].Err, secretscan.ErrSecretFound) {
+		t.Errorf("expected ErrSecretFound, got %v", output.Skipped[1].Err)
+	}
+}
+
+func TestImportBookmarks_ClassifiesByCanonicalURL(t *testing.T) {
+	repo := &mockRepositoryForCheck{}
+	repo.Add(bookmarktest.New(t, "https://example.com/docs", "docs"))
+	repo.Add(bookmarktest.New(t, "https://example.com/blog", "blog"))
+	uc := newImportBookmarks(repo)
+
+	output, err := uc.Execute(usecase.ImportBookmarksInput{Entries: []importer.Entry{
+		{URL: "https://EXAMPLE.com/docs/", Title: "https://example.com/docs", Tags: []string{"docs"}},
+		{URL: "https://example.com/blog", Title: "Blog", Tags: []string{"news"}},
+		{URL: "https://example.com/new", Title: "New"},
+		{URL: "https://example.com/new/", Title: "New"},
+	}})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if len(output.New) != 1 || output.New[0].URL.Value() != "https://example.com/new" {
+		t.Errorf("expected 1 new bookmark, got %v", output.New)
+	}
+	if len(output.Duplicates) != 2 {
+		t.Errorf("expected 2 duplicates, got %d", len(output.Duplicates))
+	}
+	if len(output.Conflicts) != 1 || output.Conflicts[0].Existing.ID != repo.bookmarks[1].ID {
+		t.Errorf("expected a conflict with the blog bookmark, got %v", output.Conflicts)
+	}
+	if len(output.Added) != 1 || len(output.Updated) != 0 || repo.updated != nil {
+		t.Errorf("expected 1 added and nothing updated, got %d and %d", len(output.Added), len(output.Updated))
+	}
+}
+
+func TestImportBookmarks_MergeStrategies(t *testing.T) {
+	tests := []struct {
+		strategy     usecase.MergeStrategy
+		added        int
+		updated      bool
+		expectTitle  string
+		expectedTags []string
+	}{
+		{usecase.MergeSkip, 0, false, "https://example.com/blog", []string{"blog"}},
+		{usecase.MergeTags, 0, true, "https://example.com/blog", []string{"blog", "news"}},
+		{usecase.MergeOverwrite, 0, true, "Blog", []string{"news"}},
+		{usecase.MergeKeepBoth, 1, false, "https://example.com/blog", []string{"blog"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			repo := &mockRepositoryForCheck{}
+			existing := bookmarktest.New(t, "https://example.com/blog", "blog")
+			repo.Add(existing)
+			uc := newImportBookmarks(repo)
+
+			output, err := uc.Execute(usecase.ImportBookmarksInput{
+				Entries:  []importer.Entry{{URL: "https://example.com/blog", Title: "Blog", Tags: []string{"news"}}},
+				Strategy: tt.strategy,
+			})
+			if err != nil {
+				t.Fatalf("expected success, got error: %v", err)
+			}
+
+			if len(output.Added) != tt.added || len(repo.bookmarks) != 1+tt.added {
+				t.Errorf("expected %d added, got %d (stored %d)", tt.added, len(output.Added), len(repo.bookmarks))
+			}
+			if !tt.updated {
+				if len(output.Updated) != 0 || repo.updated != nil {
+					t.Fatalf("expected nothing updated, got %v", output.Updated)
+				}
+				return
+			}
+			if len(repo.updated) != 1 {
+				t.Fatalf("expected 1 updated bookmark, got %d", len(repo.updated))
+			}
+			bm := repo.updated[0]
+			if bm.ID != existing.ID || bm.Title.Value() != tt.expectTitle {
+				t.Errorf("expected %s titled %q, got %s titled %q", existing.ID.Value(), tt.expectTitle, bm.ID.Value(), bm.Title.Value())
+			}
+			if len(bm.Tags) != len(tt.expectedTags) {
+				t.Fatalf("expected tags %v, got %v", tt.expectedTags, bm.Tags)
+			}
+			for _, name := range tt.expectedTags {
+				if !hasTag(bm, name) {
+					t.Errorf("expected tag %s, got %v", name, bm.Tags)
+				}
+			}
+		})
+	}
+}
+
+func TestImportBookmarks_MergesWithinTheImport(t *testing.T) {
+	repo := &mockRepositoryForCheck{}
+	uc := newImportBookmarks(repo)
+
+	output, err := uc.Execute(usecase.ImportBookmarksInput{
+		Entries: []importer.Entry{
+			{URL: "https://example.com/", Title: "Example", Tags: []string{"a"}},
+			{URL: "https://example.com", Title: "Example", Tags: []string{"b"}},
+		},
+		Strategy: usecase.MergeTags,
+	})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if len(output.Conflicts) != 1 || len(output.Added) != 1 || repo.updated != nil {
+		t.Fatalf("expected 1 conflict merged into 1 added bookmark, got %d and %d", len(output.Conflicts), len(output.Added))
+	}
+	if tags := output.Added[0].Tags; len(tags) != 2 {
+		t.Errorf("expected tags a and b, got %v", tags)
+	}
+}
+
+func TestImportBookmarks_DryRunStoresNothing(t *testing.T) {
+	repo := &mockRepositoryForCheck{}
+	repo.Add(bookmarktest.New(t, "https://example.com/blog", "blog"))
+	uc := newImportBookmarks(repo)
+
+	output, err := uc.Execute(usecase.ImportBookmarksInput{
+		Entries: []importer.Entry{
+			{URL: "https://example.com/blog", Title: "Blog"},
+			{URL: "https://example.com/new", Title: "New"},
+		},
+		Strategy: usecase.MergeOverwrite,
+		DryRun:   true,
+	})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if len(output.Added) != 1 || len(output.Updated) != 1 {
+		t.Errorf("expected 1 added and 1 updated, got %d and %d", len(output.Added), len(output.Updated))
+	}
+	if len(repo.bookmarks) != 1 || repo.updated != nil {
+		t.Errorf("dry run should not store anything, got %d bookmarks and %v updated", len(repo.bookmarks), repo.updated)
+	}
+	if repo.bookmarks[0].Title.Value() != "https://example.com/blog" {
+		t.Errorf("dry run should not change the existing bookmark, got %q", repo.bookmarks[0].Title.Value())
+	}
+}
+
+func TestParseMergeStrategy(t *testing.T) {
+	for _, s := range []string{"skip", "merge-tags", "overwrite", "keep-both"} {
+		if strategy, err := usecase.ParseMergeStrategy(s); err != nil || string(strategy) != s {
+			t.Errorf("expected %s to parse, got %q, %v", s, strategy, err)
+		}
+	}
+	if _, err := usecase.ParseMergeStrategy("replace"); err == nil {
+		t.Error("expected an unknown strategy to fail")
+	}
+}
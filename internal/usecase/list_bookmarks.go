@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+)
+
+type ListBookmarksInput struct {
+	// Tags limit the result to bookmarks that have all of them.
+	Tags []string
+}
+
+type ListBookmarks struct {
+	repo bookmark.Repository
+}
+
+func NewListBookmarks(repo bookmark.Repository) *ListBookmarks {
+	return &ListBookmarks{repo: repo}
+}
+
+func (uc *ListBookmarks) Execute(input ListBookmarksInput) ([]bookmark.Bookmark, error) {
+	targetTags := make([]bookmark.BookmarkTag, 0, len(input.Tags))
+	for i, t := range input.Tags {
+		tag, err := bookmark.NewBookmarkTag(t)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tag at index %d: %w", i, err)
+		}
+		targetTags = append(targetTags, tag)
+	}
+
+	bookmarks, err := uc.repo.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bookmarks: %w", err)
+	}
+
+	var matched []bookmark.Bookmark
+	for _, bm := range bookmarks {
+		if hasAllTags(bm, targetTags) {
+			matched = append(matched, bm)
+		}
+	}
+
+	return matched, nil
+}
+
+func hasAllTags(bm bookmark.Bookmark, tags []bookmark.BookmarkTag) bool {
+	tagSet := make(map[string]struct{}, len(bm.Tags))
+	for _, tag := range bm.Tags {
+		tagSet[tag.Value()] = struct{}{}
+	}
+	for _, target := range tags {
+		if _, ok := tagSet[target.Value()]; !ok {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,70 @@
+package usecase_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/bookmark/bookmarktest"
+	"github.com/airRnot1106/bkm/internal/usecase"
+)
+
+type mockRepositoryForList struct {
+	bookmarks []bookmark.Bookmark
+}
+
+func (m *mockRepositoryForList) Add(bm bookmark.Bookmark) error {
+	m.bookmarks = append(m.bookmarks, bm)
+	return nil
+}
+
+func (m *mockRepositoryForList) List() ([]bookmark.Bookmark, error) {
+	return m.bookmarks, nil
+}
+
+func (m *mockRepositoryForList) Update(bm bookmark.Bookmark) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (m *mockRepositoryForList) Delete(id bookmark.BookmarkID) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (m *mockRepositoryForList) UpdateAll(bms []bookmark.Bookmark) error {
+	return fmt.Errorf("not implemented")
+}
+
+func TestListBookmarks_FiltersByAllTags(t *testing.T) {
+	repo := &mockRepositoryForList{}
+	for i, tagNames := range [][]string{{"morning", "work"}, {"morning"}, {"work"}, nil} {
+		repo.Add(bookmarktest.New(t, fmt.Sprintf("https://example.com/%d", i), tagNames...))
+	}
+	uc := usecase.NewListBookmarks(repo)
+
+	tests := []struct {
+		tags     []string
+		expected int
+	}{
+		{nil, 4},
+		{[]string{"morning"}, 2},
+		{[]string{"morning", "work"}, 1},
+		{[]string{"evening"}, 0},
+	}
+	for _, tt := range tests {
+		got, err := uc.Execute(usecase.ListBookmarksInput{Tags: tt.tags})
+		if err != nil {
+			t.Fatalf("Execute(%v) should succeed: %v", tt.tags, err)
+		}
+		if len(got) != tt.expected {
+			t.Errorf("Execute(%v): expected %d bookmarks, got %d", tt.tags, tt.expected, len(got))
+		}
+	}
+}
+
+func TestListBookmarks_InvalidTagFails(t *testing.T) {
+	uc := usecase.NewListBookmarks(&mockRepositoryForList{})
+
+	if _, err := uc.Execute(usecase.ListBookmarksInput{Tags: []string{" "}}); err == nil {
+		t.Fatal("expected error for empty tag")
+	}
+}
@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/history"
+)
+
+// RecentBookmark is a URL from the history with the bookmark it was opened from.
+type RecentBookmark struct {
+	Bookmark bookmark.Bookmark
+	URL      bookmark.BookmarkURL
+	OpenedAt time.Time
+}
+
+type ListRecentBookmarks struct {
+	repo    bookmark.Repository
+	history history.Repository
+}
+
+func NewListRecentBookmarks(repo bookmark.Repository, history history.Repository) *ListRecentBookmarks {
+	return &ListRecentBookmarks{repo: repo, history: history}
+}
+
+// Execute returns the opened URLs from the most recently opened one, each URL
+// once. Bookmarks that were deleted since are replaced by one titled with the
+// URL itself.
+func (uc *ListRecentBookmarks) Execute() ([]RecentBookmark, error) {
+	entries, err := uc.history.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list history: %w", err)
+	}
+
+	bookmarks, err := uc.repo.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bookmarks: %w", err)
+	}
+	byID := make(map[bookmark.BookmarkID]bookmark.Bookmark, len(bookmarks))
+	for _, bm := range bookmarks {
+		byID[bm.ID] = bm
+	}
+
+	seen := make(map[bookmark.BookmarkURL]struct{}, len(entries))
+	var recent []RecentBookmark
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		if _, ok := seen[entry.URL]; ok {
+			continue
+		}
+		seen[entry.URL] = struct{}{}
+
+		bm, ok := byID[entry.BookmarkID]
+		if !ok {
+			title, err := bookmark.NewBookmarkTitle(entry.URL.Value())
+			if err != nil {
+				return nil, fmt.Errorf("invalid title: %w", err)
+			}
+			bm = bookmark.NewBookmark(entry.BookmarkID, entry.URL, title, bookmark.NewBookmarkDescription(""), nil, entry.OpenedAt, entry.OpenedAt)
+		}
+		recent = append(recent, RecentBookmark{Bookmark: bm, URL: entry.URL, OpenedAt: entry.OpenedAt})
+	}
+
+	return recent, nil
+}
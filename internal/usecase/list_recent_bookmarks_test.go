@@ -0,0 +1,84 @@
+package usecase_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/bookmark/bookmarktest"
+	"github.com/airRnot1106/bkm/internal/history"
+	"github.com/airRnot1106/bkm/internal/usecase"
+)
+
+type mockHistoryForRecent struct {
+	entries []history.Entry
+}
+
+func (m *mockHistoryForRecent) Append(entry history.Entry) error {
+	m.entries = append(m.entries, entry)
+	return nil
+}
+
+func (m *mockHistoryForRecent) List() ([]history.Entry, error) {
+	return m.entries, nil
+}
+
+func (m *mockHistoryForRecent) Prune(before time.Time) error {
+	return fmt.Errorf("not implemented")
+}
+
+func TestListRecentBookmarks_NewestFirstWithoutDuplicates(t *testing.T) {
+	repo := &mockRepositoryForList{}
+	docs, issues := bookmarktest.New(t, "https://status.example.com/1"), bookmarktest.New(t, "https://status.example.com/2")
+	repo.Add(docs)
+	repo.Add(issues)
+
+	filled, _ := bookmark.NewBookmarkURL("https://status.example.com/2?q=outage")
+	now := time.Now()
+	hist := &mockHistoryForRecent{entries: []history.Entry{
+		history.NewEntry(docs.ID, docs.URL, now.Add(-3*time.Hour)),
+		history.NewEntry(issues.ID, filled, now.Add(-2*time.Hour)),
+		history.NewEntry(docs.ID, docs.URL, now.Add(-time.Hour)),
+	}}
+	uc := usecase.NewListRecentBookmarks(repo, hist)
+
+	recent, err := uc.Execute()
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if len(recent) != 2 {
+		t.Fatalf("expected 2 bookmarks, got %d", len(recent))
+	}
+	if recent[0].Bookmark.ID != docs.ID || recent[1].Bookmark.ID != issues.ID {
+		t.Errorf("expected newest first, got %s then %s", recent[0].Bookmark.Title.Value(), recent[1].Bookmark.Title.Value())
+	}
+	if recent[1].URL != filled {
+		t.Errorf("expected the opened URL %q, got %q", filled.Value(), recent[1].URL.Value())
+	}
+	if recent[1].Bookmark.URL != issues.URL {
+		t.Errorf("expected the bookmark to keep its URL %q, got %q", issues.URL.Value(), recent[1].Bookmark.URL.Value())
+	}
+}
+
+func TestListRecentBookmarks_DeletedBookmarksAreTitledWithTheirURL(t *testing.T) {
+	repo := &mockRepositoryForList{}
+	url, _ := bookmark.NewBookmarkURL("https://example.com/deleted")
+	hist := &mockHistoryForRecent{entries: []history.Entry{
+		history.NewEntry(bookmark.GenerateBookmarkID(), url, time.Now()),
+	}}
+	uc := usecase.NewListRecentBookmarks(repo, hist)
+
+	recent, err := uc.Execute()
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if len(recent) != 1 || recent[0].URL != url {
+		t.Fatalf("expected the deleted bookmark, got %v", recent)
+	}
+	if recent[0].Bookmark.Title.Value() != url.Value() {
+		t.Errorf("expected deleted bookmark to be titled with its URL, got %q", recent[0].Bookmark.Title.Value())
+	}
+}
@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/airRnot1106/bkm/internal/session"
+)
+
+type ListSessions struct {
+	sessions session.Repository
+}
+
+func NewListSessions(sessions session.Repository) *ListSessions {
+	return &ListSessions{sessions: sessions}
+}
+
+func (uc *ListSessions) Execute() ([]session.Session, error) {
+	sessions, err := uc.sessions.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	return sessions, nil
+}
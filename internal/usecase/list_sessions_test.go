@@ -0,0 +1,23 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/session/sessiontest"
+	"github.com/airRnot1106/bkm/internal/usecase"
+)
+
+func TestListSessions_ReturnsAllSessions(t *testing.T) {
+	repo := &mockSessionRepository{}
+	repo.Add(sessiontest.New(t, "daily"))
+	repo.Add(sessiontest.New(t, "incident-response"))
+	uc := usecase.NewListSessions(repo)
+
+	sessions, err := uc.Execute()
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("expected 2 sessions, got %d", len(sessions))
+	}
+}
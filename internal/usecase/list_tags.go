@@ -0,0 +1,25 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+)
+
+type ListTags struct {
+	repo bookmark.Repository
+}
+
+func NewListTags(repo bookmark.Repository) *ListTags {
+	return &ListTags{repo: repo}
+}
+
+// Execute returns the tags in use with the number of bookmarks that have
+// them, most used first.
+func (uc *ListTags) Execute() ([]bookmark.TagCount, error) {
+	bookmarks, err := uc.repo.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bookmarks: %w", err)
+	}
+	return bookmark.CountTags(bookmarks), nil
+}
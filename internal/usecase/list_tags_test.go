@@ -0,0 +1,30 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/bookmark/bookmarktest"
+	"github.com/airRnot1106/bkm/internal/usecase"
+)
+
+func TestListTags_CountsTags(t *testing.T) {
+	repo := &mockRepositoryForCheck{}
+	repo.Add(bookmarktest.New(t, "https://go.dev/doc", "go", "docs"))
+	repo.Add(bookmarktest.New(t, "https://pkg.go.dev/", "go"))
+	uc := usecase.NewListTags(repo)
+
+	counts, err := uc.Execute()
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if len(counts) != 2 {
+		t.Fatalf("expected 2 tags, got %d", len(counts))
+	}
+	if counts[0].Tag.Value() != "go" || counts[0].Count != 2 {
+		t.Errorf("expected go used twice first, got %s (%d)", counts[0].Tag.Value(), counts[0].Count)
+	}
+	if counts[1].Tag.Value() != "docs" || counts[1].Count != 1 {
+		t.Errorf("expected docs used once, got %s (%d)", counts[1].Tag.Value(), counts[1].Count)
+	}
+}
@@ -1,22 +1,118 @@
 package usecase
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/history"
 	"github.com/airRnot1106/bkm/internal/opener"
 )
 
 type OpenBookmarkInput struct {
 	Bookmark bookmark.Bookmark
+	// Variant names the URL variant to open instead of the primary URL.
+	Variant string
+	// Args fill the placeholders when the bookmark URL is a template.
+	Args []string
+	// KeepStatus leaves a queued bookmark in the queue instead of marking it done.
+	KeepStatus bool
+	// URL, when set, is opened instead of the bookmark URL, e.g. to reopen the
+	// exact URL from the history. Variant and Args are ignored then.
+	URL bookmark.BookmarkURL
+}
+
+type OpenBookmarkOutput struct {
+	// HistoryErr is set when the bookmark was opened but the open could not be
+	// recorded in the history.
+	HistoryErr error
 }
 
 type OpenBookmark struct {
-	opener opener.Opener
+	repo    bookmark.Repository
+	opener  opener.Opener
+	history history.Repository
 }
 
-func NewOpenBookmark(opener opener.Opener) *OpenBookmark {
-	return &OpenBookmark{opener: opener}
+func NewOpenBookmark(repo bookmark.Repository, opener opener.Opener, history history.Repository) *OpenBookmark {
+	return &OpenBookmark{repo: repo, opener: opener, history: history}
 }
 
-func (uc *OpenBookmark) Execute(input OpenBookmarkInput) error {
-	return uc.opener.Open(input.Bookmark)
+// Execute opens the bookmark. Once it is open, failing to record it in the
+// history is not an error and is returned in the output instead.
+func (uc *OpenBookmark) Execute(input OpenBookmarkInput) (OpenBookmarkOutput, error) {
+	target, err := resolveOpenTarget(input)
+	if err != nil {
+		return OpenBookmarkOutput{}, err
+	}
+
+	if err := uc.opener.Open(target); err != nil {
+		return OpenBookmarkOutput{}, err
+	}
+
+	output := OpenBookmarkOutput{HistoryErr: recordOpen(uc.history, target)}
+	return output, markOpened(uc.repo, input.Bookmark, input.KeepStatus)
+}
+
+// resolveOpenTarget returns the bookmark with its URL replaced by the chosen
+// variant and the template filled.
+func resolveOpenTarget(input OpenBookmarkInput) (bookmark.Bookmark, error) {
+	target := input.Bookmark
+	if input.URL != (bookmark.BookmarkURL{}) {
+		target.URL = input.URL
+		return target, nil
+	}
+
+	if input.Variant != "" {
+		name, err := bookmark.NewBookmarkVariantName(input.Variant)
+		if err != nil {
+			return bookmark.Bookmark{}, fmt.Errorf("invalid variant: %w", err)
+		}
+		if target.URL, err = target.URLFor(name); err != nil {
+			return bookmark.Bookmark{}, err
+		}
+	}
+
+	if target.URL.IsTemplate() {
+		filled, err := target.URL.Fill(input.Args)
+		if err != nil {
+			return bookmark.Bookmark{}, fmt.Errorf("failed to fill URL template: %w", err)
+		}
+		target.URL = filled
+	} else if len(input.Args) > 0 {
+		return bookmark.Bookmark{}, fmt.Errorf("cannot use arguments: %w", bookmark.ErrNotTemplate)
+	}
+
+	return target, nil
+}
+
+// recordOpen appends the opened URL to the history.
+func recordOpen(h history.Repository, target bookmark.Bookmark) error {
+	if err := h.Append(history.NewEntry(target.ID, target.URL, time.Now())); err != nil {
+		return fmt.Errorf("failed to record history: %w", err)
+	}
+	return nil
+}
+
+// markOpened moves a queued bookmark forward in the read-later queue.
+func markOpened(repo bookmark.Repository, bm bookmark.Bookmark, keepStatus bool) error {
+	if !bm.Status.IsQueued() {
+		return nil
+	}
+
+	next := bookmark.StatusDone
+	if keepStatus {
+		next = bookmark.StatusReading
+	}
+	if bm.Status == next {
+		return nil
+	}
+
+	bm.Status = next
+	bm.UpdatedAt = time.Now()
+	if err := repo.Update(bm); err != nil {
+		return fmt.Errorf("failed to update bookmark status: %w", err)
+	}
+
+	return nil
 }
@@ -1,13 +1,46 @@
 package usecase_test
 
 import (
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/bookmark/bookmarktest"
+	"github.com/airRnot1106/bkm/internal/history"
 	"github.com/airRnot1106/bkm/internal/usecase"
 )
 
+type mockRepositoryForOpen struct {
+	updateFunc func(bookmark.Bookmark) error
+	updated    []bookmark.Bookmark
+}
+
+func (m *mockRepositoryForOpen) Add(bm bookmark.Bookmark) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (m *mockRepositoryForOpen) List() ([]bookmark.Bookmark, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *mockRepositoryForOpen) Update(bm bookmark.Bookmark) error {
+	if m.updateFunc != nil {
+		return m.updateFunc(bm)
+	}
+	m.updated = append(m.updated, bm)
+	return nil
+}
+
+func (m *mockRepositoryForOpen) Delete(id bookmark.BookmarkID) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (m *mockRepositoryForOpen) UpdateAll(bms []bookmark.Bookmark) error {
+	return fmt.Errorf("not implemented")
+}
+
 type mockOpenerForOpener struct {
 	openFunc func(bookmark.Bookmark) error
 }
@@ -19,9 +52,31 @@ func (m *mockOpenerForOpener) Open(bm bookmark.Bookmark) error {
 	return nil
 }
 
+type mockHistoryForOpen struct {
+	appendFunc func(history.Entry) error
+	entries    []history.Entry
+}
+
+func (m *mockHistoryForOpen) Append(entry history.Entry) error {
+	if m.appendFunc != nil {
+		return m.appendFunc(entry)
+	}
+	m.entries = append(m.entries, entry)
+	return nil
+}
+
+func (m *mockHistoryForOpen) List() ([]history.Entry, error) {
+	return m.entries, nil
+}
+
+func (m *mockHistoryForOpen) Prune(before time.Time) error {
+	return fmt.Errorf("not implemented")
+}
+
 func TestOpenBookmark_Success(t *testing.T) {
+	repo := &mockRepositoryForOpen{}
 	opener := &mockOpenerForOpener{}
-	uc := usecase.NewOpenBookmark(opener)
+	uc := usecase.NewOpenBookmark(repo, opener, &mockHistoryForOpen{})
 
 	url, _ := bookmark.NewBookmarkURL("https://example.com")
 	title, _ := bookmark.NewBookmarkTitle("Example")
@@ -32,31 +87,37 @@ func TestOpenBookmark_Success(t *testing.T) {
 		Bookmark: bm,
 	}
 
-	err := uc.Execute(input)
+	_, err := uc.Execute(input)
 	if err != nil {
 		t.Fatalf("expected success, got error: %v", err)
 	}
+
+	if len(repo.updated) != 0 {
+		t.Errorf("expected bookmark outside the queue not to be updated, got %d updates", len(repo.updated))
+	}
 }
 
 func TestOpenBookmark_OpenerError(t *testing.T) {
+	repo := &mockRepositoryForOpen{}
 	expectedErr := fmt.Errorf("failed to open browser")
 	opener := &mockOpenerForOpener{
 		openFunc: func(bm bookmark.Bookmark) error {
 			return expectedErr
 		},
 	}
-	uc := usecase.NewOpenBookmark(opener)
+	uc := usecase.NewOpenBookmark(repo, opener, &mockHistoryForOpen{})
 
 	url, _ := bookmark.NewBookmarkURL("https://example.com")
 	title, _ := bookmark.NewBookmarkTitle("Example")
 	desc := bookmark.NewBookmarkDescription("Example description")
 	bm := bookmark.CreateBookmark(url, title, desc, []bookmark.BookmarkTag{})
+	bm.Status = bookmark.StatusUnread
 
 	input := usecase.OpenBookmarkInput{
 		Bookmark: bm,
 	}
 
-	err := uc.Execute(input)
+	_, err := uc.Execute(input)
 	if err == nil {
 		t.Fatalf("expected error, got success")
 	}
@@ -64,4 +125,293 @@ func TestOpenBookmark_OpenerError(t *testing.T) {
 	if err != expectedErr {
 		t.Errorf("expected error %v, got %v", expectedErr, err)
 	}
+
+	if len(repo.updated) != 0 {
+		t.Errorf("expected no status update when opening fails, got %d updates", len(repo.updated))
+	}
+}
+
+func TestOpenBookmark_StatusTransition(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     bookmark.BookmarkStatus
+		keepStatus bool
+		expected   bookmark.BookmarkStatus
+		updated    bool
+	}{
+		{
+			name:     "unread becomes done",
+			status:   bookmark.StatusUnread,
+			expected: bookmark.StatusDone,
+			updated:  true,
+		},
+		{
+			name:     "reading becomes done",
+			status:   bookmark.StatusReading,
+			expected: bookmark.StatusDone,
+			updated:  true,
+		},
+		{
+			name:       "unread becomes reading when status is kept",
+			status:     bookmark.StatusUnread,
+			keepStatus: true,
+			expected:   bookmark.StatusReading,
+			updated:    true,
+		},
+		{
+			name:       "reading stays reading when status is kept",
+			status:     bookmark.StatusReading,
+			keepStatus: true,
+			updated:    false,
+		},
+		{
+			name:    "done is left untouched",
+			status:  bookmark.StatusDone,
+			updated: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockRepositoryForOpen{}
+			uc := usecase.NewOpenBookmark(repo, &mockOpenerForOpener{}, &mockHistoryForOpen{})
+
+			bm := bookmarktest.New(t, "https://example.com")
+			bm.Status = tt.status
+
+			_, err := uc.Execute(usecase.OpenBookmarkInput{Bookmark: bm, KeepStatus: tt.keepStatus})
+			if err != nil {
+				t.Fatalf("expected success, got error: %v", err)
+			}
+
+			if !tt.updated {
+				if len(repo.updated) != 0 {
+					t.Fatalf("expected no update, got %d", len(repo.updated))
+				}
+				return
+			}
+
+			if len(repo.updated) != 1 {
+				t.Fatalf("expected 1 update, got %d", len(repo.updated))
+			}
+			if repo.updated[0].Status != tt.expected {
+				t.Errorf("expected status %q, got %q", tt.expected.Value(), repo.updated[0].Status.Value())
+			}
+		})
+	}
+}
+
+func TestOpenBookmark_RepositoryUpdateError(t *testing.T) {
+	repo := &mockRepositoryForOpen{
+		updateFunc: func(bm bookmark.Bookmark) error {
+			return fmt.Errorf("disk full")
+		},
+	}
+	uc := usecase.NewOpenBookmark(repo, &mockOpenerForOpener{}, &mockHistoryForOpen{})
+
+	bm := bookmarktest.New(t, "https://example.com")
+	bm.Status = bookmark.StatusUnread
+
+	_, err := uc.Execute(usecase.OpenBookmarkInput{Bookmark: bm})
+	if err == nil {
+		t.Fatalf("expected error, got success")
+	}
+}
+
+func TestOpenBookmark_TemplateIsFilledBeforeOpening(t *testing.T) {
+	repo := &mockRepositoryForOpen{}
+	var opened bookmark.Bookmark
+	opener := &mockOpenerForOpener{
+		openFunc: func(bm bookmark.Bookmark) error {
+			opened = bm
+			return nil
+		},
+	}
+	uc := usecase.NewOpenBookmark(repo, opener, &mockHistoryForOpen{})
+
+	bm := bookmarktest.New(t, "https://pkg.go.dev/search?q=%s")
+	bm.Status = bookmark.StatusUnread
+
+	_, err := uc.Execute(usecase.OpenBookmarkInput{Bookmark: bm, Args: []string{"http", "client"}})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if opened.URL.Value() != "https://pkg.go.dev/search?q=http+client" {
+		t.Errorf("unexpected opened URL: %q", opened.URL.Value())
+	}
+	if len(repo.updated) != 1 {
+		t.Fatalf("expected 1 update, got %d", len(repo.updated))
+	}
+	if repo.updated[0].URL != bm.URL {
+		t.Errorf("stored URL should stay a template, got %q", repo.updated[0].URL.Value())
+	}
+}
+
+func TestOpenBookmark_TemplateWithoutArgsFails(t *testing.T) {
+	opened := false
+	opener := &mockOpenerForOpener{
+		openFunc: func(bm bookmark.Bookmark) error {
+			opened = true
+			return nil
+		},
+	}
+	uc := usecase.NewOpenBookmark(&mockRepositoryForOpen{}, opener, &mockHistoryForOpen{})
+
+	bm := bookmarktest.New(t, "https://jira.example.com/browse/%s")
+
+	_, err := uc.Execute(usecase.OpenBookmarkInput{Bookmark: bm})
+	if !errors.Is(err, bookmark.ErrMissingTemplateArgs) {
+		t.Fatalf("expected ErrMissingTemplateArgs, got %v", err)
+	}
+	if opened {
+		t.Errorf("opener should not be called")
+	}
+}
+
+func TestOpenBookmark_ArgsForPlainURLFail(t *testing.T) {
+	uc := usecase.NewOpenBookmark(&mockRepositoryForOpen{}, &mockOpenerForOpener{}, &mockHistoryForOpen{})
+
+	bm := bookmarktest.New(t, "https://example.com")
+
+	_, err := uc.Execute(usecase.OpenBookmarkInput{Bookmark: bm, Args: []string{"query"}})
+	if !errors.Is(err, bookmark.ErrNotTemplate) {
+		t.Fatalf("expected ErrNotTemplate, got %v", err)
+	}
+}
+
+func TestOpenBookmark_VariantIsOpened(t *testing.T) {
+	var opened bookmark.Bookmark
+	opener := &mockOpenerForOpener{
+		openFunc: func(bm bookmark.Bookmark) error {
+			opened = bm
+			return nil
+		},
+	}
+	uc := usecase.NewOpenBookmark(&mockRepositoryForOpen{}, opener, &mockHistoryForOpen{})
+
+	prodURL, _ := bookmark.NewBookmarkURL("https://grafana.example.com")
+	prod, _ := bookmark.NewBookmarkVariantName("prod")
+	bm := bookmarktest.New(t, "https://grafana.dev.example.com")
+	bm.Variants = map[bookmark.BookmarkVariantName]bookmark.BookmarkURL{prod: prodURL}
+
+	if _, err := uc.Execute(usecase.OpenBookmarkInput{Bookmark: bm, Variant: "prod"}); err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if opened.URL != prodURL {
+		t.Errorf("expected variant URL %q, got %q", prodURL.Value(), opened.URL.Value())
+	}
+}
+
+func TestOpenBookmark_UnknownVariantFails(t *testing.T) {
+	uc := usecase.NewOpenBookmark(&mockRepositoryForOpen{}, &mockOpenerForOpener{}, &mockHistoryForOpen{})
+
+	bm := bookmarktest.New(t, "https://grafana.dev.example.com")
+
+	_, err := uc.Execute(usecase.OpenBookmarkInput{Bookmark: bm, Variant: "staging"})
+	if !errors.Is(err, bookmark.ErrUnknownVariant) {
+		t.Fatalf("expected ErrUnknownVariant, got %v", err)
+	}
+}
+
+func TestOpenBookmark_RecordsOpenedURLInHistory(t *testing.T) {
+	repo := &mockRepositoryForOpen{}
+	opener := &mockOpenerForOpener{}
+	hist := &mockHistoryForOpen{}
+	uc := usecase.NewOpenBookmark(repo, opener, hist)
+
+	bm := bookmarktest.New(t, "https://pkg.go.dev/search?q=%s")
+
+	before := time.Now()
+	if _, err := uc.Execute(usecase.OpenBookmarkInput{Bookmark: bm, Args: []string{"http"}}); err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if len(hist.entries) != 1 {
+		t.Fatalf("expected 1 history entry, got %d", len(hist.entries))
+	}
+	entry := hist.entries[0]
+	if entry.BookmarkID != bm.ID {
+		t.Errorf("expected bookmark ID %s, got %s", bm.ID.Value(), entry.BookmarkID.Value())
+	}
+	if entry.URL.Value() != "https://pkg.go.dev/search?q=http" {
+		t.Errorf("expected the filled URL, got %q", entry.URL.Value())
+	}
+	if entry.OpenedAt.Before(before) {
+		t.Errorf("expected the time of opening, got %v", entry.OpenedAt)
+	}
+}
+
+func TestOpenBookmark_FailedOpenIsNotRecorded(t *testing.T) {
+	repo := &mockRepositoryForOpen{}
+	opener := &mockOpenerForOpener{
+		openFunc: func(bm bookmark.Bookmark) error {
+			return errors.New("no browser")
+		},
+	}
+	hist := &mockHistoryForOpen{}
+	uc := usecase.NewOpenBookmark(repo, opener, hist)
+
+	bm := bookmarktest.New(t, "https://example.com")
+
+	if _, err := uc.Execute(usecase.OpenBookmarkInput{Bookmark: bm}); err == nil {
+		t.Fatal("expected error")
+	}
+	if len(hist.entries) != 0 {
+		t.Errorf("expected no history entry, got %d", len(hist.entries))
+	}
+}
+
+func TestOpenBookmark_HistoryFailureIsAWarning(t *testing.T) {
+	repo := &mockRepositoryForOpen{}
+	historyErr := errors.New("disk full")
+	hist := &mockHistoryForOpen{
+		appendFunc: func(history.Entry) error {
+			return historyErr
+		},
+	}
+	uc := usecase.NewOpenBookmark(repo, &mockOpenerForOpener{}, hist)
+
+	bm := bookmarktest.New(t, "https://example.com")
+	bm.Status = bookmark.StatusUnread
+
+	output, err := uc.Execute(usecase.OpenBookmarkInput{Bookmark: bm})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if !errors.Is(output.HistoryErr, historyErr) {
+		t.Errorf("expected the history error in the output, got %v", output.HistoryErr)
+	}
+	if len(repo.updated) != 1 || repo.updated[0].Status != bookmark.StatusDone {
+		t.Errorf("expected the bookmark to be marked done, got %+v", repo.updated)
+	}
+}
+
+func TestOpenBookmark_URLOverridesBookmarkURL(t *testing.T) {
+	repo := &mockRepositoryForOpen{}
+	var opened bookmark.Bookmark
+	opener := &mockOpenerForOpener{
+		openFunc: func(bm bookmark.Bookmark) error {
+			opened = bm
+			return nil
+		},
+	}
+	uc := usecase.NewOpenBookmark(repo, opener, &mockHistoryForOpen{})
+
+	bm := bookmarktest.New(t, "https://pkg.go.dev/search?q=%s")
+	bm.Status = bookmark.StatusUnread
+	previous, _ := bookmark.NewBookmarkURL("https://pkg.go.dev/search?q=http")
+
+	if _, err := uc.Execute(usecase.OpenBookmarkInput{Bookmark: bm, URL: previous}); err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if opened.URL != previous {
+		t.Errorf("expected %q to be opened, got %q", previous.Value(), opened.URL.Value())
+	}
+	if len(repo.updated) != 1 || repo.updated[0].URL != bm.URL {
+		t.Errorf("expected the stored bookmark to keep its URL, got %v", repo.updated)
+	}
 }
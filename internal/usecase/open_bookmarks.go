@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/history"
+	"github.com/airRnot1106/bkm/internal/opener"
+)
+
+type OpenBookmarksInput struct {
+	Bookmarks []bookmark.Bookmark
+	// Delay is the pause between starting two opens, so that the browser is
+	// not flooded with tabs at once.
+	Delay time.Duration
+	// Concurrency limits how many opens run at the same time. Values below 1
+	// open one bookmark at a time.
+	Concurrency int
+	// KeepStatus leaves queued bookmarks in the queue instead of marking them done.
+	KeepStatus bool
+}
+
+type OpenBookmarksOutput struct {
+	// HistoryErrs are the opened bookmarks that could not be recorded in the
+	// history.
+	HistoryErrs []error
+}
+
+type OpenBookmarks struct {
+	repo    bookmark.Repository
+	opener  opener.Opener
+	history history.Repository
+}
+
+func NewOpenBookmarks(repo bookmark.Repository, opener opener.Opener, history history.Repository) *OpenBookmarks {
+	return &OpenBookmarks{repo: repo, opener: opener, history: history}
+}
+
+// Execute opens every bookmark in order and reports the ones that failed. A
+// failure does not stop the remaining bookmarks from being opened, and failing
+// to record an open in the history is returned in the output.
+func (uc *OpenBookmarks) Execute(input OpenBookmarksInput) (OpenBookmarksOutput, error) {
+	concurrency := max(input.Concurrency, 1)
+
+	targets := make([]bookmark.Bookmark, len(input.Bookmarks))
+	errs := make([]error, len(input.Bookmarks))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, bm := range input.Bookmarks {
+		if i > 0 && input.Delay > 0 {
+			time.Sleep(input.Delay)
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			target, err := resolveOpenTarget(OpenBookmarkInput{Bookmark: bm})
+			if err == nil {
+				err = uc.opener.Open(target)
+			}
+			targets[i], errs[i] = target, err
+		}()
+	}
+	wg.Wait()
+
+	// History and status updates run one by one because the repositories are
+	// not safe for concurrent writes.
+	var output OpenBookmarksOutput
+	var failed []error
+	for i, bm := range input.Bookmarks {
+		err := errs[i]
+		if err == nil {
+			if historyErr := recordOpen(uc.history, targets[i]); historyErr != nil {
+				output.HistoryErrs = append(output.HistoryErrs, fmt.Errorf("%q: %w", bm.Title.Value(), historyErr))
+			}
+			err = markOpened(uc.repo, bm, input.KeepStatus)
+		}
+		if err != nil {
+			failed = append(failed, fmt.Errorf("failed to open %q: %w", bm.Title.Value(), err))
+		}
+	}
+
+	return output, errors.Join(failed...)
+}
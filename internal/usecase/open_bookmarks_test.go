@@ -0,0 +1,192 @@
+package usecase_test
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/bookmark/bookmarktest"
+	"github.com/airRnot1106/bkm/internal/history"
+	"github.com/airRnot1106/bkm/internal/usecase"
+)
+
+func TestOpenBookmarks_OpensAllInOrder(t *testing.T) {
+	repo := &mockRepositoryForOpen{}
+	var opened []string
+	opener := &mockOpenerForOpener{
+		openFunc: func(bm bookmark.Bookmark) error {
+			opened = append(opened, bm.URL.Value())
+			return nil
+		},
+	}
+	uc := usecase.NewOpenBookmarks(repo, opener, &mockHistoryForOpen{})
+
+	bms := []bookmark.Bookmark{
+		bookmarktest.New(t, "https://example.com/dashboard0"),
+		bookmarktest.New(t, "https://example.com/dashboard1"),
+		bookmarktest.New(t, "https://example.com/dashboard2"),
+		bookmarktest.New(t, "https://example.com/dashboard3"),
+	}
+	if _, err := uc.Execute(usecase.OpenBookmarksInput{Bookmarks: bms}); err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if len(opened) != len(bms) {
+		t.Fatalf("expected %d opened, got %d", len(bms), len(opened))
+	}
+	for i, bm := range bms {
+		if opened[i] != bm.URL.Value() {
+			t.Errorf("expected %q at position %d, got %q", bm.URL.Value(), i, opened[i])
+		}
+	}
+}
+
+func TestOpenBookmarks_RespectsConcurrency(t *testing.T) {
+	repo := &mockRepositoryForOpen{}
+	var inFlight, peak atomic.Int32
+	opener := &mockOpenerForOpener{
+		openFunc: func(bm bookmark.Bookmark) error {
+			n := inFlight.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			inFlight.Add(-1)
+			return nil
+		},
+	}
+	uc := usecase.NewOpenBookmarks(repo, opener, &mockHistoryForOpen{})
+
+	_, err := uc.Execute(usecase.OpenBookmarksInput{
+		Bookmarks: []bookmark.Bookmark{
+			bookmarktest.New(t, "https://example.com/dashboard0"),
+			bookmarktest.New(t, "https://example.com/dashboard1"),
+			bookmarktest.New(t, "https://example.com/dashboard2"),
+			bookmarktest.New(t, "https://example.com/dashboard3"),
+			bookmarktest.New(t, "https://example.com/dashboard4"),
+			bookmarktest.New(t, "https://example.com/dashboard5"),
+		},
+		Concurrency: 2,
+	})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if got := peak.Load(); got > 2 {
+		t.Errorf("expected at most 2 concurrent opens, got %d", got)
+	}
+}
+
+func TestOpenBookmarks_WaitsBetweenOpens(t *testing.T) {
+	repo := &mockRepositoryForOpen{}
+	var mu sync.Mutex
+	var starts []time.Time
+	opener := &mockOpenerForOpener{
+		openFunc: func(bm bookmark.Bookmark) error {
+			mu.Lock()
+			defer mu.Unlock()
+			starts = append(starts, time.Now())
+			return nil
+		},
+	}
+	uc := usecase.NewOpenBookmarks(repo, opener, &mockHistoryForOpen{})
+
+	delay := 20 * time.Millisecond
+	_, err := uc.Execute(usecase.OpenBookmarksInput{
+		Bookmarks: []bookmark.Bookmark{
+			bookmarktest.New(t, "https://example.com/dashboard0"),
+			bookmarktest.New(t, "https://example.com/dashboard1"),
+			bookmarktest.New(t, "https://example.com/dashboard2"),
+		},
+		Delay:       delay,
+		Concurrency: 3,
+	})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if elapsed := starts[len(starts)-1].Sub(starts[0]); elapsed < 2*delay {
+		t.Errorf("expected opens to be spread over at least %v, got %v", 2*delay, elapsed)
+	}
+}
+
+func TestOpenBookmarks_ContinuesAfterFailure(t *testing.T) {
+	repo := &mockRepositoryForOpen{}
+	bms := []bookmark.Bookmark{
+		bookmarktest.New(t, "https://example.com/dashboard0"),
+		bookmarktest.New(t, "https://example.com/dashboard1"),
+		bookmarktest.New(t, "https://example.com/dashboard2"),
+	}
+	for i := range bms {
+		bms[i].Status = bookmark.StatusUnread
+	}
+
+	expectedErr := errors.New("browser crashed")
+	opener := &mockOpenerForOpener{
+		openFunc: func(bm bookmark.Bookmark) error {
+			if bm.ID == bms[1].ID {
+				return expectedErr
+			}
+			return nil
+		},
+	}
+	uc := usecase.NewOpenBookmarks(repo, opener, &mockHistoryForOpen{})
+
+	_, err := uc.Execute(usecase.OpenBookmarksInput{Bookmarks: bms})
+	if !errors.Is(err, expectedErr) {
+		t.Fatalf("expected %v, got %v", expectedErr, err)
+	}
+
+	if len(repo.updated) != 2 {
+		t.Fatalf("expected the 2 opened bookmarks to be marked done, got %d updates", len(repo.updated))
+	}
+	for _, bm := range repo.updated {
+		if bm.ID == bms[1].ID {
+			t.Error("failed bookmark should not be marked done")
+		}
+		if bm.Status != bookmark.StatusDone {
+			t.Errorf("expected status done, got %q", bm.Status.Value())
+		}
+	}
+}
+
+func TestOpenBookmarks_HistoryFailuresAreWarnings(t *testing.T) {
+	historyErr := errors.New("disk full")
+	hist := &mockHistoryForOpen{
+		appendFunc: func(history.Entry) error {
+			return historyErr
+		},
+	}
+	uc := usecase.NewOpenBookmarks(&mockRepositoryForOpen{}, &mockOpenerForOpener{}, hist)
+
+	output, err := uc.Execute(usecase.OpenBookmarksInput{Bookmarks: []bookmark.Bookmark{
+		bookmarktest.New(t, "https://example.com/dashboard0"),
+		bookmarktest.New(t, "https://example.com/dashboard1"),
+	}})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if len(output.HistoryErrs) != 2 || !errors.Is(output.HistoryErrs[0], historyErr) {
+		t.Errorf("expected 2 history errors, got %v", output.HistoryErrs)
+	}
+}
+
+func TestOpenBookmarks_TemplateWithoutArgsFails(t *testing.T) {
+	repo := &mockRepositoryForOpen{}
+	opener := &mockOpenerForOpener{}
+	uc := usecase.NewOpenBookmarks(repo, opener, &mockHistoryForOpen{})
+
+	_, err := uc.Execute(usecase.OpenBookmarksInput{Bookmarks: []bookmark.Bookmark{
+		bookmarktest.New(t, "https://pkg.go.dev/search?q=%s"),
+	}})
+	if !errors.Is(err, bookmark.ErrMissingTemplateArgs) {
+		t.Fatalf("expected ErrMissingTemplateArgs, got %v", err)
+	}
+}
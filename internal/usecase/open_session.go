@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/history"
+	"github.com/airRnot1106/bkm/internal/opener"
+	"github.com/airRnot1106/bkm/internal/session"
+)
+
+type OpenSessionInput struct {
+	Name        string
+	Delay       time.Duration
+	Concurrency int
+	KeepStatus  bool
+}
+
+type OpenSession struct {
+	sessions session.Repository
+	repo     bookmark.Repository
+	opener   opener.Opener
+	history  history.Repository
+}
+
+func NewOpenSession(sessions session.Repository, repo bookmark.Repository, opener opener.Opener, history history.Repository) *OpenSession {
+	return &OpenSession{sessions: sessions, repo: repo, opener: opener, history: history}
+}
+
+// Execute opens the bookmarks of the session in order. Bookmarks that no
+// longer exist are reported after the others have been opened.
+func (uc *OpenSession) Execute(input OpenSessionInput) (OpenBookmarksOutput, error) {
+	getUc := NewGetSessionBookmarks(uc.sessions, uc.repo)
+	resolved, err := getUc.Execute(GetSessionBookmarksInput{Name: input.Name})
+	if err != nil {
+		return OpenBookmarksOutput{}, err
+	}
+
+	openUc := NewOpenBookmarks(uc.repo, uc.opener, uc.history)
+	output, openErr := openUc.Execute(OpenBookmarksInput{
+		Bookmarks:   resolved.Bookmarks,
+		Delay:       input.Delay,
+		Concurrency: input.Concurrency,
+		KeepStatus:  input.KeepStatus,
+	})
+
+	var missingErr error
+	if len(resolved.Missing) > 0 {
+		missingErr = fmt.Errorf("%w: %d bookmark(s) of the session no longer exist", bookmark.ErrBookmarkNotFound, len(resolved.Missing))
+	}
+
+	return output, errors.Join(openErr, missingErr)
+}
@@ -0,0 +1,72 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/bookmark/bookmarktest"
+	"github.com/airRnot1106/bkm/internal/session"
+	"github.com/airRnot1106/bkm/internal/session/sessiontest"
+	"github.com/airRnot1106/bkm/internal/usecase"
+)
+
+func TestOpenSession_OpensInSessionOrder(t *testing.T) {
+	sessions := &mockSessionRepository{}
+	repo := &mockRepositoryForList{}
+	first, second := bookmarktest.New(t, "https://status.example.com/1"), bookmarktest.New(t, "https://status.example.com/2")
+	repo.Add(first)
+	repo.Add(second)
+	sessions.Add(sessiontest.New(t, "incident-response", second, first))
+
+	var opened []bookmark.BookmarkID
+	opener := &mockOpenerForOpener{
+		openFunc: func(bm bookmark.Bookmark) error {
+			opened = append(opened, bm.ID)
+			return nil
+		},
+	}
+	uc := usecase.NewOpenSession(sessions, repo, opener, &mockHistoryForOpen{})
+
+	if _, err := uc.Execute(usecase.OpenSessionInput{Name: "incident-response"}); err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if len(opened) != 2 || opened[0] != second.ID || opened[1] != first.ID {
+		t.Errorf("expected bookmarks in session order, got %v", opened)
+	}
+}
+
+func TestOpenSession_ReportsDeletedBookmarks(t *testing.T) {
+	sessions := &mockSessionRepository{}
+	repo := &mockRepositoryForList{}
+	kept, deleted := bookmarktest.New(t, "https://status.example.com/1"), bookmarktest.New(t, "https://status.example.com/2")
+	repo.Add(kept)
+	sessions.Add(sessiontest.New(t, "daily", deleted, kept))
+
+	var opened int
+	opener := &mockOpenerForOpener{
+		openFunc: func(bm bookmark.Bookmark) error {
+			opened++
+			return nil
+		},
+	}
+	uc := usecase.NewOpenSession(sessions, repo, opener, &mockHistoryForOpen{})
+
+	_, err := uc.Execute(usecase.OpenSessionInput{Name: "daily"})
+	if !errors.Is(err, bookmark.ErrBookmarkNotFound) {
+		t.Fatalf("expected ErrBookmarkNotFound, got %v", err)
+	}
+	if opened != 1 {
+		t.Errorf("expected the remaining bookmark to be opened, got %d opens", opened)
+	}
+}
+
+func TestOpenSession_UnknownSessionFails(t *testing.T) {
+	uc := usecase.NewOpenSession(&mockSessionRepository{}, &mockRepositoryForList{}, &mockOpenerForOpener{}, &mockHistoryForOpen{})
+
+	_, err := uc.Execute(usecase.OpenSessionInput{Name: "missing"})
+	if !errors.Is(err, session.ErrSessionNotFound) {
+		t.Fatalf("expected ErrSessionNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/selector"
+)
+
+type PickFromQueueInput struct {
+	Tags []string
+}
+
+type PickFromQueue struct {
+	repo     bookmark.Repository
+	selector selector.Selector
+}
+
+func NewPickFromQueue(repo bookmark.Repository, selector selector.Selector) *PickFromQueue {
+	return &PickFromQueue{repo: repo, selector: selector}
+}
+
+func (uc *PickFromQueue) Execute(input PickFromQueueInput) (bookmark.Bookmark, error) {
+	targetTags := make([]bookmark.BookmarkTag, 0, len(input.Tags))
+	for i, t := range input.Tags {
+		tag, err := bookmark.NewBookmarkTag(t)
+		if err != nil {
+			return bookmark.Bookmark{}, fmt.Errorf("invalid tag at index %d: %w", i, err)
+		}
+		targetTags = append(targetTags, tag)
+	}
+
+	bookmarks, err := uc.repo.List()
+	if err != nil {
+		return bookmark.Bookmark{}, fmt.Errorf("failed to list bookmarks: %w", err)
+	}
+
+	var queued []bookmark.Bookmark
+	for _, bm := range bookmarks {
+		if !bm.Status.IsQueued() {
+			continue
+		}
+		matched := true
+		for _, target := range targetTags {
+			if !slices.Contains(bm.Tags, target) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			queued = append(queued, bm)
+		}
+	}
+
+	// Bookmarks already being read come first, then the oldest unread ones.
+	slices.SortStableFunc(queued, func(a, b bookmark.Bookmark) int {
+		if a.Status != b.Status {
+			if a.Status == bookmark.StatusReading {
+				return -1
+			}
+			if b.Status == bookmark.StatusReading {
+				return 1
+			}
+		}
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	bm, err := uc.selector.Select(queued)
+	if err != nil {
+		return bookmark.Bookmark{}, err
+	}
+
+	return bm, nil
+}
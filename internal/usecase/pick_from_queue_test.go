@@ -0,0 +1,127 @@
+package usecase_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/bookmark/bookmarktest"
+	"github.com/airRnot1106/bkm/internal/selector"
+	"github.com/airRnot1106/bkm/internal/usecase"
+)
+
+type mockRepositoryForQueue struct {
+	listFunc  func() ([]bookmark.Bookmark, error)
+	bookmarks []bookmark.Bookmark
+}
+
+func (m *mockRepositoryForQueue) Add(bm bookmark.Bookmark) error {
+	m.bookmarks = append(m.bookmarks, bm)
+	return nil
+}
+
+func (m *mockRepositoryForQueue) List() ([]bookmark.Bookmark, error) {
+	if m.listFunc != nil {
+		return m.listFunc()
+	}
+	return m.bookmarks, nil
+}
+
+func (m *mockRepositoryForQueue) Update(bm bookmark.Bookmark) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (m *mockRepositoryForQueue) Delete(id bookmark.BookmarkID) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (m *mockRepositoryForQueue) UpdateAll(bms []bookmark.Bookmark) error {
+	return fmt.Errorf("not implemented")
+}
+
+type mockSelectorForQueue struct {
+	received []bookmark.Bookmark
+}
+
+func (m *mockSelectorForQueue) Select(bms []bookmark.Bookmark) (bookmark.Bookmark, error) {
+	m.received = bms
+	if len(bms) == 0 {
+		return bookmark.Bookmark{}, selector.ErrCancelled
+	}
+	return bms[0], nil
+}
+
+func (m *mockSelectorForQueue) SelectMulti(bms []bookmark.Bookmark) ([]bookmark.Bookmark, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func TestPickFromQueue_OnlyQueuedBookmarksAreOffered(t *testing.T) {
+	now := time.Now()
+	repo := &mockRepositoryForQueue{}
+	for _, queued := range []struct {
+		name      string
+		status    bookmark.BookmarkStatus
+		createdAt time.Time
+	}{
+		{"newer", bookmark.StatusUnread, now},
+		{"finished", bookmark.StatusDone, now.Add(-3 * time.Hour)},
+		{"plain", bookmark.StatusNone, now.Add(-2 * time.Hour)},
+		{"older", bookmark.StatusUnread, now.Add(-time.Hour)},
+		{"started", bookmark.StatusReading, now},
+	} {
+		bm := bookmarktest.New(t, "https://example.com/"+queued.name)
+		bm.Status = queued.status
+		bm.CreatedAt = queued.createdAt
+		repo.Add(bm)
+	}
+
+	sel := &mockSelectorForQueue{}
+	uc := usecase.NewPickFromQueue(repo, sel)
+
+	bm, err := uc.Execute(usecase.PickFromQueueInput{})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	expected := []string{"https://example.com/started", "https://example.com/older", "https://example.com/newer"}
+	if len(sel.received) != len(expected) {
+		t.Fatalf("expected %d queued bookmarks, got %d", len(expected), len(sel.received))
+	}
+	for i, url := range expected {
+		if sel.received[i].URL.Value() != url {
+			t.Errorf("expected %q at position %d, got %q", url, i, sel.received[i].URL.Value())
+		}
+	}
+	if bm.URL.Value() != expected[0] {
+		t.Errorf("expected selected bookmark %q, got %q", expected[0], bm.URL.Value())
+	}
+}
+
+func TestPickFromQueue_EmptyQueueIsCancelled(t *testing.T) {
+	repo := &mockRepositoryForQueue{}
+	finished := bookmarktest.New(t, "https://example.com/finished")
+	finished.Status = bookmark.StatusDone
+	repo.Add(finished)
+
+	uc := usecase.NewPickFromQueue(repo, &mockSelectorForQueue{})
+
+	_, err := uc.Execute(usecase.PickFromQueueInput{})
+	if err != selector.ErrCancelled {
+		t.Fatalf("expected selector.ErrCancelled, got %v", err)
+	}
+}
+
+func TestPickFromQueue_RepositoryListError(t *testing.T) {
+	repo := &mockRepositoryForQueue{
+		listFunc: func() ([]bookmark.Bookmark, error) {
+			return nil, fmt.Errorf("database connection failed")
+		},
+	}
+	uc := usecase.NewPickFromQueue(repo, &mockSelectorForQueue{})
+
+	_, err := uc.Execute(usecase.PickFromQueueInput{})
+	if err == nil {
+		t.Fatalf("expected error, got success")
+	}
+}
@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+)
+
+type ReadLaterInput struct {
+	URL   string
+	Title string
+	Tags  []string
+}
+
+type ReadLater struct {
+	repo   bookmark.Repository
+	policy *URLPolicy
+}
+
+func NewReadLater(repo bookmark.Repository, policy *URLPolicy) *ReadLater {
+	return &ReadLater{repo: repo, policy: policy}
+}
+
+// Execute puts the URL into the read-later queue. A URL that is already
+// bookmarked is re-queued instead of being added twice; a given title replaces
+// its title and given tags are added to its tags. The URL goes through the URL
+// policy first.
+func (uc *ReadLater) Execute(input ReadLaterInput) (bookmark.Bookmark, error) {
+	url, err := uc.policy.Apply(input.URL)
+	if err != nil {
+		return bookmark.Bookmark{}, err
+	}
+
+	rawTitle := input.Title
+	if rawTitle == "" {
+		rawTitle = url.Value()
+	}
+	title, err := bookmark.NewBookmarkTitle(rawTitle)
+	if err != nil {
+		return bookmark.Bookmark{}, fmt.Errorf("invalid title: %w", err)
+	}
+
+	tags := make([]bookmark.BookmarkTag, 0, len(input.Tags))
+	for i, t := range input.Tags {
+		tag, err := bookmark.NewBookmarkTag(t)
+		if err != nil {
+			return bookmark.Bookmark{}, fmt.Errorf("invalid tag at index %d: %w", i, err)
+		}
+		tags = append(tags, tag)
+	}
+
+	bookmarks, err := uc.repo.List()
+	if err != nil {
+		return bookmark.Bookmark{}, fmt.Errorf("failed to list bookmarks: %w", err)
+	}
+
+	for _, bm := range bookmarks {
+		if bm.URL != url {
+			continue
+		}
+		if input.Title != "" {
+			bm.Title = title
+		}
+		for _, tag := range tags {
+			if !slices.Contains(bm.Tags, tag) {
+				bm.Tags = append(slices.Clone(bm.Tags), tag)
+			}
+		}
+		bm.Status = bookmark.StatusUnread
+		bm.UpdatedAt = time.Now()
+		if err := uc.repo.Update(bm); err != nil {
+			return bookmark.Bookmark{}, fmt.Errorf("failed to update bookmark: %w", err)
+		}
+		return bm, nil
+	}
+
+	bm := bookmark.CreateBookmark(url, title, bookmark.NewBookmarkDescription(""), tags)
+	bm.Status = bookmark.StatusUnread
+
+	if err := uc.repo.Add(bm); err != nil {
+		return bookmark.Bookmark{}, fmt.Errorf("failed to add bookmark: %w", err)
+	}
+
+	return bm, nil
+}
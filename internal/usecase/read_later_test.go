@@ -0,0 +1,157 @@
+package usecase_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/bookmark/bookmarktest"
+	"github.com/airRnot1106/bkm/internal/secretscan"
+	"github.com/airRnot1106/bkm/internal/urlclean"
+	"github.com/airRnot1106/bkm/internal/usecase"
+)
+
+type mockRepositoryForReadLater struct {
+	addFunc   func(bookmark.Bookmark) error
+	bookmarks []bookmark.Bookmark
+	updated   []bookmark.Bookmark
+}
+
+func (m *mockRepositoryForReadLater) Add(bm bookmark.Bookmark) error {
+	if m.addFunc != nil {
+		return m.addFunc(bm)
+	}
+	m.bookmarks = append(m.bookmarks, bm)
+	return nil
+}
+
+func (m *mockRepositoryForReadLater) List() ([]bookmark.Bookmark, error) {
+	return m.bookmarks, nil
+}
+
+func (m *mockRepositoryForReadLater) Update(bm bookmark.Bookmark) error {
+	m.updated = append(m.updated, bm)
+	return nil
+}
+
+func (m *mockRepositoryForReadLater) Delete(id bookmark.BookmarkID) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (m *mockRepositoryForReadLater) UpdateAll(bms []bookmark.Bookmark) error {
+	return fmt.Errorf("not implemented")
+}
+
+func TestReadLater_NewURLIsQueuedAsUnread(t *testing.T) {
+	repo := &mockRepositoryForReadLater{}
+	uc := usecase.NewReadLater(repo, newURLPolicy(nil, secretscan.PolicyRefuse))
+
+	bm, err := uc.Execute(usecase.ReadLaterInput{URL: "https://example.com/article"})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if len(repo.bookmarks) != 1 {
+		t.Fatalf("expected 1 bookmark, got %d", len(repo.bookmarks))
+	}
+	if bm.Status != bookmark.StatusUnread {
+		t.Errorf("expected status unread, got %q", bm.Status.Value())
+	}
+	if bm.Title.Value() != "https://example.com/article" {
+		t.Errorf("expected title to default to the URL, got %q", bm.Title.Value())
+	}
+}
+
+func TestReadLater_ExistingURLIsRequeued(t *testing.T) {
+	repo := &mockRepositoryForReadLater{}
+	existing := bookmarktest.New(t, "https://example.com/article")
+	existing.Status = bookmark.StatusDone
+	repo.bookmarks = append(repo.bookmarks, existing)
+
+	uc := usecase.NewReadLater(repo, newURLPolicy(nil, secretscan.PolicyRefuse))
+
+	bm, err := uc.Execute(usecase.ReadLaterInput{URL: "https://example.com/article"})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if len(repo.bookmarks) != 1 {
+		t.Fatalf("expected no new bookmark, got %d bookmarks", len(repo.bookmarks))
+	}
+	if len(repo.updated) != 1 {
+		t.Fatalf("expected 1 update, got %d", len(repo.updated))
+	}
+	if bm.ID != existing.ID {
+		t.Errorf("expected existing bookmark to be returned")
+	}
+	if bm.Status != bookmark.StatusUnread {
+		t.Errorf("expected status unread, got %q", bm.Status.Value())
+	}
+}
+
+func TestReadLater_ExistingURLGetsTitleAndTags(t *testing.T) {
+	repo := &mockRepositoryForReadLater{}
+	existing := bookmarktest.New(t, "https://example.com/article", "go")
+	repo.bookmarks = append(repo.bookmarks, existing)
+	uc := usecase.NewReadLater(repo, newURLPolicy(nil, secretscan.PolicyRefuse))
+
+	bm, err := uc.Execute(usecase.ReadLaterInput{URL: "https://example.com/article", Title: "Article", Tags: []string{"go", "later"}})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if bm.Title.Value() != "Article" {
+		t.Errorf("expected the new title, got %q", bm.Title.Value())
+	}
+	if len(bm.Tags) != 2 || !hasTag(bm, "go") || !hasTag(bm, "later") {
+		t.Errorf("expected tags go and later, got %v", bm.Tags)
+	}
+	if len(repo.updated) != 1 || repo.updated[0].Title.Value() != "Article" {
+		t.Errorf("expected the changes to be stored, got %+v", repo.updated)
+	}
+}
+
+func TestReadLater_InvalidURLFails(t *testing.T) {
+	repo := &mockRepositoryForReadLater{}
+	uc := usecase.NewReadLater(repo, newURLPolicy(nil, secretscan.PolicyRefuse))
+
+	_, err := uc.Execute(usecase.ReadLaterInput{URL: "invalid-url"})
+	if err == nil {
+		t.Fatalf("expected error for invalid URL, got success")
+	}
+}
+
+func TestReadLater_RepositoryAddFails(t *testing.T) {
+	repo := &mockRepositoryForReadLater{
+		addFunc: func(bm bookmark.Bookmark) error {
+			return fmt.Errorf("repository add error")
+		},
+	}
+	uc := usecase.NewReadLater(repo, newURLPolicy(nil, secretscan.PolicyRefuse))
+
+	_, err := uc.Execute(usecase.ReadLaterInput{URL: "https://example.com"})
+	if err == nil {
+		t.Fatalf("expected error from repository add, got success")
+	}
+}
+
+func TestReadLater_AppliesURLPolicy(t *testing.T) {
+	repo := &mockRepositoryForReadLater{}
+	uc := usecase.NewReadLater(repo, newURLPolicy(urlclean.BuiltinRules(), secretscan.PolicyRefuse))
+
+	if _, err := uc.Execute(usecase.ReadLaterInput{URL: "https://example.com/feed?token=abc"}); !errors.Is(err, secretscan.ErrSecretFound) {
+		t.Errorf("expected ErrSecretFound, got %v", err)
+	}
+	bm, err := uc.Execute(usecase.ReadLaterInput{URL: "https://example.com/article?utm_source=feed"})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if bm.URL.Value() != "https://example.com/article" {
+		t.Errorf("expected a cleaned URL, got %s", bm.URL.Value())
+	}
+	if len(repo.bookmarks) != 1 {
+		t.Errorf("expected only the cleaned URL to be stored, got %d bookmarks", len(repo.bookmarks))
+	}
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/secretscan"
+)
+
+type RedactBookmarkSecretsInput struct {
+	Bookmarks []bookmark.Bookmark
+}
+
+type RedactBookmarkSecrets struct {
+	repo    bookmark.Repository
+	scanner *secretscan.Scanner
+}
+
+func NewRedactBookmarkSecrets(repo bookmark.Repository, scanner *secretscan.Scanner) *RedactBookmarkSecrets {
+	return &RedactBookmarkSecrets{repo: repo, scanner: scanner}
+}
+
+// Execute replaces the secrets in the primary URL and the variants of every
+// bookmark and returns the bookmarks that changed.
+func (uc *RedactBookmarkSecrets) Execute(input RedactBookmarkSecretsInput) ([]bookmark.Bookmark, error) {
+	now := time.Now()
+	var updated []bookmark.Bookmark
+	for _, bm := range input.Bookmarks {
+		redacted, changed, err := uc.redact(bm)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			redacted.UpdatedAt = now
+			updated = append(updated, redacted)
+		}
+	}
+	if len(updated) == 0 {
+		return nil, nil
+	}
+
+	if err := uc.repo.UpdateAll(updated); err != nil {
+		return nil, fmt.Errorf("failed to update bookmarks: %w", err)
+	}
+	return updated, nil
+}
+
+func (uc *RedactBookmarkSecrets) redact(bm bookmark.Bookmark) (bookmark.Bookmark, bool, error) {
+	changed := false
+	redactURL := func(url bookmark.BookmarkURL) (bookmark.BookmarkURL, error) {
+		redacted := uc.scanner.Redact(url.Value())
+		if redacted == url.Value() {
+			return url, nil
+		}
+		changed = true
+		return bookmark.NewBookmarkURL(redacted)
+	}
+
+	url, err := redactURL(bm.URL)
+	if err != nil {
+		return bookmark.Bookmark{}, false, fmt.Errorf("failed to redact URL of %q: %w", bm.Title.Value(), err)
+	}
+	bm.URL = url
+
+	if len(bm.Variants) > 0 {
+		variants := make(map[bookmark.BookmarkVariantName]bookmark.BookmarkURL, len(bm.Variants))
+		for name, variantURL := range bm.Variants {
+			if variants[name], err = redactURL(variantURL); err != nil {
+				return bookmark.Bookmark{}, false, fmt.Errorf("failed to redact variant %q of %q: %w", name.Value(), bm.Title.Value(), err)
+			}
+		}
+		bm.Variants = variants
+	}
+	return bm, changed, nil
+}
@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/session"
+)
+
+type RemoveFromSessionInput struct {
+	Name        string
+	BookmarkIDs []bookmark.BookmarkID
+}
+
+type RemoveFromSession struct {
+	sessions session.Repository
+}
+
+func NewRemoveFromSession(sessions session.Repository) *RemoveFromSession {
+	return &RemoveFromSession{sessions: sessions}
+}
+
+func (uc *RemoveFromSession) Execute(input RemoveFromSessionInput) (session.Session, error) {
+	sess, err := findSession(uc.sessions, input.Name)
+	if err != nil {
+		return session.Session{}, err
+	}
+
+	for _, id := range input.BookmarkIDs {
+		if err := sess.Remove(id); err != nil {
+			return session.Session{}, err
+		}
+	}
+
+	sess.UpdatedAt = time.Now()
+	if err := uc.sessions.Update(sess); err != nil {
+		return session.Session{}, fmt.Errorf("failed to update session: %w", err)
+	}
+
+	return sess, nil
+}
@@ -0,0 +1,48 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/bookmark/bookmarktest"
+	"github.com/airRnot1106/bkm/internal/session"
+	"github.com/airRnot1106/bkm/internal/session/sessiontest"
+	"github.com/airRnot1106/bkm/internal/usecase"
+)
+
+func TestRemoveFromSession_Success(t *testing.T) {
+	repo := &mockSessionRepository{}
+	first, second := bookmarktest.New(t, "https://status.example.com/1"), bookmarktest.New(t, "https://status.example.com/2")
+	repo.Add(sessiontest.New(t, "daily", first, second))
+	uc := usecase.NewRemoveFromSession(repo)
+
+	sess, err := uc.Execute(usecase.RemoveFromSessionInput{
+		Name:        "daily",
+		BookmarkIDs: []bookmark.BookmarkID{first.ID},
+	})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if sess.Contains(first.ID) || !sess.Contains(second.ID) {
+		t.Errorf("expected only the second bookmark to remain, got %v", sess.BookmarkIDs)
+	}
+	if repo.sessions[0].Contains(first.ID) {
+		t.Error("expected session to be saved")
+	}
+}
+
+func TestRemoveFromSession_BookmarkNotInSessionFails(t *testing.T) {
+	repo := &mockSessionRepository{}
+	repo.Add(sessiontest.New(t, "daily"))
+	uc := usecase.NewRemoveFromSession(repo)
+
+	_, err := uc.Execute(usecase.RemoveFromSessionInput{
+		Name:        "daily",
+		BookmarkIDs: []bookmark.BookmarkID{bookmark.GenerateBookmarkID()},
+	})
+	if !errors.Is(err, session.ErrNotInSession) {
+		t.Fatalf("expected ErrNotInSession, got %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/fulltext"
+	"github.com/airRnot1106/bkm/internal/selector"
+)
+
+type SearchBookmarkContentInput struct {
+	Query string
+	// Tags limit the result to bookmarks that have all of them.
+	Tags []string
+}
+
+type SearchBookmarkContent struct {
+	repo     bookmark.Repository
+	index    fulltext.Repository
+	selector selector.Selector
+}
+
+func NewSearchBookmarkContent(repo bookmark.Repository, index fulltext.Repository, selector selector.Selector) *SearchBookmarkContent {
+	return &SearchBookmarkContent{repo: repo, index: index, selector: selector}
+}
+
+// Execute ranks the bookmarks by how well their page content matches the
+// query and lets the user select one from the ranked list.
+func (uc *SearchBookmarkContent) Execute(input SearchBookmarkContentInput) (bookmark.Bookmark, error) {
+	if strings.TrimSpace(input.Query) == "" {
+		return bookmark.Bookmark{}, fmt.Errorf("query cannot be empty")
+	}
+
+	listUc := NewListBookmarks(uc.repo)
+	bookmarks, err := listUc.Execute(ListBookmarksInput{Tags: input.Tags})
+	if err != nil {
+		return bookmark.Bookmark{}, err
+	}
+	byID := make(map[string]bookmark.Bookmark, len(bookmarks))
+	for _, bm := range bookmarks {
+		byID[bm.ID.Value()] = bm
+	}
+
+	idx, err := uc.index.Load()
+	if err != nil {
+		return bookmark.Bookmark{}, fmt.Errorf("failed to load index: %w", err)
+	}
+
+	var ranked []bookmark.Bookmark
+	for _, hit := range idx.Search(input.Query) {
+		if bm, ok := byID[hit.ID]; ok {
+			ranked = append(ranked, bm)
+		}
+	}
+	if len(ranked) == 0 {
+		return bookmark.Bookmark{}, fulltext.ErrNoMatches
+	}
+
+	return uc.selector.Select(ranked)
+}
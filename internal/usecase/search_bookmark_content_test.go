@@ -0,0 +1,74 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/bookmark/bookmarktest"
+	"github.com/airRnot1106/bkm/internal/fulltext"
+	"github.com/airRnot1106/bkm/internal/usecase"
+)
+
+// mockSelectorForContent picks the first item and records the list it was given.
+type mockSelectorForContent struct {
+	items []bookmark.Bookmark
+}
+
+func (m *mockSelectorForContent) Select(bms []bookmark.Bookmark) (bookmark.Bookmark, error) {
+	m.items = bms
+	return bms[0], nil
+}
+
+func (m *mockSelectorForContent) SelectMulti(bms []bookmark.Bookmark) ([]bookmark.Bookmark, error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestSearchBookmarkContent_FeedsRankedList(t *testing.T) {
+	repo := &mockRepositoryForCheck{}
+	garden := bookmarktest.New(t, "https://example.com/garden", "home")
+	recipe := bookmarktest.New(t, "https://example.com/recipe")
+	kernel := bookmarktest.New(t, "https://example.com/kernel")
+	repo.Add(recipe)
+	repo.Add(garden)
+	repo.Add(kernel)
+	index := &mockIndexRepository{index: fulltext.NewIndex(
+		fulltext.NewDocument(garden.ID.Value(), "", "Growing tomatoes in a small garden"),
+		fulltext.NewDocument(recipe.ID.Value(), "", "Pasta with tomatoes"),
+		fulltext.NewDocument(kernel.ID.Value(), "", "Kernel scheduler"),
+		fulltext.NewDocument("deleted", "", "tomatoes garden garden"),
+	)}
+	sel := &mockSelectorForContent{}
+	uc := usecase.NewSearchBookmarkContent(repo, index, sel)
+
+	selected, err := uc.Execute(usecase.SearchBookmarkContentInput{Query: "tomatoes garden"})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if selected.ID != garden.ID {
+		t.Errorf("expected the best match to be selected, got %s", selected.Title.Value())
+	}
+	if len(sel.items) != 2 || sel.items[0].ID != garden.ID || sel.items[1].ID != recipe.ID {
+		t.Errorf("expected [garden recipe] in rank order, got %d items", len(sel.items))
+	}
+
+	if _, err := uc.Execute(usecase.SearchBookmarkContentInput{Query: "tomatoes", Tags: []string{"home"}}); err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	if len(sel.items) != 1 || sel.items[0].ID != garden.ID {
+		t.Errorf("expected only the tagged bookmark, got %d items", len(sel.items))
+	}
+}
+
+func TestSearchBookmarkContent_NoMatches(t *testing.T) {
+	repo := &mockRepositoryForCheck{}
+	uc := usecase.NewSearchBookmarkContent(repo, &mockIndexRepository{}, &mockSelectorForContent{})
+
+	if _, err := uc.Execute(usecase.SearchBookmarkContentInput{Query: "anything"}); !errors.Is(err, fulltext.ErrNoMatches) {
+		t.Errorf("expected ErrNoMatches, got %v", err)
+	}
+	if _, err := uc.Execute(usecase.SearchBookmarkContentInput{Query: "  "}); err == nil {
+		t.Error("expected an error for an empty query")
+	}
+}
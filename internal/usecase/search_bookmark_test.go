@@ -31,10 +31,18 @@ func (m *mockRepositoryForSearch) List() ([]bookmark.Bookmark, error) {
 	return m.bookmarks, nil
 }
 
+func (m *mockRepositoryForSearch) Update(bm bookmark.Bookmark) error {
+	return fmt.Errorf("not implemented")
+}
+
 func (m *mockRepositoryForSearch) Delete(id bookmark.BookmarkID) error {
 	return fmt.Errorf("not implemented")
 }
 
+func (m *mockRepositoryForSearch) UpdateAll(bms []bookmark.Bookmark) error {
+	return fmt.Errorf("not implemented")
+}
+
 type mockSelectorForSearch struct {
 	selectFunc func([]bookmark.Bookmark) (bookmark.Bookmark, error)
 }
@@ -49,6 +57,10 @@ func (m *mockSelectorForSearch) Select(bms []bookmark.Bookmark) (bookmark.Bookma
 	return bms[0], nil
 }
 
+func (m *mockSelectorForSearch) SelectMulti(bms []bookmark.Bookmark) ([]bookmark.Bookmark, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
 func TestSearchBookmark_ValidParamsAlwaysSucceed(t *testing.T) {
 	repo := &mockRepositoryForSearch{}
 	sel := &mockSelectorForSearch{}
@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/tagsuggest"
+)
+
+type SuggestTagsInput struct {
+	URL         string
+	Title       string
+	Description string
+}
+
+type SuggestTags struct {
+	repo      bookmark.Repository
+	suggester *tagsuggest.Suggester
+}
+
+func NewSuggestTags(repo bookmark.Repository, suggester *tagsuggest.Suggester) *SuggestTags {
+	return &SuggestTags{repo: repo, suggester: suggester}
+}
+
+// Execute suggests tags for a new bookmark, best first. The dead tag set by
+// bkm check describes a link rather than a site, so it is never suggested.
+func (uc *SuggestTags) Execute(input SuggestTagsInput) ([]string, error) {
+	bookmarks, err := uc.repo.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bookmarks: %w", err)
+	}
+
+	return uc.suggester.Suggest(tagsuggest.Page{
+		URL:         input.URL,
+		Title:       input.Title,
+		Description: input.Description,
+	}, bookmarks, DeadTag), nil
+}
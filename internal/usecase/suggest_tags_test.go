@@ -0,0 +1,43 @@
+package usecase_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/bookmark/bookmarktest"
+	"github.com/airRnot1106/bkm/internal/tagsuggest"
+	"github.com/airRnot1106/bkm/internal/usecase"
+)
+
+func TestSuggestTags_SuggestsFromExistingBookmarks(t *testing.T) {
+	repo := &mockRepositoryForCheck{}
+	repo.Add(bookmarktest.New(t, "https://go.dev/doc", "go", usecase.DeadTag))
+	repo.Add(bookmarktest.New(t, "https://example.com/", "cli"))
+	suggester := tagsuggest.NewSuggester([]tagsuggest.Rule{{Domain: "go.dev", Tags: []string{"docs"}}})
+	uc := usecase.NewSuggestTags(repo, suggester)
+
+	tags, err := uc.Execute(usecase.SuggestTagsInput{URL: "https://go.dev/blog", Title: "A new CLI"})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	expected := []string{"docs", "go", "cli"}
+	if fmt.Sprint(tags) != fmt.Sprint(expected) {
+		t.Errorf("expected %v without the dead tag, got %v", expected, tags)
+	}
+}
+
+func TestSuggestTags_DeadTagDoesNotTakeAPlace(t *testing.T) {
+	repo := &mockRepositoryForCheck{}
+	repo.Add(bookmarktest.New(t, "https://example.com/a", usecase.DeadTag, "docs"))
+	uc := usecase.NewSuggestTags(repo, tagsuggest.NewSuggester(nil, tagsuggest.WithLimit(1)))
+
+	tags, err := uc.Execute(usecase.SuggestTagsInput{URL: "https://example.com/b"})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if fmt.Sprint(tags) != "[docs]" {
+		t.Errorf("expected [docs], got %v", tags)
+	}
+}
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/fulltext"
+	"github.com/airRnot1106/bkm/internal/snapshot"
+)
+
+type UpdateContentIndexInput struct {
+	// Fetch downloads the live page of bookmarks without an offline copy.
+	// Otherwise only offline copies are indexed.
+	Fetch bool
+}
+
+type UpdateContentIndexOutput struct {
+	Indexed int
+	Removed int
+	// Errors are the pages that could not be indexed. They do not stop the
+	// remaining pages from being indexed.
+	Errors []error
+}
+
+type UpdateContentIndex struct {
+	repo     bookmark.Repository
+	index    fulltext.Repository
+	store    snapshot.Store
+	capturer snapshot.Capturer
+}
+
+func NewUpdateContentIndex(repo bookmark.Repository, index fulltext.Repository, store snapshot.Store, capturer snapshot.Capturer) *UpdateContentIndex {
+	return &UpdateContentIndex{repo: repo, index: index, store: store, capturer: capturer}
+}
+
+// Execute indexes the text of offline copies that changed since they were
+// last indexed, and of live pages when requested. Documents of deleted
+// bookmarks are removed.
+func (uc *UpdateContentIndex) Execute(input UpdateContentIndexInput) (UpdateContentIndexOutput, error) {
+	bookmarks, err := uc.repo.List()
+	if err != nil {
+		return UpdateContentIndexOutput{}, fmt.Errorf("failed to list bookmarks: %w", err)
+	}
+	idx, err := uc.index.Load()
+	if err != nil {
+		return UpdateContentIndexOutput{}, fmt.Errorf("failed to load index: %w", err)
+	}
+
+	var output UpdateContentIndexOutput
+	ids := make(map[string]struct{}, len(bookmarks))
+	for _, bm := range bookmarks {
+		ids[bm.ID.Value()] = struct{}{}
+
+		doc, indexed, err := uc.document(bm, idx, input.Fetch)
+		if err != nil {
+			output.Errors = append(output.Errors, fmt.Errorf("failed to index %q: %w", bm.Title.Value(), err))
+			continue
+		}
+		if indexed {
+			idx.Put(doc)
+			output.Indexed++
+		}
+	}
+
+	for _, doc := range idx.Documents() {
+		if _, ok := ids[doc.ID]; !ok {
+			idx.Remove(doc.ID)
+			output.Removed++
+		}
+	}
+
+	if output.Indexed > 0 || output.Removed > 0 {
+		if err := uc.index.Save(idx); err != nil {
+			return UpdateContentIndexOutput{}, fmt.Errorf("failed to save index: %w", err)
+		}
+	}
+
+	return output, nil
+}
+
+// document builds the document of bm, reporting false when the index is
+// already up to date or there is nothing to index.
+func (uc *UpdateContentIndex) document(bm bookmark.Bookmark, idx *fulltext.Index, fetch bool) (fulltext.Document, bool, error) {
+	id := bm.ID.Value()
+
+	if digest := bm.Snapshot.Digest; digest != "" {
+		if doc, ok := idx.Document(id); ok && doc.Digest == digest {
+			return fulltext.Document{}, false, nil
+		}
+		content, err := uc.store.Get(digest)
+		if err != nil {
+			return fulltext.Document{}, false, fmt.Errorf("failed to read snapshot: %w", err)
+		}
+		return fulltext.NewDocument(id, digest, fulltext.ExtractText(string(content))), true, nil
+	}
+
+	if !fetch || !isCheckable(bm.URL) {
+		return fulltext.Document{}, false, nil
+	}
+	content, err := uc.capturer.Capture(bm.URL.Value())
+	if err != nil {
+		return fulltext.Document{}, false, fmt.Errorf("failed to capture page: %w", err)
+	}
+	return fulltext.NewDocument(id, snapshot.Digest(content), fulltext.ExtractText(string(content))), true, nil
+}
@@ -0,0 +1,124 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/bookmark/bookmarktest"
+	"github.com/airRnot1106/bkm/internal/fulltext"
+	"github.com/airRnot1106/bkm/internal/snapshot"
+	"github.com/airRnot1106/bkm/internal/usecase"
+)
+
+type mockIndexRepository struct {
+	index *fulltext.Index
+	saves int
+}
+
+func (m *mockIndexRepository) Load() (*fulltext.Index, error) {
+	if m.index == nil {
+		return fulltext.NewIndex(), nil
+	}
+	return m.index, nil
+}
+
+func (m *mockIndexRepository) Save(index *fulltext.Index) error {
+	m.index = index
+	m.saves++
+	return nil
+}
+
+func TestUpdateContentIndex_IndexesSnapshots(t *testing.T) {
+	repo := &mockRepositoryForCheck{}
+	store := &mockSnapshotStore{}
+	archived := bookmarktest.New(t, "https://example.com/garden")
+	digest, _ := store.Put([]byte("<p>Growing tomatoes</p>"))
+	archived.Snapshot = bookmark.Snapshot{Digest: digest}
+	repo.Add(archived)
+	repo.Add(bookmarktest.New(t, "https://example.com/live"))
+	index := &mockIndexRepository{}
+	capturer := &mockCapturerForArchive{content: []byte("<p>Live page</p>")}
+	uc := usecase.NewUpdateContentIndex(repo, index, store, capturer)
+
+	output, err := uc.Execute(usecase.UpdateContentIndexInput{})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if output.Indexed != 1 || len(output.Errors) != 0 {
+		t.Errorf("expected only the snapshot to be indexed, got %+v", output)
+	}
+	if hits := index.index.Search("tomatoes"); len(hits) != 1 || hits[0].ID != archived.ID.Value() {
+		t.Errorf("expected the snapshot text to be searchable, got %v", hits)
+	}
+
+	// Unchanged snapshots are not indexed again.
+	output, err = uc.Execute(usecase.UpdateContentIndexInput{})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	if output.Indexed != 0 || index.saves != 1 {
+		t.Errorf("expected nothing to be indexed again, got %+v after %d saves", output, index.saves)
+	}
+}
+
+func TestUpdateContentIndex_FetchesLivePages(t *testing.T) {
+	repo := &mockRepositoryForCheck{}
+	live := bookmarktest.New(t, "https://example.com/live")
+	repo.Add(live)
+	repo.Add(bookmarktest.New(t, "https://example.com/search?q=%s"))
+	index := &mockIndexRepository{}
+	capturer := &mockCapturerForArchive{content: []byte("<p>Scheduler latency</p>")}
+	uc := usecase.NewUpdateContentIndex(repo, index, &mockSnapshotStore{}, capturer)
+
+	output, err := uc.Execute(usecase.UpdateContentIndexInput{Fetch: true})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if output.Indexed != 1 {
+		t.Errorf("expected the live page to be indexed, got %+v", output)
+	}
+	if hits := index.index.Search("latency"); len(hits) != 1 || hits[0].ID != live.ID.Value() {
+		t.Errorf("expected the live page text to be searchable, got %v", hits)
+	}
+}
+
+func TestUpdateContentIndex_RemovesDeletedBookmarks(t *testing.T) {
+	repo := &mockRepositoryForCheck{}
+	index := &mockIndexRepository{index: fulltext.NewIndex(fulltext.NewDocument("deleted", "", "old page"))}
+	uc := usecase.NewUpdateContentIndex(repo, index, &mockSnapshotStore{}, &mockCapturerForArchive{})
+
+	output, err := uc.Execute(usecase.UpdateContentIndexInput{})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if output.Removed != 1 || len(index.index.Documents()) != 0 {
+		t.Errorf("expected the document to be removed, got %+v", output)
+	}
+}
+
+func TestUpdateContentIndex_ReportsFailuresAndContinues(t *testing.T) {
+	repo := &mockRepositoryForCheck{}
+	store := &mockSnapshotStore{}
+	broken := bookmarktest.New(t, "https://example.com/broken")
+	broken.Snapshot = bookmark.Snapshot{Digest: snapshot.Digest([]byte("lost"))}
+	repo.Add(broken)
+	archived := bookmarktest.New(t, "https://example.com/ok")
+	digest, _ := store.Put([]byte("<p>fine</p>"))
+	archived.Snapshot = bookmark.Snapshot{Digest: digest}
+	repo.Add(archived)
+	index := &mockIndexRepository{}
+	uc := usecase.NewUpdateContentIndex(repo, index, store, &mockCapturerForArchive{})
+
+	output, err := uc.Execute(usecase.UpdateContentIndexInput{})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if output.Indexed != 1 || len(output.Errors) != 1 || !errors.Is(output.Errors[0], snapshot.ErrNotFound) {
+		t.Errorf("expected one page indexed and one failure, got %+v", output)
+	}
+}
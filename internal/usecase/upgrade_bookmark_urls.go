@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+)
+
+type UpgradeBookmarkURLsInput struct {
+	Upgrades []URLUpgrade
+}
+
+type UpgradeBookmarkURLs struct {
+	repo bookmark.Repository
+}
+
+func NewUpgradeBookmarkURLs(repo bookmark.Repository) *UpgradeBookmarkURLs {
+	return &UpgradeBookmarkURLs{repo: repo}
+}
+
+// Execute replaces the URLs of the bookmarks with their proposed URLs and
+// returns the bookmarks that changed. Upgrades of the same bookmark are
+// applied together.
+func (uc *UpgradeBookmarkURLs) Execute(input UpgradeBookmarkURLsInput) ([]bookmark.Bookmark, error) {
+	if len(input.Upgrades) == 0 {
+		return nil, nil
+	}
+
+	now := time.Now()
+	var updated []bookmark.Bookmark
+	index := make(map[bookmark.BookmarkID]int)
+	for _, upgrade := range input.Upgrades {
+		i, ok := index[upgrade.Bookmark.ID]
+		if !ok {
+			i = len(updated)
+			index[upgrade.Bookmark.ID] = i
+			bm := upgrade.Bookmark
+			bm.Variants = maps.Clone(bm.Variants)
+			bm.UpdatedAt = now
+			updated = append(updated, bm)
+		}
+		if upgrade.Variant == (bookmark.BookmarkVariantName{}) {
+			updated[i].URL = upgrade.URL
+		} else {
+			updated[i].Variants[upgrade.Variant] = upgrade.URL
+		}
+	}
+
+	if err := uc.repo.UpdateAll(updated); err != nil {
+		return nil, fmt.Errorf("failed to update bookmark URLs: %w", err)
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,71 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/bookmark/bookmarktest"
+	"github.com/airRnot1106/bkm/internal/usecase"
+)
+
+func TestUpgradeBookmarkURLs_ReplacesURLs(t *testing.T) {
+	repo := &mockRepositoryForCheck{}
+	old := bookmarktest.New(t, "http://example.com/old")
+	repo.Add(old)
+	newURL, _ := bookmark.NewBookmarkURL("https://example.com/new")
+	uc := usecase.NewUpgradeBookmarkURLs(repo)
+
+	updated, err := uc.Execute(usecase.UpgradeBookmarkURLsInput{Upgrades: []usecase.URLUpgrade{{Bookmark: old, URL: newURL}}})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if len(repo.updated) != 1 || repo.updated[0].URL != newURL {
+		t.Fatalf("expected the stored URL to be replaced, got %+v", repo.updated)
+	}
+	if updated[0].ID != old.ID || updated[0].Title != old.Title {
+		t.Errorf("expected the rest of the bookmark to be kept, got %+v", updated[0])
+	}
+	if !updated[0].UpdatedAt.After(old.UpdatedAt) {
+		t.Error("expected UpdatedAt to be refreshed")
+	}
+}
+
+func TestUpgradeBookmarkURLs_ReplacesVariantsWithThePrimaryURL(t *testing.T) {
+	repo := &mockRepositoryForCheck{}
+	old := bookmarktest.New(t, "https://example.com/?utm_source=x")
+	name, _ := bookmark.NewBookmarkVariantName("staging")
+	oldVariant, _ := bookmark.NewBookmarkURL("https://staging.example.com/?utm_source=x")
+	old.Variants = map[bookmark.BookmarkVariantName]bookmark.BookmarkURL{name: oldVariant}
+	repo.Add(old)
+	newURL, _ := bookmark.NewBookmarkURL("https://example.com/")
+	newVariant, _ := bookmark.NewBookmarkURL("https://staging.example.com/")
+	uc := usecase.NewUpgradeBookmarkURLs(repo)
+
+	updated, err := uc.Execute(usecase.UpgradeBookmarkURLsInput{Upgrades: []usecase.URLUpgrade{
+		{Bookmark: old, URL: newURL},
+		{Bookmark: old, Variant: name, URL: newVariant},
+	}})
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if len(updated) != 1 || updated[0].URL != newURL || updated[0].Variants[name] != newVariant {
+		t.Fatalf("expected both URLs to be replaced in one bookmark, got %+v", updated)
+	}
+	if old.Variants[name] != oldVariant {
+		t.Error("expected the given bookmark not to be modified")
+	}
+}
+
+func TestUpgradeBookmarkURLs_NothingToUpgrade(t *testing.T) {
+	repo := &mockRepositoryForCheck{}
+	uc := usecase.NewUpgradeBookmarkURLs(repo)
+
+	if _, err := uc.Execute(usecase.UpgradeBookmarkURLsInput{}); err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+	if repo.updated != nil {
+		t.Error("expected the repository not to be written")
+	}
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/secretscan"
+)
+
+// URLCleaner removes tracking parameters from URLs.
+type URLCleaner interface {
+	Clean(rawURL string) string
+}
+
+// URLPolicy prepares URLs before they are stored. Every use case that writes
+// a URL given by the user goes through it: tracking parameters are removed
+// first, then URLs with secrets are handled by the policy of the guard, and
+// only schemes in the allowlist are accepted.
+type URLPolicy struct {
+	cleaner URLCleaner
+	guard   *secretscan.Guard
+	schemes bookmark.SchemeAllowlist
+}
+
+func NewURLPolicy(cleaner URLCleaner, guard *secretscan.Guard, schemes bookmark.SchemeAllowlist) *URLPolicy {
+	return &URLPolicy{cleaner: cleaner, guard: guard, schemes: schemes}
+}
+
+// Apply returns the URL to store in place of rawURL.
+func (p *URLPolicy) Apply(rawURL string) (bookmark.BookmarkURL, error) {
+	checked, err := p.guard.Check(p.cleaner.Clean(rawURL))
+	if err != nil {
+		return bookmark.BookmarkURL{}, err
+	}
+	url, err := bookmark.NewBookmarkURL(checked)
+	if err != nil {
+		return bookmark.BookmarkURL{}, fmt.Errorf("invalid URL: %w", err)
+	}
+	if !p.schemes.Allows(url.Scheme()) {
+		return bookmark.BookmarkURL{}, fmt.Errorf("invalid URL: scheme %q is not allowed", url.Scheme())
+	}
+	return url, nil
+}
+
+// applyVariants parses the variant URLs. Those that are the same as in
+// current are kept as they are, so that editing a bookmark does not change
+// URLs the user did not touch.
+func (p *URLPolicy) applyVariants(raw map[string]string, current map[bookmark.BookmarkVariantName]bookmark.BookmarkURL) (map[bookmark.BookmarkVariantName]bookmark.BookmarkURL, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	variants := make(map[bookmark.BookmarkVariantName]bookmark.BookmarkURL, len(raw))
+	for rawName, rawURL := range raw {
+		name, err := bookmark.NewBookmarkVariantName(rawName)
+		if err != nil {
+			return nil, fmt.Errorf("invalid variant name %q: %w", rawName, err)
+		}
+		if url, ok := current[name]; ok && url.Value() == rawURL {
+			variants[name] = url
+			continue
+		}
+		url, err := p.Apply(rawURL)
+		if err != nil {
+			return nil, fmt.Errorf("variant %q: %w", rawName, err)
+		}
+		variants[name] = url
+	}
+	return variants, nil
+}
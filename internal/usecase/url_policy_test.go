@@ -0,0 +1,72 @@
+package usecase_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/airRnot1106/bkm/internal/bookmark"
+	"github.com/airRnot1106/bkm/internal/secretscan"
+	"github.com/airRnot1106/bkm/internal/urlclean"
+	"github.com/airRnot1106/bkm/internal/usecase"
+)
+
+func newURLPolicy(rules []urlclean.Rule, policy secretscan.Policy) *usecase.URLPolicy {
+	return usecase.NewURLPolicy(urlclean.NewCleaner(rules), secretscan.NewGuard(secretscan.NewScanner(), policy), bookmark.NewSchemeAllowlist())
+}
+
+func TestURLPolicy_CleansBeforeCheckingSecrets(t *testing.T) {
+	rules := []urlclean.Rule{{Domain: "example.com", Remove: []string{"token"}}}
+	policy := newURLPolicy(rules, secretscan.PolicyRefuse)
+
+	url, err := policy.Apply("https://example.com/?id=1&token=abc")
+	if err != nil {
+		t.Fatalf("expected success, got error: %v", err)
+	}
+
+	if url.Value() != "https://example.com/?id=1" {
+		t.Errorf("expected the cleaned URL, got %s", url.Value())
+	}
+}
+
+func TestURLPolicy_RefusesSecrets(t *testing.T) {
+	policy := newURLPolicy(nil, secretscan.PolicyRefuse)
+
+	if _, err := policy.Apply("https://example.com/feed?token=abc"); !errors.Is(err, secretscan.ErrSecretFound) {
+		t.Errorf("expected ErrSecretFound, got %v", err)
+	}
+}
+
+func TestURLPolicy_InvalidURLFails(t *testing.T) {
+	policy := newURLPolicy(nil, secretscan.PolicyRefuse)
+
+	if _, err := policy.Apply("not a url"); err == nil {
+		t.Error("expected an invalid URL to fail")
+	}
+}
+
+func TestURLPolicy_KeepsPlaceholdersForSecrets(t *testing.T) {
+	policy := newURLPolicy(nil, secretscan.PolicyRefuse)
+
+	for _, rawURL := range []string{"https://api.example.com/?token=${API_TOKEN}", "https://api.example.com/?token=%s"} {
+		url, err := policy.Apply(rawURL)
+		if err != nil {
+			t.Fatalf("expected %s to be accepted, got error: %v", rawURL, err)
+		}
+		if url.Value() != rawURL {
+			t.Errorf("expected %s, got %s", rawURL, url.Value())
+		}
+	}
+}
+
+func TestURLPolicy_OnlyAcceptsAllowedSchemes(t *testing.T) {
+	rawURL := "obsidian://open?vault=work"
+	guard := secretscan.NewGuard(secretscan.NewScanner(), secretscan.PolicyRefuse)
+
+	if _, err := newURLPolicy(nil, secretscan.PolicyRefuse).Apply(rawURL); err == nil {
+		t.Errorf("expected %s to be refused without the scheme in the allowlist", rawURL)
+	}
+	policy := usecase.NewURLPolicy(urlclean.NewCleaner(nil), guard, bookmark.NewSchemeAllowlist("obsidian"))
+	if _, err := policy.Apply(rawURL); err != nil {
+		t.Errorf("expected %s to be accepted, got error: %v", rawURL, err)
+	}
+}